@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"cyber/internal/auth"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("authentication required")
+	ErrForbidden    = errors.New("access denied")
+)
+
+// MaxAuthBodySize - максимальный размер тела запросов регистрации и входа в байтах.
+const MaxAuthBodySize = 4 * 1024
+
+// RegisterRequest - тело запроса регистрации.
+type RegisterRequest struct {
+	Login    string `json:"login"`    // Логин пользователя
+	Email    string `json:"email"`    // Email пользователя
+	Password string `json:"password"` // Пароль пользователя
+}
+
+// LoginRequest - тело запроса входа.
+type LoginRequest struct {
+	Login    string `json:"login"`    // Логин пользователя
+	Password string `json:"password"` // Пароль пользователя
+}
+
+// userIdKey - ключ идентификатора пользователя в контексте запроса и в сессии websocket соединения.
+type userIdKey struct{}
+
+// Authenticator определяет пользователя HTTP запроса по токену сессии.
+// Токен передается в заголовке "Authorization: Bearer <token>" или, для websocket
+// соединений из браузера, в параметре запроса token.
+type Authenticator struct {
+	tokens *auth.Tokens
+}
+
+// Конструктор для Authenticator.
+func NewAuthenticator(tokens *auth.Tokens) *Authenticator {
+	return &Authenticator{tokens: tokens}
+}
+
+// UserId возвращает идентификатор пользователя из токена сессии запроса.
+func (a *Authenticator) UserId(r *http.Request) (int64, error) {
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, value, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return 0, fmt.Errorf("%w: unsupported authorization scheme", ErrUnauthorized)
+		}
+		token = value
+	}
+	if token == "" {
+		return 0, ErrUnauthorized
+	}
+	return a.tokens.Verify(token)
+}
+
+// Require пропускает к next только запросы с действующим токеном сессии.
+// Идентификатор пользователя передается next в контексте запроса, см. requestUserId.
+func (a *Authenticator) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userId, err := a.UserId(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userIdKey{}, userId)))
+	})
+}
+
+// requestUserId возвращает идентификатор пользователя, сохраненный Authenticator.Require.
+func requestUserId(r *http.Request) (int64, bool) {
+	userId, ok := r.Context().Value(userIdKey{}).(int64)
+	return userId, ok
+}
+
+// AuthHandler обслуживает REST endpoints регистрации и входа.
+type AuthHandler struct {
+	service *auth.Service
+}
+
+// Конструктор для AuthHandler.
+func NewAuthHandler(service *auth.Service) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Register регистрирует пользователя и отвечает 201 с токеном его сессии.
+func (ah *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+	if err := decodePost(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	session, err := ah.service.Register(req.Login, req.Email, req.Password)
+	if err != nil {
+		log.Printf("cant register user %v: %v\n", req.Login, err)
+		writeError(w, err)
+		return
+	}
+	writeSession(w, http.StatusCreated, session)
+}
+
+// Login проверяет логин и пароль и отвечает 200 с токеном сессии.
+func (ah *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := decodePost(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	session, err := ah.service.Login(req.Login, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeSession(w, http.StatusOK, session)
+}
+
+// decodePost читает JSON тело POST запроса. Тело больше MaxAuthBodySize отклоняется с кодом 413.
+func decodePost(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		return &Error{Code: http.StatusMethodNotAllowed, Message: http.StatusText(http.StatusMethodNotAllowed)}
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxAuthBodySize)).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &Error{Code: http.StatusRequestEntityTooLarge, Message: http.StatusText(http.StatusRequestEntityTooLarge)}
+		}
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	return nil
+}
+
+func writeSession(w http.ResponseWriter, status int, session auth.Session) {
+	payload, err := json.Marshal(session)
+	if err != nil {
+		writeError(w, fmt.Errorf("marshal to JSON error: %v", err))
+		return
+	}
+	writeJSON(w, status, Envelope{Version: ProtocolVersion, Type: "session", Payload: payload})
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lxzan/gws"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/auth"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+var testTokens = auth.NewTokens([]byte("test secret"), time.Hour)
+
+// testToken выдает токен сессии пользователю userId.
+func testToken(userId int64) string {
+	token, _ := testTokens.Issue(userId)
+	return token
+}
+
+func newTestAuthenticator() *Authenticator {
+	return NewAuthenticator(testTokens)
+}
+
+// newTestClientOption - параметры websocket клиента пользователя userId.
+func newTestClientOption(addr string, userId int64) *gws.ClientOption {
+	return &gws.ClientOption{
+		Addr:          "ws://" + addr,
+		RequestHeader: http.Header{"Authorization": []string{"Bearer " + testToken(userId)}},
+	}
+}
+
+// fakeUsers - пользователи в памяти
+type fakeUsers struct {
+	mu    sync.Mutex
+	users []models.User
+}
+
+func (f *fakeUsers) AddUser(u models.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, existing := range f.users {
+		if existing.Login == u.Login {
+			return storage.ErrAlreadyExists
+		}
+	}
+	if !storage.IsEmailValid(u.Email) {
+		return storage.ErrInvalidEmail
+	}
+	u.Id = int64(len(f.users) + 1)
+	f.users = append(f.users, u)
+	return nil
+}
+
+func (f *fakeUsers) GetUserByLogin(login string) (models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if u.Login == login {
+			return u, nil
+		}
+	}
+	return models.User{}, storage.ErrNotFound
+}
+
+func TestAuthHandler(t *testing.T) {
+	accounts := NewAuthHandler(auth.NewService(&fakeUsers{}, testTokens))
+	wsServer := NewWebsocketServer(NewWebSocketHandler(nil, nil, nil, nil, nil, nil), newTestAuthenticator())
+	wsServer.Handle("/register", http.HandlerFunc(accounts.Register))
+	wsServer.Handle("/login", http.HandlerFunc(accounts.Login))
+
+	// Шаги выполняются по порядку: вход возможен только после регистрации
+	steps := []struct {
+		name          string
+		method        string
+		target        string
+		body          string
+		expectedCode  int
+		expectedError int
+	}{
+		{"Register", http.MethodPost, "/register", `{"login":"user1","email":"user1@example.com","password":"password1"}`, http.StatusCreated, 0},
+		{"Login taken", http.MethodPost, "/register", `{"login":"user1","email":"other@example.com","password":"password1"}`, http.StatusConflict, http.StatusConflict},
+		{"Weak password", http.MethodPost, "/register", `{"login":"user2","email":"user2@example.com","password":"short"}`, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid email", http.MethodPost, "/register", `{"login":"user2","email":"user2","password":"password1"}`, http.StatusBadRequest, http.StatusBadRequest},
+		{"Login", http.MethodPost, "/login", `{"login":"user1","password":"password1"}`, http.StatusOK, 0},
+		{"Wrong password", http.MethodPost, "/login", `{"login":"user1","password":"password2"}`, http.StatusUnauthorized, http.StatusUnauthorized},
+		{"Unknown login", http.MethodPost, "/login", `{"login":"user9","password":"password1"}`, http.StatusUnauthorized, http.StatusUnauthorized},
+		{"Invalid body", http.MethodPost, "/login", `{"login":`, http.StatusBadRequest, http.StatusBadRequest},
+		{"Method not allowed", http.MethodGet, "/login", "", http.StatusMethodNotAllowed, http.StatusMethodNotAllowed},
+		{"Body too large", http.MethodPost, "/register", `{"login":"user3","email":"user3@example.com","password":"` + strings.Repeat("a", MaxAuthBodySize) + `"}`, http.StatusRequestEntityTooLarge, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range steps {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			wsServer.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+			// Проверяем HTTP статус и формат ответа
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			var response Envelope
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			if tt.expectedError != 0 {
+				assert.Equal(t, ErrorType, response.Type)
+				assert.Equal(t, tt.expectedError, response.Error.Code)
+				return
+			}
+
+			// Проверяем, что выданный токен принадлежит пользователю
+			assert.Equal(t, "session", response.Type)
+			var session auth.Session
+			assert.NoError(t, json.Unmarshal(response.Payload, &session))
+			userId, err := testTokens.Verify(session.Token)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), userId)
+			assert.Equal(t, int64(1), session.UserId)
+		})
+	}
+}
+
+// messageHandler передает полученные клиентом сообщения в канал
+type messageHandler struct {
+	gws.BuiltinEventHandler
+	messages chan Envelope
+}
+
+func (m *messageHandler) OnMessage(socket *gws.Conn, message *gws.Message) {
+	defer message.Close()
+	var env Envelope
+	if err := json.Unmarshal(message.Bytes(), &env); err == nil {
+		m.messages <- env
+	}
+}
+
+func TestWebSocketUpgradeRequiresToken(t *testing.T) {
+	handler := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	handler.actionHandlers["whoami"] = &userEchoHandler{}
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+
+	expired := auth.NewTokens([]byte("test secret"), time.Nanosecond)
+	expiredToken, _ := expired.Issue(1)
+	for name, target := range map[string]string{
+		"No token":      "/",
+		"Invalid token": "/?token=abc.def",
+		"Expired token": "/?token=" + expiredToken,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+			r.Header.Set("Connection", "Upgrade")
+			r.Header.Set("Upgrade", "websocket")
+			r.Header.Set("Sec-WebSocket-Version", "13")
+			r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			rec := httptest.NewRecorder()
+			wsServer.ServeHTTP(rec, r)
+
+			// Проверяем, что соединение без действующего токена не открывается
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			var response Envelope
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, http.StatusUnauthorized, response.Error.Code)
+		})
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go wsServer.Serve(ln)
+	defer wsServer.Shutdown(context.Background())
+
+	// Браузер не может задать заголовок websocket запроса, поэтому токен передается в параметре
+	client := &messageHandler{messages: make(chan Envelope, 1)}
+	socket, _, err := gws.NewClient(client, &gws.ClientOption{Addr: "ws://" + ln.Addr().String() + "/?token=" + testToken(7)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+
+	// Проверяем, что действие выполняется от имени пользователя токена, а не user_id из сообщения
+	assert.NoError(t, socket.WriteString(`{"type":"whoami","request_id":"w1","payload":{"user_id":1}}`))
+	select {
+	case response := <-client.messages:
+		assert.Equal(t, "w1", response.RequestId)
+		assert.JSONEq(t, `{"user_id":7}`, string(response.Payload))
+	case <-time.After(time.Second):
+		t.Fatal("no response")
+	}
+}
@@ -0,0 +1,301 @@
+package server
+
+import (
+	"cyber/internal/game"
+	"cyber/internal/models"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found on area")
+	ErrStalePosition  = errors.New("object is not at the given position")
+)
+
+// AccessStore - хранилище, по которому проверяются права пользователя. Реализуется *postgress.Storage.
+type AccessStore interface {
+	GetArea(areaId int64) (models.Area, error)
+	GetAreaObjectKinds(areaId, objectId int64) ([]models.ObjectKind, error)
+	GetHeroes(areaId int64) ([]models.Hero, error)
+	GetUnits(areaId int64) ([]models.Unit, error)
+}
+
+// actionRule - ограничения на объекты действия.
+type actionRule struct {
+	source []models.ObjectKind // допустимые типы объекта-источника, nil - источник не нужен
+	target []models.ObjectKind // допустимые типы объекта-цели, nil - цель не нужна
+	// objects возвращает ID источника и цели действия. nil - ObjectSourceId и ObjectDestId
+	objects func(action *models.Action) (source, target int64, err error)
+	// group возвращает ID источников группового действия, каждый проверяется по source.
+	// nil - источник один
+	group func(action *models.Action) ([]int64, error)
+	// kind возвращает тип объекта-источника, указанный клиентом. Источник проверяется
+	// только по этому типу, так как ID героев и юнитов могут совпадать. nil - любой тип из source
+	kind func(action *models.Action) (models.ObjectKind, error)
+	// from возвращает клетку, в которой должен стоять герой или юнит источника. nil - положение не проверяется
+	from func(action *models.Action) (models.Hex, error)
+}
+
+// actionRules - правила действий, работающих с ареной. Действия без правила арену не затрагивают.
+var actionRules = map[string]actionRule{
+	"move": {source: []models.ObjectKind{models.HeroObject, models.UnitObject}, from: moveFrom},
+	"harvest": {
+		source:  []models.ObjectKind{models.UnitObject},
+		target:  []models.ObjectKind{models.NeutralObject},
+		objects: harvestObjects,
+	},
+	"build": {source: []models.ObjectKind{models.UnitObject}, objects: buildObjects},
+	"attack": {
+		source:  []models.ObjectKind{models.HeroObject, models.UnitObject},
+		target:  []models.ObjectKind{models.EnemyObject},
+		objects: attackObjects,
+		kind:    attackerKind,
+	},
+	"upgrade":       {source: []models.ObjectKind{models.BuildingObject}, objects: upgradeObjects},
+	"group_move":    {source: []models.ObjectKind{models.UnitObject}, group: groupMoveObjects},
+	"get_area_data": {},
+}
+
+// Authorizer проверяет, что пользователь может выполнить действие: арена принадлежит ему,
+// объект-источник находится на этой арене и управляется пользователем, а цель - допустимого типа.
+// Чужая арена и объект недопустимого типа - 403, отсутствующие арена или объект - 404.
+// Перемещение разрешено только из клетки, в которой объект сохранен в БД, иначе - 409.
+// Групповое действие с объектами больше maxGroup отклоняется с 400 еще до обращения к БД.
+type Authorizer struct {
+	store    AccessStore
+	maxGroup int
+}
+
+// Конструктор для Authorizer. maxGroup - максимальное количество объектов группового
+// действия, 0 - game.DefaultAreasConfig.MaxGroupUnits.
+func NewAuthorizer(store AccessStore, maxGroup int) *Authorizer {
+	if maxGroup <= 0 {
+		maxGroup = game.DefaultAreasConfig.MaxGroupUnits
+	}
+	return &Authorizer{store: store, maxGroup: maxGroup}
+}
+
+// Authorize проверяет права пользователя action.UserId на действие action и возвращает арену действия.
+// Для действий, не затрагивающих арену, возвращается пустая арена.
+func (a *Authorizer) Authorize(action *models.Action) (models.Area, error) {
+	rule, ok := actionRules[action.ActionType]
+	if !ok {
+		return models.Area{}, nil
+	}
+	area, err := a.AuthorizeArea(action.UserId, action.AreaId)
+	if err != nil {
+		return models.Area{}, err
+	}
+
+	source, target := action.ObjectSourceId, action.ObjectDestId
+	if rule.objects != nil {
+		if source, target, err = rule.objects(action); err != nil {
+			return models.Area{}, err
+		}
+	}
+	if rule.group != nil {
+		units, err := rule.group(action)
+		if err != nil {
+			return models.Area{}, err
+		}
+		if len(units) > a.maxGroup {
+			return models.Area{}, fmt.Errorf("%w: at most %d units can act together", ErrBadRequest, a.maxGroup)
+		}
+		for _, unit := range units {
+			if err := a.checkObject(action.AreaId, unit, rule.source, "units"); err != nil {
+				return models.Area{}, err
+			}
+		}
+	} else if rule.source != nil {
+		kinds := rule.source
+		if rule.kind != nil {
+			kind, err := rule.kind(action)
+			if err != nil {
+				return models.Area{}, err
+			}
+			if kind == "" {
+				return models.Area{}, fmt.Errorf("%w: object_source_id kind required", ErrBadRequest)
+			}
+			if !slices.Contains(rule.source, kind) {
+				return models.Area{}, fmt.Errorf("%w: object kind %q, expected %v", ErrBadRequest, kind, rule.source)
+			}
+			kinds = []models.ObjectKind{kind}
+		}
+		if err := a.checkObject(action.AreaId, source, kinds, "object_source_id"); err != nil {
+			return models.Area{}, err
+		}
+	}
+	if rule.from != nil {
+		from, err := rule.from(action)
+		if err != nil {
+			return models.Area{}, err
+		}
+		if err := a.checkPosition(action.AreaId, source, from); err != nil {
+			return models.Area{}, err
+		}
+	}
+	if rule.target != nil {
+		if err := a.checkObject(action.AreaId, target, rule.target, "object_dest_id"); err != nil {
+			return models.Area{}, err
+		}
+	}
+	return area, nil
+}
+
+// AuthorizeArea проверяет, что арена areaId принадлежит пользователю userId, и возвращает ее.
+func (a *Authorizer) AuthorizeArea(userId, areaId int64) (models.Area, error) {
+	area, err := a.store.GetArea(areaId)
+	if err != nil {
+		return models.Area{}, fmt.Errorf("cant read area %v: %w", areaId, err)
+	}
+	if area.UserId != userId {
+		return models.Area{}, fmt.Errorf("%w: area %v belongs to other user", ErrForbidden, areaId)
+	}
+	return area, nil
+}
+
+// checkObject проверяет, что на арене есть объект objectId одного из типов kinds.
+func (a *Authorizer) checkObject(areaId, objectId int64, kinds []models.ObjectKind, field string) error {
+	if objectId == 0 {
+		return fmt.Errorf("%w: %s required", ErrBadRequest, field)
+	}
+	found, err := a.store.GetAreaObjectKinds(areaId, objectId)
+	if err != nil {
+		return fmt.Errorf("cant read object %v of area %v: %w", objectId, areaId, err)
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("%w: %s %v on area %v", ErrObjectNotFound, field, objectId, areaId)
+	}
+	for _, kind := range found {
+		if slices.Contains(kinds, kind) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s %v is %v, expected %v", ErrForbidden, field, objectId, found, kinds)
+}
+
+// checkPosition проверяет, что герой или юнит objectId арены areaId стоит в клетке from.
+// ID героев и юнитов независимы, поэтому достаточно, чтобы в from стоял любой из них.
+func (a *Authorizer) checkPosition(areaId, objectId int64, from models.Hex) error {
+	heroes, err := a.store.GetHeroes(areaId)
+	if err != nil {
+		return fmt.Errorf("cant read heroes of area %v: %w", areaId, err)
+	}
+	for _, h := range heroes {
+		if h.Id == objectId && slices.Contains(h.Coordinates, from) {
+			return nil
+		}
+	}
+	units, err := a.store.GetUnits(areaId)
+	if err != nil {
+		return fmt.Errorf("cant read units of area %v: %w", areaId, err)
+	}
+	for _, u := range units {
+		if u.Id == objectId && slices.Contains(u.Coordinates, from) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: object_source_id %v is not at %v", ErrStalePosition, objectId, from)
+}
+
+// objectId выбирает ID объекта из поля действия и из характеристик. Если заданы оба, они должны совпадать.
+func objectId(field, characteristic int64) (int64, error) {
+	switch {
+	case characteristic == 0:
+		return field, nil
+	case field == 0 || field == characteristic:
+		return characteristic, nil
+	}
+	return 0, fmt.Errorf("%w: object %v in characteristics differs from %v", ErrBadRequest, characteristic, field)
+}
+
+// actionCharacteristics разбирает характеристики действия. Характеристики необязательны,
+// если ID объектов заданы в полях действия.
+func actionCharacteristics[T any](action *models.Action) (*T, error) {
+	if len(action.Characteristics) == 0 {
+		return new(T), nil
+	}
+	return UnmarshalCharacteristics[T](action.Characteristics)
+}
+
+func harvestObjects(action *models.Action) (int64, int64, error) {
+	c, err := actionCharacteristics[models.HarvestActionCharacteristics](action)
+	if err != nil {
+		return 0, 0, err
+	}
+	source, err := objectId(action.ObjectSourceId, c.Harvester)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, err := objectId(action.ObjectDestId, c.NeutralId)
+	return source, target, err
+}
+
+func buildObjects(action *models.Action) (int64, int64, error) {
+	c, err := actionCharacteristics[models.BuildActionCharacteristics](action)
+	if err != nil {
+		return 0, 0, err
+	}
+	source, err := objectId(action.ObjectSourceId, c.Builder)
+	return source, action.ObjectDestId, err
+}
+
+func attackObjects(action *models.Action) (int64, int64, error) {
+	c, err := actionCharacteristics[models.AttackActionCharacteristics](action)
+	if err != nil {
+		return 0, 0, err
+	}
+	source, err := objectId(action.ObjectSourceId, c.Atacker)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, err := objectId(action.ObjectDestId, c.Defenser)
+	return source, target, err
+}
+
+// attackerKind возвращает тип атакующего объекта из характеристик атаки.
+func attackerKind(action *models.Action) (models.ObjectKind, error) {
+	c, err := actionCharacteristics[models.AttackActionCharacteristics](action)
+	if err != nil {
+		return "", err
+	}
+	return c.AtackerKind, nil
+}
+
+func upgradeObjects(action *models.Action) (int64, int64, error) {
+	c, err := actionCharacteristics[models.UpgradeActionCharacteristics](action)
+	if err != nil {
+		return 0, 0, err
+	}
+	source, err := objectId(action.ObjectSourceId, c.BuildingId)
+	return source, action.ObjectDestId, err
+}
+
+// moveFrom возвращает начальную точку перемещения. Без характеристик начальная точка неизвестна.
+func moveFrom(action *models.Action) (models.Hex, error) {
+	if len(action.Characteristics) == 0 {
+		return models.Hex{}, fmt.Errorf("%w: characteristics required", ErrBadRequest)
+	}
+	c, err := UnmarshalCharacteristics[models.MoveActionCharacteristics](action.Characteristics)
+	if err != nil {
+		return models.Hex{}, err
+	}
+	return c.From, nil
+}
+
+func groupMoveObjects(action *models.Action) ([]int64, error) {
+	c, err := actionCharacteristics[models.GroupMoveActionCharacteristics](action)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Units) == 0 {
+		return nil, fmt.Errorf("%w: units required", ErrBadRequest)
+	}
+	for i, unit := range c.Units {
+		if slices.Contains(c.Units[:i], unit) {
+			return nil, fmt.Errorf("%w: unit %v is listed twice", ErrBadRequest, unit)
+		}
+	}
+	return c.Units, nil
+}
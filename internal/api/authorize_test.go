@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeActions(t *testing.T) {
+	h := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	h.authorizer = NewAuthorizer(&fakeWorld{}, 2)
+	for _, actionType := range []string{"move", "harvest", "build", "attack", "upgrade", "group_move"} {
+		h.actionHandlers[actionType] = &userEchoHandler{}
+	}
+
+	// Пользователь 1 владеет ареной 4: нейтрал 1, здание 2, герой 3 в (0, 0), юнит 5 в (19, 20), враг 6.
+	// Арена 8 принадлежит пользователю 2. В групповом перемещении не больше 2 юнитов
+	tests := []struct {
+		name         string
+		message      string
+		expectedCode int // 0 - действие разрешено
+	}{
+		{"Move own hero", `{"type":"move","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":0,"r":0},"to":{"q":1,"r":1}}}}`, 0},
+		{"Move own unit", `{"type":"move","payload":{"area_id":4,"object_source_id":5,"characteristics":{"from":{"q":19,"r":20},"to":{"q":1,"r":1}}}}`, 0},
+		{"Move hero from other cell", `{"type":"move","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":19,"r":20},"to":{"q":1,"r":1}}}}`, 409},
+		{"Move unit from other cell", `{"type":"move","payload":{"area_id":4,"object_source_id":5,"characteristics":{"from":{"q":0,"r":0},"to":{"q":1,"r":1}}}}`, 409},
+		{"Move without characteristics", `{"type":"move","payload":{"area_id":4,"object_source_id":3}}`, 400},
+		{"Move on other user area", `{"type":"move","payload":{"area_id":8,"object_source_id":3}}`, 403},
+		{"Move enemy", `{"type":"move","payload":{"area_id":4,"object_source_id":6}}`, 403},
+		{"Move building", `{"type":"move","payload":{"area_id":4,"object_source_id":2}}`, 403},
+		{"Move object from other area", `{"type":"move","payload":{"area_id":4,"object_source_id":7}}`, 404},
+		{"Move without object", `{"type":"move","payload":{"area_id":4}}`, 400},
+		{"Unknown area", `{"type":"move","payload":{"area_id":7,"object_source_id":3}}`, 404},
+		{"Harvest neutral", `{"type":"harvest","payload":{"area_id":4,"object_source_id":5,"object_dest_id":1}}`, 0},
+		{"Harvest neutral from characteristics", `{"type":"harvest","payload":{"area_id":4,"characteristics":{"harvester":5,"neutral_id":1}}}`, 0},
+		{"Harvest enemy", `{"type":"harvest","payload":{"area_id":4,"object_source_id":5,"object_dest_id":6}}`, 403},
+		{"Harvest by hero", `{"type":"harvest","payload":{"area_id":4,"object_source_id":3,"object_dest_id":1}}`, 403},
+		{"Harvest missing neutral", `{"type":"harvest","payload":{"area_id":4,"object_source_id":5,"object_dest_id":9}}`, 404},
+		{"Harvester differs from source", `{"type":"harvest","payload":{"area_id":4,"object_source_id":5,"object_dest_id":1,"characteristics":{"harvester":3}}}`, 400},
+		{"Build by unit", `{"type":"build","payload":{"area_id":4,"characteristics":{"builder":5,"object":"CyMan miner house","construction_time":60,"place":{"q":10,"r":10}}}}`, 0},
+		{"Attack enemy", `{"type":"attack","payload":{"area_id":4,"object_source_id":3,"object_dest_id":6,"characteristics":{"atacker_kind":"hero"}}}`, 0},
+		{"Attack own unit", `{"type":"attack","payload":{"area_id":4,"object_source_id":3,"object_dest_id":5,"characteristics":{"atacker_kind":"hero"}}}`, 403},
+		{"Attack by hero given as unit", `{"type":"attack","payload":{"area_id":4,"object_source_id":3,"object_dest_id":6,"characteristics":{"atacker_kind":"unit"}}}`, 403},
+		{"Attack without attacker kind", `{"type":"attack","payload":{"area_id":4,"object_source_id":3,"object_dest_id":6}}`, 400},
+		{"Upgrade own building", `{"type":"upgrade","payload":{"area_id":4,"characteristics":{"building_id":2}}}`, 0},
+		{"Upgrade hero", `{"type":"upgrade","payload":{"area_id":4,"characteristics":{"building_id":3}}}`, 403},
+		{"Upgrade on other user area", `{"type":"upgrade","payload":{"area_id":8,"characteristics":{"building_id":2}}}`, 403},
+		{"Group move own unit", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[5],"to":{"q":1,"r":1}}}}`, 0},
+		{"Group move hero", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[5,3],"to":{"q":1,"r":1}}}}`, 403},
+		{"Group move without units", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[],"to":{"q":1,"r":1}}}}`, 400},
+		{"Group move same unit twice", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[5,5],"to":{"q":1,"r":1}}}}`, 400},
+		{"Group move too many units", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[5,7,9],"to":{"q":1,"r":1}}}}`, 400},
+		{"Group move missing unit", `{"type":"group_move","payload":{"area_id":4,"characteristics":{"units":[5,9],"to":{"q":1,"r":1}}}}`, 404},
+		{"Read other user area", `{"type":"get_area_data","payload":{"area_id":8}}`, 403},
+		{"Subscribe to own area", `{"type":"subscribe","payload":{"area_id":4}}`, 0},
+		{"Subscribe to other user area", `{"type":"subscribe","payload":{"area_id":8}}`, 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := h.process(h.registry.Add(&fakeSocket{}, 1), []byte(tt.message))
+
+			// Проверяем, что чужие объекты и недопустимые цели отклоняются с кодом из контракта ошибок
+			if tt.expectedCode == 0 {
+				assert.Nil(t, response.Error)
+			} else if assert.NotNil(t, response.Error) {
+				assert.Equal(t, tt.expectedCode, response.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"cyber/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SyncWorldType - тип запроса изменений состояния мира.
+const SyncWorldType = "sync_world_state"
+
+var ErrDeltaBase = errors.New("delta does not match state")
+
+// SyncWorldPayload - данные запроса sync_world_state.
+type SyncWorldPayload struct {
+	Ack *int64 `json:"ack,omitempty"` // Последняя версия состояния, примененная клиентом. Без ack - полный снимок
+}
+
+// ObjectRef - ссылка на объект арены. ID объектов разных типов могут совпадать.
+type ObjectRef struct {
+	Kind models.ObjectKind `json:"kind"` // Тип объекта
+	Id   int64             `json:"id"`   // Идентификатор объекта
+}
+
+// ObjectDelta - изменения одного объекта. Передаются только изменившиеся позиция, здоровье и уровень.
+// Новый объект или объект, у которого изменились другие поля, передается целиком в Object.
+type ObjectDelta struct {
+	ObjectRef
+	Coordinates []models.Hex    `json:"coordinates,omitempty"` // Новые координаты
+	HP          *int            `json:"hp,omitempty"`          // Текущее здоровье
+	Level       *int            `json:"level,omitempty"`       // Уровень
+	Object      json.RawMessage `json:"object,omitempty"`      // Объект целиком
+}
+
+// WorldDelta - ответ на запрос sync_world_state: изменения состояния мира с версии Base
+// до версии Version или, при подключении и рассинхронизации, полный снимок.
+type WorldDelta struct {
+	Version   int64         `json:"version"`            // Версия состояния, которую клиент подтверждает в следующем запросе
+	Base      int64         `json:"base,omitempty"`     // Версия, к которой применяются изменения
+	Timestamp time.Time     `json:"timestamp"`          // Время, на которое получено состояние
+	Changed   []ObjectDelta `json:"changed,omitempty"`  // Новые и изменившиеся объекты
+	Removed   []ObjectRef   `json:"removed,omitempty"`  // Удаленные объекты
+	Snapshot  *WorldState   `json:"snapshot,omitempty"` // Полное состояние вместо изменений
+}
+
+// objectFields - доступ к полям объекта арены типа T, изменения которых передаются отдельно.
+type objectFields[T any] struct {
+	kind   models.ObjectKind
+	id     func(o *T) int64
+	coords func(o *T) *[]models.Hex
+	hp     func(o *T) *int // nil - у объекта нет здоровья
+	level  func(o *T) *int // nil - у объекта нет уровня
+}
+
+var (
+	neutralFields = objectFields[models.Neutral]{
+		kind:   models.NeutralObject,
+		id:     func(o *models.Neutral) int64 { return o.Id },
+		coords: func(o *models.Neutral) *[]models.Hex { return &o.Coordinates },
+	}
+	buildingFields = objectFields[models.Building]{
+		kind:   models.BuildingObject,
+		id:     func(o *models.Building) int64 { return o.Id },
+		coords: func(o *models.Building) *[]models.Hex { return &o.Coordinates },
+		hp:     func(o *models.Building) *int { return &o.Charachteristics.HP },
+		level:  func(o *models.Building) *int { return &o.Level },
+	}
+	heroFields = objectFields[models.Hero]{
+		kind:   models.HeroObject,
+		id:     func(o *models.Hero) int64 { return o.Id },
+		coords: func(o *models.Hero) *[]models.Hex { return &o.Coordinates },
+		hp:     func(o *models.Hero) *int { return &o.Charachteristics.HPnow },
+		level:  func(o *models.Hero) *int { return &o.Level },
+	}
+	unitFields = objectFields[models.Unit]{
+		kind:   models.UnitObject,
+		id:     func(o *models.Unit) int64 { return o.Id },
+		coords: func(o *models.Unit) *[]models.Hex { return &o.Coordinates },
+		hp:     func(o *models.Unit) *int { return &o.Charachteristics.HPnow },
+		level:  func(o *models.Unit) *int { return &o.Level },
+	}
+	enemyFields = objectFields[models.Enemy]{
+		kind:   models.EnemyObject,
+		id:     func(o *models.Enemy) int64 { return o.Id },
+		coords: func(o *models.Enemy) *[]models.Hex { return &o.Coordinates },
+		hp:     func(o *models.Enemy) *int { return &o.Charachteristics.HP },
+		level:  func(o *models.Enemy) *int { return &o.Level },
+	}
+)
+
+// diff возвращает изменения объектов от before к after.
+func (f objectFields[T]) diff(before, after []T) ([]ObjectDelta, []ObjectRef, error) {
+	old := make(map[int64]*T, len(before))
+	for i := range before {
+		old[f.id(&before[i])] = &before[i]
+	}
+
+	var changed []ObjectDelta
+	for i := range after {
+		o := &after[i]
+		d := ObjectDelta{ObjectRef: ObjectRef{Kind: f.kind, Id: f.id(o)}}
+		prev, ok := old[d.Id]
+		delete(old, d.Id)
+
+		full := !ok
+		if ok {
+			// Переносим отслеживаемые поля в копию старого объекта: если копия не совпала
+			// с новым объектом, изменились и другие поля
+			patched := *prev
+			if coords := *f.coords(o); !slices.Equal(*f.coords(prev), coords) {
+				d.Coordinates = coords
+				*f.coords(&patched) = coords
+				full = len(coords) == 0
+			}
+			if f.hp != nil && *f.hp(prev) != *f.hp(o) {
+				d.HP = intPtr(*f.hp(o))
+				*f.hp(&patched) = *d.HP
+			}
+			if f.level != nil && *f.level(prev) != *f.level(o) {
+				d.Level = intPtr(*f.level(o))
+				*f.level(&patched) = *d.Level
+			}
+			if !reflect.DeepEqual(patched, *o) {
+				full = true
+			}
+		}
+
+		if full {
+			data, err := json.Marshal(o)
+			if err != nil {
+				return nil, nil, fmt.Errorf("marshal %v %v: %v", f.kind, d.Id, err)
+			}
+			d = ObjectDelta{ObjectRef: d.ObjectRef, Object: data}
+		}
+		if full || d.Coordinates != nil || d.HP != nil || d.Level != nil {
+			changed = append(changed, d)
+		}
+	}
+
+	var removed []ObjectRef
+	for i := range before {
+		if id := f.id(&before[i]); old[id] != nil {
+			removed = append(removed, ObjectRef{Kind: f.kind, Id: id})
+		}
+	}
+	return changed, removed, nil
+}
+
+// apply применяет изменения объектов своего типа к копии objects.
+func (f objectFields[T]) apply(objects []T, changed []ObjectDelta, removed []ObjectRef) ([]T, error) {
+	objects = slices.Clone(objects)
+	for _, d := range changed {
+		if d.Kind != f.kind {
+			continue
+		}
+		i := slices.IndexFunc(objects, func(o T) bool { return f.id(&o) == d.Id })
+		if d.Object != nil {
+			var o T
+			if err := json.Unmarshal(d.Object, &o); err != nil {
+				return nil, fmt.Errorf("%w: %v %v: %v", ErrDeltaBase, f.kind, d.Id, err)
+			}
+			if i < 0 {
+				objects = append(objects, o)
+			} else {
+				objects[i] = o
+			}
+			continue
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("%w: no %v %v", ErrDeltaBase, f.kind, d.Id)
+		}
+		if d.Coordinates != nil {
+			*f.coords(&objects[i]) = d.Coordinates
+		}
+		if d.HP != nil && f.hp != nil {
+			*f.hp(&objects[i]) = *d.HP
+		}
+		if d.Level != nil && f.level != nil {
+			*f.level(&objects[i]) = *d.Level
+		}
+	}
+	for _, r := range removed {
+		if r.Kind == f.kind {
+			objects = slices.DeleteFunc(objects, func(o T) bool { return f.id(&o) == r.Id })
+		}
+	}
+	return objects, nil
+}
+
+// DiffWorld возвращает изменения состояния мира от before (версия base) к after (версия version).
+func DiffWorld(base int64, before WorldState, version int64, after WorldState) (WorldDelta, error) {
+	delta := WorldDelta{Version: version, Base: base, Timestamp: after.Timestamp}
+	if before.UserId != after.UserId || before.AreaId != after.AreaId {
+		delta.Base = 0
+		delta.Snapshot = &after
+		return delta, nil
+	}
+
+	var err error
+	add := func(changed []ObjectDelta, removed []ObjectRef, diffErr error) {
+		delta.Changed = append(delta.Changed, changed...)
+		delta.Removed = append(delta.Removed, removed...)
+		err = errors.Join(err, diffErr)
+	}
+	add(neutralFields.diff(before.Neutrals, after.Neutrals))
+	add(buildingFields.diff(before.Buildings, after.Buildings))
+	add(heroFields.diff(before.Heroes, after.Heroes))
+	add(unitFields.diff(before.Units, after.Units))
+	add(enemyFields.diff(before.Enemies, after.Enemies))
+	if err != nil {
+		return WorldDelta{}, err
+	}
+	return delta, nil
+}
+
+// Apply применяет изменения к состоянию версии d.Base и возвращает состояние версии d.Version.
+// Исходное состояние не изменяется.
+func (d WorldDelta) Apply(state WorldState) (WorldState, error) {
+	if d.Snapshot != nil {
+		return *d.Snapshot, nil
+	}
+	var err error
+	state.Timestamp = d.Timestamp
+	if state.Neutrals, err = neutralFields.apply(state.Neutrals, d.Changed, d.Removed); err != nil {
+		return WorldState{}, err
+	}
+	if state.Buildings, err = buildingFields.apply(state.Buildings, d.Changed, d.Removed); err != nil {
+		return WorldState{}, err
+	}
+	if state.Heroes, err = heroFields.apply(state.Heroes, d.Changed, d.Removed); err != nil {
+		return WorldState{}, err
+	}
+	if state.Units, err = unitFields.apply(state.Units, d.Changed, d.Removed); err != nil {
+		return WorldState{}, err
+	}
+	if state.Enemies, err = enemyFields.apply(state.Enemies, d.Changed, d.Removed); err != nil {
+		return WorldState{}, err
+	}
+	return state, nil
+}
+
+// worldSync - состояния мира, отправленные одному соединению.
+// Изменения считаются от последнего состояния, которое клиент подтвердил.
+type worldSync struct {
+	mu      sync.Mutex
+	version int64
+	acked   *versionedState // подтвержденное клиентом состояние
+	sent    *versionedState // отправленное, но еще не подтвержденное состояние
+}
+
+type versionedState struct {
+	version int64
+	state   WorldState
+}
+
+// next запоминает текущее состояние state и возвращает его изменения относительно версии ack.
+// Если клиент не подтвердил ни одну известную версию, возвращается полный снимок.
+func (w *worldSync) next(ack *int64, state WorldState) (WorldDelta, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var base *versionedState
+	if ack != nil {
+		if w.sent != nil && w.sent.version == *ack {
+			w.acked, w.sent = w.sent, nil
+		}
+		if w.acked != nil && w.acked.version == *ack {
+			base = w.acked
+		}
+	}
+
+	w.version++
+	w.sent = &versionedState{version: w.version, state: state}
+	if base == nil {
+		return WorldDelta{Version: w.version, Timestamp: state.Timestamp, Snapshot: &state}, nil
+	}
+	return DiffWorld(base.version, base.state, w.version, state)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// testWorld возвращает состояние мира пользователя 1 на арене 4
+func testWorld() WorldState {
+	return WorldState{
+		UserId:    1,
+		AreaId:    4,
+		Timestamp: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		AreaObjects: AreaObjects{
+			Neutrals: []models.Neutral{
+				{Id: 1, Name: "Gold mine", Capacity: decimal.NewFromInt(500), Size: 1, Coordinates: []models.Hex{{Q: 1, R: 1}}},
+			},
+			Buildings: []models.Building{
+				{Id: 2, Name: "CyMan miner house", Level: 1, Charachteristics: models.BuildingCharacteristics{HP: 100}, Coordinates: []models.Hex{{Q: 2, R: 2}}},
+			},
+			Heroes: []models.Hero{
+				{Id: 3, Name: "Hero", Level: 1, Experience: decimal.NewFromInt(10), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, Speed: decimal.NewFromFloat(1.5)}, Coordinates: []models.Hex{{Q: 3, R: 3}}},
+			},
+			Units: []models.Unit{
+				{Id: 5, Name: "Miner", Level: 1, Charachteristics: models.UnitCharacteristics{HP: 50, HPnow: 50}, Coordinates: []models.Hex{{Q: 5, R: 5}}},
+				{Id: 7, Name: "Miner", Level: 1, Charachteristics: models.UnitCharacteristics{HP: 50, HPnow: 50}, Coordinates: []models.Hex{{Q: 7, R: 7}}},
+			},
+			Enemies: []models.Enemy{
+				{Id: 6, Name: "Bug", Level: 2, Charachteristics: models.EnemyCharacteristics{HP: 30, Damage: decimal.NewFromInt(4)}, Coordinates: []models.Hex{{Q: 6, R: 6}}},
+			},
+		},
+	}
+}
+
+func TestDiffWorld(t *testing.T) {
+	tests := []struct {
+		name            string
+		change          func(w *WorldState)
+		expectedChanged string
+		expectedRemoved []ObjectRef
+	}{
+		{
+			name:   "Nothing changed",
+			change: func(w *WorldState) {},
+		},
+		{
+			name: "Move and damage",
+			change: func(w *WorldState) {
+				w.Units[0].Coordinates = []models.Hex{{Q: 6, R: 5}}
+				w.Heroes[0].Charachteristics.HPnow = 80
+			},
+			expectedChanged: `[{"kind":"hero","id":3,"hp":80},{"kind":"unit","id":5,"coordinates":[{"q":6,"r":5}]}]`,
+		},
+		{
+			name: "Level up and kill",
+			change: func(w *WorldState) {
+				w.Buildings[0].Level = 2
+				w.Enemies = w.Enemies[:0]
+				w.Units = w.Units[1:]
+			},
+			expectedChanged: `[{"kind":"building","id":2,"level":2}]`,
+			expectedRemoved: []ObjectRef{{Kind: models.UnitObject, Id: 5}, {Kind: models.EnemyObject, Id: 6}},
+		},
+		{
+			name: "New and changed objects are sent whole",
+			change: func(w *WorldState) {
+				w.Neutrals[0].Capacity = decimal.NewFromInt(450)
+				w.Enemies = append(w.Enemies, models.Enemy{Id: 8, Name: "Spider", Level: 1, Coordinates: []models.Hex{{Q: 8, R: 8}}})
+			},
+			expectedChanged: `[
+				{"kind":"neutral","id":1,"object":{"id":1,"name":"Gold mine","product":"","productivity_coefficient":0,"capacity":"450","threshold_level1":"0","threshold_level2":"0","size":1,"coordinates":[{"q":1,"r":1}]}},
+				{"kind":"enemy","id":8,"object":{"id":8,"name":"Spider","characteristics":{"hp":0,"armor":0,"speed":"0","vision":0,"range":false,"atack_range":"0","damage":"0","experience":"0","level":0},"level":1,"coordinates":[{"q":8,"r":8}]}}
+			]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := testWorld(), testWorld()
+			tt.change(&after)
+			after.Timestamp = before.Timestamp.Add(time.Second)
+
+			delta, err := DiffWorld(1, before, 2, after)
+			assert.NoError(t, err)
+
+			// Проверяем, что передаются только изменившиеся объекты и поля
+			changed, err := json.Marshal(delta.Changed)
+			assert.NoError(t, err)
+			if tt.expectedChanged == "" {
+				assert.Empty(t, delta.Changed)
+			} else {
+				assert.JSONEq(t, tt.expectedChanged, string(changed))
+			}
+			assert.Equal(t, tt.expectedRemoved, delta.Removed)
+			assert.Nil(t, delta.Snapshot)
+
+			// Проверяем, что изменения, переданные по сети и примененные к старому состоянию, дают новое
+			data, err := json.Marshal(delta)
+			assert.NoError(t, err)
+			var received WorldDelta
+			assert.NoError(t, json.Unmarshal(data, &received))
+			applied, err := received.Apply(before)
+			assert.NoError(t, err)
+			assertSameWorld(t, after, applied)
+		})
+	}
+}
+
+func TestWorldDeltaApplyToWrongBase(t *testing.T) {
+	delta := WorldDelta{Version: 2, Base: 1, Changed: []ObjectDelta{{ObjectRef: ObjectRef{Kind: models.UnitObject, Id: 9}, HP: intPtr(10)}}}
+
+	// Проверяем, что изменения несуществующего объекта не применяются молча
+	_, err := delta.Apply(testWorld())
+	assert.ErrorIs(t, err, ErrDeltaBase)
+}
+
+// worldStateHandler возвращает копию текущего состояния мира теста, как если бы оно было прочитано из БД
+type worldStateHandler struct {
+	state WorldState
+}
+
+func (wh *worldStateHandler) Handle(action *models.Action) (interface{}, error) {
+	data, err := json.Marshal(wh.state)
+	if err != nil {
+		return nil, err
+	}
+	var state WorldState
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+func TestSyncWorldState(t *testing.T) {
+	h := newTestHandler()
+	world := &worldStateHandler{state: testWorld()}
+	h.actionHandlers["get_world_state"] = world
+	session := h.registry.Add(&fakeSocket{}, 1)
+
+	// client - состояние мира, которое клиент собрал из полученных ответов
+	var client WorldState
+	sync := func(message string) WorldDelta {
+		response := h.process(session, []byte(message))
+		assert.Nil(t, response.Error)
+		assert.Equal(t, "world_delta", response.Type)
+		var delta WorldDelta
+		assert.NoError(t, json.Unmarshal(response.Payload, &delta))
+		return delta
+	}
+
+	// Проверяем, что при подключении отправляется полный снимок
+	delta := sync(`{"type":"sync_world_state"}`)
+	assert.NotNil(t, delta.Snapshot)
+	assert.Equal(t, int64(1), delta.Version)
+	client, _ = delta.Apply(client)
+
+	// Проверяем, что после подтверждения отправляются только изменения
+	world.state.Units[0].Coordinates = []models.Hex{{Q: 6, R: 5}}
+	delta = sync(`{"type":"sync_world_state","payload":{"ack":1}}`)
+	assert.Nil(t, delta.Snapshot)
+	assert.Equal(t, int64(1), delta.Base)
+	assert.Len(t, delta.Changed, 1)
+	client, err := delta.Apply(client)
+	assert.NoError(t, err)
+	assertSameWorld(t, world.state, client)
+
+	// Проверяем, что если ответ с версией 3 потерян, изменения считаются от последней подтвержденной версии
+	world.state.Heroes[0].Level = 2
+	lost := sync(`{"type":"sync_world_state","payload":{"ack":2}}`)
+	assert.Equal(t, int64(3), lost.Version)
+	world.state.Enemies = []models.Enemy{}
+	delta = sync(`{"type":"sync_world_state","payload":{"ack":2}}`)
+	assert.Equal(t, int64(2), delta.Base)
+	client, err = delta.Apply(client)
+	assert.NoError(t, err)
+	assertSameWorld(t, world.state, client)
+
+	// Проверяем, что при неизвестной версии клиент получает полный снимок
+	delta = sync(`{"type":"sync_world_state","payload":{"ack":42}}`)
+	assert.NotNil(t, delta.Snapshot)
+	assert.Zero(t, delta.Base)
+}
+
+// assertSameWorld сравнивает состояния мира так, как их видит клиент
+func assertSameWorld(t *testing.T, expected, actual WorldState) {
+	t.Helper()
+	expectedJSON, err := json.Marshal(expected)
+	assert.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"cyber/internal/auth"
+	"cyber/internal/game"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/internal/validation"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// ProtocolVersion - текущая версия формата сообщений websocket.
+const ProtocolVersion = 1
+
+const (
+	ErrorType     = "error"     // Тип ответа с ошибкой
+	SubscribeType = "subscribe" // Тип запроса подписки на события арены
+)
+
+// responseTypes - типы ответов на запросы, тип ответа которых отличается от типа запроса.
+var responseTypes = map[string]string{
+	"get_world_state": "world_state",
+	"get_user_data":   "user_data",
+	"get_area_data":   "area_data",
+	SyncWorldType:     "world_delta",
+
+	"get_resource_history": "resource_history",
+}
+
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnknownType  = errors.New("unknown message type")
+	ErrVersion      = errors.New("unsupported protocol version")
+	ErrPathNotFound = errors.New("path not found")
+)
+
+// Envelope - единый формат сообщений websocket в обе стороны.
+// Ответ сервера всегда содержит request_id запроса, чтобы фронт мог сопоставить их.
+type Envelope struct {
+	Version   int             `json:"v"`                    // Версия формата сообщений
+	Type      string          `json:"type"`                 // Тип сообщения: move, harvest, ..., error
+	RequestId string          `json:"request_id,omitempty"` // Идентификатор запроса, задается фронтом
+	Seq       int64           `json:"seq,omitempty"`        // Номер события арены, только в событиях
+	Payload   json.RawMessage `json:"payload,omitempty"`    // Данные сообщения
+	Error     *Error          `json:"error,omitempty"`      // Ошибка обработки запроса
+
+	result interface{} // Данные ответа или события до кодирования, из них собирается сообщение wspb
+}
+
+// Error - ошибка из контракта ошибок. Коды совпадают с кодами HTTP.
+type Error struct {
+	Code    int                     `json:"code"`             // Код ошибки
+	Message string                  `json:"message"`          // Описание ошибки
+	Fields  []validation.FieldError `json:"fields,omitempty"` // Ошибки по полям запроса
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// ActionPayload - данные сообщения с действием пользователя.
+type ActionPayload struct {
+	UserId          int64           `json:"user_id"`          // Не используется: пользователь определяется по сессии соединения
+	AreaId          int64           `json:"area_id"`          // Идентификатор арены
+	ObjectSourceId  int64           `json:"object_source_id"` // Идентификатор объекта-источника действия
+	ObjectDestId    int64           `json:"object_dest_id"`   // Идентификатор объекта-цели действия
+	Characteristics json.RawMessage `json:"characteristics"`  // Характеристики действия, зависят от типа
+}
+
+// SubscribePayload - данные запроса подписки на события арены.
+// События пользователя соединение получает с момента открытия.
+type SubscribePayload struct {
+	AreaId  int64  `json:"area_id"`            // Идентификатор арены
+	LastSeq *int64 `json:"last_seq,omitempty"` // Номер последнего полученного события арены при переподключении
+}
+
+// SubscribeResult - ответ на подписку на события арены.
+type SubscribeResult struct {
+	Status string `json:"status"` // success
+	Seq    int64  `json:"seq"`    // Номер последнего события арены на момент подписки
+	// Snapshot - состояние арены (как в area_data), если пропущенные события уже недоступны.
+	// События с номером больше Seq могут быть уже учтены в снимке
+	Snapshot *AreaData `json:"snapshot,omitempty"`
+}
+
+// ActionResult - ответ на действие пользователя.
+type ActionResult struct {
+	Status  string     `json:"status"`            // success или failed
+	Message string     `json:"message,omitempty"` // Опциональное сообщение
+	Paths   []UnitPath `json:"paths,omitempty"`   // Пути юнитов группового перемещения
+	// ActionId - действие, запущенное добычей или строительством. Его статус приходит в событиях
+	ActionId int64 `json:"action_id,omitempty"`
+}
+
+// UnitPath - путь юнита без стартовой клетки.
+type UnitPath struct {
+	UnitId int64        `json:"unit_id"`
+	Path   []models.Hex `json:"path"`
+}
+
+// decodeEnvelope разбирает сообщение websocket. Если сообщение не удалось разобрать,
+// возвращает конверт с уже прочитанными полями, чтобы ответить на нужный request_id.
+func decodeEnvelope(data []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return env, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	return checkEnvelope(env)
+}
+
+// checkEnvelope проверяет версию и тип разобранного сообщения.
+func checkEnvelope(env Envelope) (Envelope, error) {
+	if env.Version == 0 {
+		env.Version = ProtocolVersion
+	}
+	if env.Version > ProtocolVersion {
+		return env, fmt.Errorf("%w: %d", ErrVersion, env.Version)
+	}
+	if env.Type == "" {
+		return env, fmt.Errorf("%w: empty message type", ErrBadRequest)
+	}
+	return env, nil
+}
+
+// toAction преобразует сообщение с действием в *models.Action.
+func toAction(env Envelope) (*models.Action, error) {
+	var payload ActionPayload
+	if len(env.Payload) > 0 {
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+	}
+	return &models.Action{
+		UserId:          payload.UserId,
+		AreaId:          payload.AreaId,
+		ObjectSourceId:  payload.ObjectSourceId,
+		ObjectDestId:    payload.ObjectDestId,
+		ActionType:      env.Type,
+		Characteristics: payload.Characteristics,
+	}, nil
+}
+
+// reply формирует ответ на запрос req с данными result.
+func reply(req Envelope, result interface{}) (Envelope, error) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("marshal to JSON error: %v", err)
+	}
+	responseType := req.Type
+	if t, ok := responseTypes[req.Type]; ok {
+		responseType = t
+	}
+	return Envelope{Version: ProtocolVersion, Type: responseType, RequestId: req.RequestId, Payload: payload, result: result}, nil
+}
+
+// replyError формирует ответ с ошибкой на запрос req.
+func replyError(req Envelope, err error) Envelope {
+	return Envelope{Version: ProtocolVersion, Type: ErrorType, RequestId: req.RequestId, Error: toError(err)}
+}
+
+// toError переводит ошибку обработки в ошибку контракта. Внутренние ошибки
+// не раскрываются клиенту и только логируются.
+func toError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		return &Error{Code: http.StatusBadRequest, Message: "invalid characteristics", Fields: fields}
+	}
+
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrBadRequest), errors.Is(err, ErrUnknownType), errors.Is(err, ErrVersion),
+		errors.Is(err, storage.ErrNotValidAreaID), errors.Is(err, storage.ErrNotValidUserID), errors.Is(err, game.ErrOutOfArea),
+		errors.Is(err, storage.ErrInvalidEmail), errors.Is(err, auth.ErrWeakPassword), errors.Is(err, auth.ErrEmptyLogin):
+		code = http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, auth.ErrInvalidCredentials),
+		errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrTokenExpired):
+		code = http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		code = http.StatusForbidden
+	case errors.Is(err, storage.ErrNotFound), errors.Is(err, ErrPathNotFound), errors.Is(err, ErrObjectNotFound),
+		errors.Is(err, game.ErrNotOnArea):
+		code = http.StatusNotFound
+	case errors.Is(err, game.ErrUpgradeInProgress), errors.Is(err, game.ErrUpgradeMaxLevel),
+		errors.Is(err, game.ErrUnknownBuilding), errors.Is(err, game.ErrNoUpgradePrice), errors.Is(err, storage.ErrNotEnoughRes),
+		errors.Is(err, storage.ErrAlreadyExists), errors.Is(err, game.ErrOutOfRange), errors.Is(err, ErrStalePosition):
+		code = http.StatusConflict
+	case errors.Is(err, ErrRateLimited):
+		code = http.StatusTooManyRequests
+	case errors.Is(err, ErrShuttingDown), errors.Is(err, game.ErrUpgraderStopped), errors.Is(err, game.ErrSchedulerStopped):
+		code = http.StatusServiceUnavailable
+	}
+
+	if code == http.StatusInternalServerError {
+		log.Printf("internal error while handling message: %v\n", err)
+		return &Error{Code: code, Message: http.StatusText(code)}
+	}
+	return &Error{Code: code, Message: err.Error()}
+}
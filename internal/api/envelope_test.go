@@ -0,0 +1,267 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/game"
+	"cyber/internal/models"
+)
+
+// fakeObstacles - препятствия арены в памяти
+type fakeObstacles struct {
+	hexes []models.Hex
+	err   error
+}
+
+func (f *fakeObstacles) GetObstacles(areaID int64) ([]models.Hex, error) {
+	return f.hexes, f.err
+}
+
+// fakeAreaObstacles - арена 10x10 с препятствиями в памяти
+type fakeAreaObstacles struct {
+	fakeObstacles
+}
+
+func (f *fakeAreaObstacles) GetArea(areaId int64) (models.Area, error) {
+	return models.Area{Id: areaId, UserId: 1, Width: 10, Height: 10}, nil
+}
+
+// fakePaths ищет пути по арене fakeAreaObstacles
+type fakePaths struct {
+	fakeAreaObstacles
+}
+
+func (f *fakePaths) FindPath(areaId int64, start, goal game.Hex) ([]game.Hex, error) {
+	area, err := f.GetArea(areaId)
+	if err != nil {
+		return nil, err
+	}
+	obstacles, err := f.GetObstacles(areaId)
+	if err != nil {
+		return nil, err
+	}
+	world := game.NewMemoryWorld(area)
+	for i, h := range obstacles {
+		world.AddNeutral(models.Neutral{Id: int64(i + 1), Coordinates: []models.Hex{h}})
+	}
+	return world.Path(start, goal)
+}
+
+// failingHandler возвращает заданную ошибку
+type failingHandler struct {
+	err error
+}
+
+func (fh *failingHandler) Handle(action *models.Action) (interface{}, error) {
+	return nil, fh.err
+}
+
+// userEchoHandler возвращает пользователя, от имени которого выполняется действие
+type userEchoHandler struct{}
+
+func (uh *userEchoHandler) Handle(action *models.Action) (interface{}, error) {
+	return map[string]int64{"user_id": action.UserId}, nil
+}
+
+// fakeRunner нумерует запущенные действия, не выполняя их
+type fakeRunner struct {
+	mu    sync.Mutex
+	added []models.Action
+}
+
+func (f *fakeRunner) Add(a models.Action) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.added = append(f.added, a)
+	return int64(len(f.added)), nil
+}
+
+func newTestHandler() *WebSocketHandler {
+	h := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	h.authorizer = NewAuthorizer(&fakeWorld{}, 0)
+	h.catalog = NewCatalog(&fakeWorld{}, game.DefaultBuildingCatalog)
+	// Клетка (5,5) занята - путь до нее не существует
+	h.actionHandlers["move"] = &MoveActionHandler{paths: &fakePaths{fakeAreaObstacles{fakeObstacles{hexes: []models.Hex{{Q: 5, R: 5}}}}}}
+	h.actionHandlers["broken"] = &failingHandler{err: errors.New("connection refused")}
+	h.actionHandlers["whoami"] = &userEchoHandler{}
+	h.actionHandlers["harvest"] = &HarvestActionHandler{actions: &fakeRunner{}, now: time.Now}
+	h.actionHandlers["build"] = &BuildActionHandler{actions: &fakeRunner{}, now: time.Now}
+	return h
+}
+
+func TestProcessEnvelope(t *testing.T) {
+	h := newTestHandler()
+
+	tests := []struct {
+		name            string
+		anonymous       bool // сообщение пришло не из аутентифицированного соединения
+		message         string
+		expectedType    string
+		expectedRequest string
+		expectedError   *Error
+		expectedPayload string
+	}{
+		{
+			name:            "Success - move",
+			message:         `{"v":1,"type":"move","request_id":"a1","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":0,"r":0},"to":{"q":2,"r":1}}}}`,
+			expectedType:    "move",
+			expectedRequest: "a1",
+			expectedPayload: `{"status":"success","message":"Unit can start moving"}`,
+		},
+		{
+			name:            "Success - version defaults to current",
+			message:         `{"type":"harvest","request_id":"a2","payload":{"area_id":4,"object_source_id":5,"characteristics":{"neutral_id":1}}}`,
+			expectedType:    "harvest",
+			expectedRequest: "a2",
+			expectedPayload: `{"status":"success","message":"Resource collection can be started","action_id":1}`,
+		},
+		{
+			name:          "Error - invalid JSON",
+			message:       `{"type":"move",`,
+			expectedType:  ErrorType,
+			expectedError: &Error{Code: 400},
+		},
+		{
+			name:            "Error - unknown type echoes request id",
+			message:         `{"type":"teleport","request_id":"b1"}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b1",
+			expectedError:   &Error{Code: 400},
+		},
+		{
+			name:            "Error - unsupported version",
+			message:         `{"v":99,"type":"move","request_id":"b2"}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b2",
+			expectedError:   &Error{Code: 400},
+		},
+		{
+			name:            "Error - invalid characteristics",
+			message:         `{"type":"move","request_id":"b3","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":"here"}}}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b3",
+			expectedError:   &Error{Code: 400},
+		},
+		{
+			name:            "Error - path not found",
+			message:         `{"type":"move","request_id":"b4","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":0,"r":0},"to":{"q":5,"r":5}}}}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b4",
+			expectedError:   &Error{Code: 404},
+		},
+		{
+			name:            "Error - internal errors are hidden",
+			message:         `{"type":"broken","request_id":"b5"}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b5",
+			expectedError:   &Error{Code: 500, Message: "Internal Server Error"},
+		},
+		{
+			name:            "Success - user is taken from session",
+			message:         `{"type":"whoami","request_id":"a3","payload":{"user_id":2}}`,
+			expectedType:    "whoami",
+			expectedRequest: "a3",
+			expectedPayload: `{"user_id":1}`,
+		},
+		{
+			name:            "Error - no session",
+			anonymous:       true,
+			message:         `{"type":"whoami","request_id":"b6"}`,
+			expectedType:    ErrorType,
+			expectedRequest: "b6",
+			expectedError:   &Error{Code: 401},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{userId: 1}
+			if tt.anonymous {
+				session = nil
+			}
+			response := h.process(session, []byte(tt.message))
+
+			assert.Equal(t, ProtocolVersion, response.Version)
+			assert.Equal(t, tt.expectedType, response.Type)
+			assert.Equal(t, tt.expectedRequest, response.RequestId)
+			if tt.expectedError != nil {
+				if assert.NotNil(t, response.Error) {
+					assert.Equal(t, tt.expectedError.Code, response.Error.Code)
+					if tt.expectedError.Message != "" {
+						assert.Equal(t, tt.expectedError.Message, response.Error.Message)
+					}
+				}
+				assert.Empty(t, response.Payload)
+			} else {
+				assert.Nil(t, response.Error)
+				assert.JSONEq(t, tt.expectedPayload, string(response.Payload))
+			}
+		})
+	}
+}
+
+// fakeGroups - арена 10x10 в памяти с юнитами 1 в (0,0), 2 в (1,0) и 3 в (9,9), отрезанным от арены
+type fakeGroups struct {
+	world *game.MemoryWorld
+}
+
+func newFakeGroups() *fakeGroups {
+	world := game.NewMemoryWorld(models.Area{Id: 4, UserId: 1, Width: 10, Height: 10})
+	for id, h := range map[int64]models.Hex{1: {Q: 0, R: 0}, 2: {Q: 1, R: 0}, 3: {Q: 9, R: 9}} {
+		world.AddUnit(models.Unit{Id: id, Coordinates: []models.Hex{h}})
+	}
+	for i, h := range []models.Hex{{Q: 8, R: 9}, {Q: 9, R: 8}} {
+		world.AddNeutral(models.Neutral{Id: int64(i + 1), Coordinates: []models.Hex{h}})
+	}
+	return &fakeGroups{world: world}
+}
+
+func (f *fakeGroups) GroupRoutes(areaId int64, unitIds []int64, goal game.Hex) ([][]game.Hex, error) {
+	return f.world.GroupRoutes(unitIds, goal)
+}
+
+func TestGroupMoveActionHandler(t *testing.T) {
+	h := &GroupMoveActionHandler{paths: newFakeGroups()}
+
+	tests := []struct {
+		name            string
+		characteristics string
+		expectedPaths   map[int64]int // длина пути каждого юнита в ответе
+		expectedError   error
+	}{
+		{name: "Group reaches goal", characteristics: `{"units":[1,2],"to":{"q":4,"r":0}}`, expectedPaths: map[int64]int{1: 3, 2: 3}},
+		{name: "Unit outside group is an obstacle", characteristics: `{"units":[1,3],"to":{"q":4,"r":0}}`, expectedPaths: map[int64]int{1: 5}},
+		{name: "No unit reaches goal", characteristics: `{"units":[3],"to":{"q":4,"r":0}}`, expectedError: ErrPathNotFound},
+		{name: "Goal out of area", characteristics: `{"units":[1,2],"to":{"q":10,"r":0}}`, expectedError: ErrPathNotFound},
+		{name: "Unit not on area", characteristics: `{"units":[1,7],"to":{"q":4,"r":0}}`, expectedError: game.ErrNotOnArea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что в ответ попадают пути только тех юнитов, которые могут дойти до цели
+			result, err := h.Handle(&models.Action{AreaId: 4, Characteristics: json.RawMessage(tt.characteristics)})
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+			paths := make(map[int64]int)
+			for _, path := range result.(ActionResult).Paths {
+				paths[path.UnitId] = len(path.Path)
+			}
+			assert.Equal(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+func TestEnvelopeJSON(t *testing.T) {
+	// Проверяем формат ответа с ошибкой из контракта ошибок
+	data, err := json.Marshal(replyError(Envelope{Type: "move", RequestId: "c1"}, ErrUnknownType))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"v":1,"type":"error","request_id":"c1","error":{"code":400,"message":"unknown message type"}}`, string(data))
+}
@@ -1,161 +1,553 @@
 package server
 
 import (
+	"context"
 	"cyber/internal/game"
+	"cyber/internal/ledger"
 	"cyber/internal/models"
+	storage "cyber/internal/storage"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/lxzan/gws"
 	"log"
+	"sync"
+	"time"
+
+	"github.com/lxzan/gws"
 )
 
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// sessionUserKey - ключ идентификатора пользователя в сессии gws соединения.
+const sessionUserKey = "user_id"
+
 // WebSocketHandler реализует интерфейс gws.EventHandler.
 type WebSocketHandler struct {
+	gws.BuiltinEventHandler
 	actionHandlers map[string]ActionHandler
+
+	authorizer *Authorizer
+	catalog    *Catalog
+	registry   *Registry
+	limiter    *RateLimiter
+
+	mu       sync.Mutex
+	closing  bool           // сервер останавливается, новые сообщения не принимаются
+	inFlight sync.WaitGroup // сообщения, которые еще обрабатываются
 }
 
-// OnMessage срабатывает при установке соединения.
+// OnOpen срабатывает при установке соединения. Соединение без пользователя сессии закрывается.
 func (h *WebSocketHandler) OnOpen(socket *gws.Conn) {
+	value, _ := socket.Session().Load(sessionUserKey)
+	userId, ok := value.(int64)
+	if !ok {
+		socket.WriteClose(1008, []byte(ErrUnauthorized.Error()))
+		return
+	}
+
+	h.mu.Lock()
+	closing := h.closing
+	if !closing {
+		h.registry.Add(socket, userId).limiter = h.limiter.Open(userId)
+	}
+	h.mu.Unlock()
+
+	if closing {
+		socket.WriteClose(1001, []byte(ErrShuttingDown.Error()))
+		return
+	}
 	log.Println("WebSocket connection open sucess")
 }
 
 // OnMessage срабатывает при получении сообщения по websocket.
 func (h *WebSocketHandler) OnMessage(socket *gws.Conn, message *gws.Message) {
 	defer message.Close()
-	log.Printf("Received from WebScoket: %s", message.Data.String())
+	decode := decodeEnvelope
+	if message.Opcode == gws.OpcodeBinary {
+		decode = decodeProto
+		log.Printf("Received from WebScoket: %d bytes of protobuf", message.Data.Len())
+	} else {
+		log.Printf("Received from WebScoket: %s", message.Data.String())
+	}
+
+	// Во время остановки сервера новые действия не запускаются,
+	// а уже начатые успевают сохранить свое состояние
+	h.mu.Lock()
+	closing := h.closing
+	if !closing {
+		h.inFlight.Add(1)
+	}
+	h.mu.Unlock()
 
-	action, err := h.parseMessage(message.Bytes())
+	session, _ := h.registry.Get(socket)
+	req, err := decode(message.Bytes())
+	var response Envelope
+	if closing {
+		response = replyError(req, ErrShuttingDown)
+	} else {
+		defer h.inFlight.Done()
+		response = h.handle(session, req, err)
+	}
+
+	// Отправляем результат клиенту
+	if err := h.sendResponse(socket, session, response); err != nil {
+		log.Printf("failed to send response: %v", err)
+	}
+
+	// Соединение, слишком часто превышающее ограничения, закрывается после отправки ошибки
+	if session != nil && session.limiter.Exceeded() {
+		log.Printf("user ID- %v disconnected: %v\n", session.userId, ErrRepeatedViolations)
+		h.limiter.metrics.disconnected("rate_limit")
+		session.close(1008, ErrRepeatedViolations)
+	}
+}
+
+// process обрабатывает JSON сообщение websocket соединения session и возвращает ответ на него.
+func (h *WebSocketHandler) process(session *Session, data []byte) Envelope {
+	req, err := decodeEnvelope(data)
+	return h.handle(session, req, err)
+}
+
+// handle обрабатывает разобранное сообщение req соединения session. err - ошибка разбора сообщения.
+// Ошибки обработки возвращаются клиенту в формате контракта ошибок.
+// Действие всегда выполняется от имени пользователя сессии, user_id из сообщения не используется.
+func (h *WebSocketHandler) handle(session *Session, req Envelope, err error) Envelope {
+	h.limiter.metrics.message(h.messageLabel(req.Type))
 	if err != nil {
-		log.Printf("cant parse frontend message to action type:%v\n", err)
-		return
+		log.Printf("cant parse frontend message: %v\n", err)
+		return replyError(req, err)
+	}
+	if session == nil {
+		return replyError(req, ErrUnauthorized)
+	}
+	if err := session.limiter.Allow(req.Type); err != nil {
+		return replyError(req, err)
+	}
+
+	switch req.Type {
+	case SubscribeType:
+		return h.subscribe(session, req)
+	case SyncWorldType:
+		return h.syncWorld(session, req)
+	}
+
+	action, err := toAction(req)
+	if err != nil {
+		return replyError(req, err)
+	}
+	action.UserId = session.userId
+	area, err := h.authorizer.Authorize(action)
+	if err != nil {
+		log.Printf("action %v of user ID- %v rejected: %v\n", action.ActionType, action.UserId, err)
+		return replyError(req, err)
+	}
+	if err := validateCharacteristics(action, area, h.catalog); err != nil {
+		return replyError(req, err)
 	}
 
-	if result , err := h.handleAction(action); err != nil {
+	result, err := h.handleAction(action)
+	if err != nil {
 		log.Printf("cant handle action:%v\n", err)
-		return
+		return replyError(req, err)
 	}
 
-	  // Отправляем результат клиенту
-	  if err := h.sendResponse(socket, result); err != nil {
-        log.Printf("failed to send response: %v", err)
-    }
+	response, err := reply(req, result)
+	if err != nil {
+		return replyError(req, err)
+	}
+	return response
+}
+
+// messageLabel возвращает тип сообщения для метрик. Неизвестные типы объединяются,
+// чтобы клиент не мог создавать произвольное число меток.
+func (h *WebSocketHandler) messageLabel(messageType string) string {
+	if _, ok := h.actionHandlers[messageType]; ok || messageType == SubscribeType || messageType == SyncWorldType {
+		return messageType
+	}
+	return "unknown"
+}
+
+// subscribe подписывает соединение на события арены. Если задан last_seq, соединение
+// получает события арены, пропущенные с прошлого подключения.
+func (h *WebSocketHandler) subscribe(session *Session, req Envelope) Envelope {
+	var payload SubscribePayload
+	if err := json.Unmarshal(req.Payload, &payload); err != nil {
+		return replyError(req, fmt.Errorf("%w: %v", ErrBadRequest, err))
+	}
+	if payload.AreaId == 0 {
+		return replyError(req, fmt.Errorf("%w: area_id required", ErrBadRequest))
+	}
+	if _, err := h.authorizer.AuthorizeArea(session.userId, payload.AreaId); err != nil {
+		return replyError(req, err)
+	}
+
+	// При переподключении клиент получает пропущенные события до ответа на подписку,
+	// а если они уже недоступны - снимок арены в ответе
+	result := SubscribeResult{Status: "success"}
+	resumed := true
+	if payload.LastSeq == nil {
+		result.Seq = h.registry.Watch(session, payload.AreaId)
+	} else {
+		result.Seq, resumed = h.registry.Resume(session, payload.AreaId, *payload.LastSeq)
+	}
+	if !resumed {
+		snapshot, err := h.handleAction(&models.Action{UserId: session.userId, AreaId: payload.AreaId, ActionType: "get_area_data"})
+		if err != nil {
+			return replyError(req, err)
+		}
+		area, ok := snapshot.(AreaData)
+		if !ok {
+			return replyError(req, fmt.Errorf("unexpected area data %T", snapshot))
+		}
+		result.Snapshot = &area
+	}
+	response, err := reply(req, result)
+	if err != nil {
+		return replyError(req, err)
+	}
+	return response
+}
+
+// syncWorld отправляет клиенту изменения состояния мира с подтвержденной им версии.
+// Полный снимок отправляется при первом запросе и при рассинхронизации.
+func (h *WebSocketHandler) syncWorld(session *Session, req Envelope) Envelope {
+	var payload SyncWorldPayload
+	if len(req.Payload) > 0 {
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			return replyError(req, fmt.Errorf("%w: %v", ErrBadRequest, err))
+		}
+	}
+	result, err := h.handleAction(&models.Action{UserId: session.userId, ActionType: "get_world_state"})
+	if err != nil {
+		return replyError(req, err)
+	}
+	state, ok := result.(WorldState)
+	if !ok {
+		return replyError(req, fmt.Errorf("unexpected world state %T", result))
+	}
+	delta, err := session.world.next(payload.Ack, state)
+	if err != nil {
+		return replyError(req, err)
+	}
+	response, err := reply(req, delta)
+	if err != nil {
+		return replyError(req, err)
+	}
+	return response
 }
 
 // OnClose вызывается при закрытии по websocket соединения.
+// Сообщение больше допустимого размера закрывает соединение с кодом 1009.
 func (h *WebSocketHandler) OnClose(socket *gws.Conn, err error) {
+	if session, ok := h.registry.Get(socket); ok {
+		session.limiter.Close()
+	}
+	h.registry.Remove(socket)
+	if code, ok := err.(interface{ Uint16() uint16 }); ok && code.Uint16() == 1009 {
+		log.Printf("WebSocket connection closed: %v\n", err)
+		h.limiter.metrics.disconnected("message_too_large")
+		return
+	}
 	log.Println("WebSocket connection closed")
 }
-//метод отправки реузльтатов обработки сервером сообщения, полученного по websocket.
-func (h *WebSocketHandler) sendResponse(socket *gws.Conn, result interface{}) error {
-	response,err:=json.Marshal(result)
-		if err!=nil{
-			fmt.Errorf("marshal to JSON error: %v\n",err)
-		}
+
+// Drain прекращает прием новых сообщений, ждет окончания обработки уже полученных
+// и закрывает все соединения. Если ctx отменен раньше, соединения закрываются сразу.
+func (h *WebSocketHandler) Drain(ctx context.Context) error {
+	h.mu.Lock()
+	h.closing = true
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.inFlight.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
 	}
-	return socket.WriteMessage(gws.OpcodeText,response)
+
+	if closeErr := h.registry.CloseAll(ctx, 1001, ErrShuttingDown); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
 }
 
-//parseMessage преобразует сообщение сообщение полученное по websocket в *models.Action
-func (h *WebSocketHandler) parseMessage(data []byte) (*models.Action, error) {
-	var action models.Action
-	if err := json.Unmarshal(data, &action); err != nil {
-		return nil, fmt.Errorf("frontend message unmarshal error", err)
+// метод отправки реузльтатов обработки сервером сообщения, полученного по websocket.
+// Ответ ставится в очередь соединения, чтобы не перемешаться с событиями, отправляемыми ему же.
+func (h *WebSocketHandler) sendResponse(socket *gws.Conn, session *Session, response Envelope) error {
+	if session != nil {
+		return session.Send(response)
+	}
+	f, err := encodeFrame(response, socket.SubProtocol() == ProtobufSubprotocol)
+	if err != nil {
+		return err
 	}
-	return &action, nil
+	return socket.WriteMessage(f.opcode, f.data)
 }
 
-//основной обработчик, запускающий обработчик соответствующий полученному с фронта действию
-func (h *WebSocketHandler) handleAction(action *models.Action) (interface{},error) {
+// основной обработчик, запускающий обработчик соответствующий полученному с фронта действию
+func (h *WebSocketHandler) handleAction(action *models.Action) (interface{}, error) {
 	handler, ok := h.actionHandlers[action.ActionType]
 	if !ok {
-		return nil,fmt.Errorf("unknown action type: %s", action.ActionType)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, action.ActionType)
 	}
-	
-	result , err := handler.Handle(action)
-	if err!=nil{
-		return nil,err
+
+	result, err := handler.Handle(action)
+	if err != nil {
+		return nil, err
 	}
 
-	return result,nil
+	return result, nil
 }
 
-//Конструктор для WebSocketHandler
-func NewWebSocketHandler() *WebSocketHandler {
+// Конструктор для WebSocketHandler. db - общий для сервера пул подключений к БД,
+// scheduler - планировщик, выполняющий добычу ресурсов и строительство,
+// registry - реестр соединений, в которые рассылаются события игры.
+// Права пользователя на действия с ареной и названия ресурсов в характеристиках проверяются по db,
+// а пути перемещений и размер групп юнитов - по аренам в памяти areas.
+// limiter ограничивает частоту сообщений, nil - ограничения по умолчанию без метрик.
+func NewWebSocketHandler(db *storage.Storage, areas *game.Areas, upgrader *game.Upgrader, scheduler *game.Scheduler, registry *Registry, limiter *RateLimiter) *WebSocketHandler {
+	if registry == nil {
+		registry = NewRegistry(DefaultQueueSize)
+	}
+	if limiter == nil {
+		limiter = NewRateLimiter(DefaultLimits, nil)
+	}
+	maxGroup := 0
+	if areas != nil {
+		maxGroup = areas.MaxGroupUnits()
+	}
+	resources := ledger.New(db)
+	var resourceCatalog ResourceCatalog
+	if db != nil {
+		resourceCatalog = db
+	}
 	return &WebSocketHandler{
 		actionHandlers: map[string]ActionHandler{
-			"move":    &MoveActionHandler{},
-			"harvest": &HarvestActionHandler{},
-			"build":   &BuildActionHandler{},
-			"attack":  &AttackActionHandler{},
+			"move":       &MoveActionHandler{paths: areas},
+			"harvest":    &HarvestActionHandler{actions: scheduler, now: time.Now},
+			"build":      &BuildActionHandler{actions: scheduler, now: time.Now},
+			"attack":     &AttackActionHandler{areas: areas},
+			"upgrade":    &UpgradeActionHandler{upgrader: upgrader},
+			"group_move": &GroupMoveActionHandler{paths: areas},
+
+			"get_world_state": &GetWorldStateHandler{store: db},
+			"get_user_data":   &GetUserDataHandler{store: db, ledger: resources},
+			"get_area_data":   &GetAreaDataHandler{store: db},
+
+			"get_resource_history": &GetResourceHistoryHandler{ledger: resources},
 		},
+		authorizer: NewAuthorizer(db, maxGroup),
+		catalog:    NewCatalog(resourceCatalog, game.DefaultBuildingCatalog),
+		registry:   registry,
+		limiter:    limiter,
 	}
 }
 
 // ActionHandler представляет интерфейс для обработки действий.
 type ActionHandler interface {
-	Handle(action *models.Action) error
+	Handle(action *models.Action) (interface{}, error)
 }
 
 // Функция преобразующая байтовый срез в тип данных удовлетворяющий троебованию [T any]
-//В UnmarshalCharacteristics используются дженерики в связи с тем, характеристики
+// В UnmarshalCharacteristics используются дженерики в связи с тем, характеристики
 // разнятся в зависимости от типа действия, для этого и используется параметризация типа
 func UnmarshalCharacteristics[T any](data []byte) (*T, error) {
 	var characteristics T
-	if err:=json.Unmarshal(data,&characteristics);err!=nil{
-		return nil, fmt.Errorf("unmarshal charachteristics error: %v\n",err)
+	if err := json.Unmarshal(data, &characteristics); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal charachteristics error: %v", ErrBadRequest, err)
 	}
 	return &characteristics, nil
 }
 
+// PathFinder ищет пути по аренам в памяти сервера. Реализуется *game.Areas.
+type PathFinder interface {
+	FindPath(areaId int64, start, goal game.Hex) ([]game.Hex, error)
+}
+
 // MoveActionHandler обрабатывает действия типа "move".
-type MoveActionHandler struct{}
+type MoveActionHandler struct {
+	paths PathFinder
+}
 
-func (mh *MoveActionHandler) Handle(action *Action) error {
-	//подаем на вход (action.Charachteristics) являющийся []byte 
-	charachteristics,err:=UnmarshalCharacteristics[MoveActionCharacteristics](action.Charachteristics)
-	if err!=nil{
-		return return nil, fmt.Errorf("unmarshal action charachteristics error: %v\n",err)
+func (mh *MoveActionHandler) Handle(action *models.Action) (interface{}, error) {
+	//подаем на вход (action.Characteristics) являющийся []byte
+	characteristics, err := UnmarshalCharacteristics[models.MoveActionCharacteristics](action.Characteristics)
+	if err != nil {
+		return nil, err
 	}
 	// Теперь characteristics имеет тип *MoveActionCharacteristics и запускает метод поиска пути
-	game.AStar(moveCharacteristics.From, moveCharacteristics.To, action.AreaId)
-	return nil
+	_, err = mh.paths.FindPath(action.AreaId, game.Hex(characteristics.From), game.Hex(characteristics.To))
+	if errors.Is(err, game.ErrNoPath) || errors.Is(err, game.ErrOutOfArea) {
+		return nil, fmt.Errorf("%w: from %v to %v", ErrPathNotFound, characteristics.From, characteristics.To)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cant find path on area %v: %w", action.AreaId, err)
+	}
+	return ActionResult{Status: "success", Message: "Unit can start moving"}, nil
 }
 
-// HarvestActionHandler обрабатывает действия типа "harvest".
-type HarvestActionHandler struct{}
+// GroupPathFinder ищет пути групп юнитов по аренам в памяти сервера. Реализуется *game.Areas.
+type GroupPathFinder interface {
+	GroupRoutes(areaId int64, unitIds []int64, goal game.Hex) ([][]game.Hex, error)
+}
 
-func (hh *HarvestActionHandler) Handle(action *Action) error {
-	characteristics, err := UnmarshalCharacteristics[HarvestActionCharacteristics](action.Characteristics)
-    if err != nil {
-        return fmt.Errorf("unmarshal characteristics error: %v", err)
-    }
+// GroupMoveActionHandler обрабатывает действия типа "group_move". Пути всех юнитов группы
+// прокладываются по одному полю потока к цели, а не поиском пути для каждого юнита.
+type GroupMoveActionHandler struct {
+	paths GroupPathFinder
+}
 
-	// TODO:Логика для harvest
-	return nil
+func (gh *GroupMoveActionHandler) Handle(action *models.Action) (interface{}, error) {
+	characteristics, err := UnmarshalCharacteristics[models.GroupMoveActionCharacteristics](action.Characteristics)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := gh.paths.GroupRoutes(action.AreaId, characteristics.Units, game.Hex(characteristics.To))
+	if errors.Is(err, game.ErrNoPath) || errors.Is(err, game.ErrOutOfArea) {
+		return nil, fmt.Errorf("%w: to %v", ErrPathNotFound, characteristics.To)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cant find group paths on area %v: %w", action.AreaId, err)
+	}
+	// Юниты, которые не могут дойти до цели, остаются на месте и в ответ не попадают
+	result := ActionResult{Status: "success"}
+	for i, path := range paths {
+		if path == nil {
+			continue
+		}
+		unitPath := UnitPath{UnitId: characteristics.Units[i], Path: make([]models.Hex, len(path))}
+		for j, h := range path {
+			unitPath.Path[j] = models.Hex(h)
+		}
+		result.Paths = append(result.Paths, unitPath)
+	}
+	result.Message = fmt.Sprintf("%d of %d units can start moving", len(result.Paths), len(paths))
+	return result, nil
 }
 
-// BuildActionHandler обрабатывает действия типа "build".
-type BuildActionHandler struct{}
+// HARDCODE HarvestDuration - длительность добычи ресурсов, запущенной по websocket
+const HarvestDuration = time.Minute
 
-func (bh *BuildActionHandler) Handle(action *Action) error {
-	characteristics,err=UnmarshalCharacteristics[BuildActionCharacteristics](action.Charachteristics)
+// ActionRunner сохраняет действие и выполняет его заданное время. Реализуется *game.Scheduler.
+type ActionRunner interface {
+	Add(a models.Action) (int64, error)
+}
+
+// HarvestActionHandler обрабатывает действия типа "harvest". Добыча выполняется HarvestDuration,
+// по ее завершении юнит получает опыт.
+type HarvestActionHandler struct {
+	actions ActionRunner
+	now     func() time.Time
+}
+
+func (hh *HarvestActionHandler) Handle(action *models.Action) (interface{}, error) {
+	// Юнит и нейтрал задаются в действии или в характеристиках, как и при проверке прав
+	harvester, neutral, err := harvestObjects(action)
+	if err != nil {
+		return nil, err
+	}
+	id, err := hh.actions.Add(models.Action{
+		UserId:          action.UserId,
+		AreaId:          action.AreaId,
+		ObjectSourceId:  harvester,
+		ObjectDestId:    neutral,
+		ActionType:      action.ActionType,
+		Characteristics: action.Characteristics,
+		StartTime:       hh.now(),
+		Duration:        HarvestDuration,
+	})
 	if err != nil {
-        return fmt.Errorf("unmarshal characteristics error: %v", err)
-    }
+		return nil, fmt.Errorf("cant start harvest of unit %v: %w", harvester, err)
+	}
+	return ActionResult{Status: "success", Message: "Resource collection can be started", ActionId: id}, nil
+}
+
+// BuildActionHandler обрабатывает действия типа "build". Строительство выполняется
+// construction_time секунд, по его завершении юнит получает опыт.
+type BuildActionHandler struct {
+	actions ActionRunner
+	now     func() time.Time
+}
+
+func (bh *BuildActionHandler) Handle(action *models.Action) (interface{}, error) {
+	characteristics, err := UnmarshalCharacteristics[models.BuildActionCharacteristics](action.Characteristics)
+	if err != nil {
+		return nil, err
+	}
+	builder, _, err := buildObjects(action)
+	if err != nil {
+		return nil, err
+	}
+	id, err := bh.actions.Add(models.Action{
+		UserId:          action.UserId,
+		AreaId:          action.AreaId,
+		ObjectSourceId:  builder,
+		ObjectDestId:    action.ObjectDestId,
+		ActionType:      action.ActionType,
+		Characteristics: action.Characteristics,
+		StartTime:       bh.now(),
+		Duration:        time.Duration(characteristics.ConstructionTime) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cant start construction by unit %v: %w", builder, err)
+	}
+	return ActionResult{Status: "success", Message: "Construction can begin", ActionId: id}, nil
+}
 
-	// TODO:Логика для build
-	return nil
+// Attacker наносит урон врагам на аренах в памяти сервера. Реализуется *game.Areas.
+type Attacker interface {
+	Attack(areaId int64, attackerKind models.ObjectKind, attackerId, enemyId int64) (bool, error)
 }
 
-// AttackActionHandler обрабатывает действия типа "attack".
-type AttackActionHandler struct{}
+// AttackActionHandler обрабатывает действия типа "attack". Герой или юнит наносит врагу свой урон,
+// за убийство врага он получает опыт.
+type AttackActionHandler struct {
+	areas Attacker
+}
 
-func (ah *AttackActionHandler) Handle(action *Action) error {
-	characteristics,err=UnmarshalCharacteristics[AttackActionCharacteristics](action.Charachteristics)
+func (ah *AttackActionHandler) Handle(action *models.Action) (interface{}, error) {
+	// Атакующий и враг задаются в действии или в характеристиках, как и при проверке прав
+	attacker, enemy, err := attackObjects(action)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := attackerKind(action)
+	if err != nil {
+		return nil, err
+	}
+	killed, err := ah.areas.Attack(action.AreaId, kind, attacker, enemy)
 	if err != nil {
-        return fmt.Errorf("unmarshal characteristics error: %v", err)
-    }
+		return nil, err
+	}
+	if killed {
+		return ActionResult{Status: "success", Message: fmt.Sprintf("Enemy %v killed", enemy)}, nil
+	}
+	return ActionResult{Status: "success", Message: fmt.Sprintf("Enemy %v hit", enemy)}, nil
+}
 
-	// TODO:Логика для attack
-	return nil
+// UpgradeActionHandler обрабатывает действия типа "upgrade".
+type UpgradeActionHandler struct {
+	upgrader *game.Upgrader
+}
+
+func (uh *UpgradeActionHandler) Handle(action *models.Action) (interface{}, error) {
+	characteristics, err := UnmarshalCharacteristics[models.UpgradeActionCharacteristics](action.Characteristics)
+	if err != nil {
+		return nil, err
+	}
+
+	buildingId, err := objectId(action.ObjectSourceId, characteristics.BuildingId)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := uh.upgrader.Start(action.UserId, action.AreaId, buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("cant start upgrade of building %v: %w", buildingId, err)
+	}
+	return ActionResult{Status: "success", Message: fmt.Sprintf("Upgrade will take %v", duration)}, nil
 }
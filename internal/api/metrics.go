@@ -0,0 +1,69 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics - метрики websocket соединений. Методы nil метрик ничего не делают.
+type Metrics struct {
+	connections prometheus.Gauge
+	messages    *prometheus.CounterVec
+	throttledBy *prometheus.CounterVec
+	disconnects *prometheus.CounterVec
+}
+
+// Конструктор для Metrics. Метрики регистрируются в reg, если он не nil.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cyber_ws_connections",
+			Help: "Open websocket connections.",
+		}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cyber_ws_messages_total",
+			Help: "Websocket messages received, by message type.",
+		}, []string{"type"}),
+		throttledBy: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cyber_ws_throttled_total",
+			Help: "Websocket messages rejected by rate limits, by exceeded limit.",
+		}, []string{"scope"}),
+		disconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cyber_ws_disconnects_total",
+			Help: "Websocket connections closed by the server for abuse, by reason.",
+		}, []string{"reason"}),
+	}
+	if reg != nil {
+		reg.MustRegister(m.connections, m.messages, m.throttledBy, m.disconnects)
+	}
+	return m
+}
+
+func (m *Metrics) connOpened() {
+	if m != nil {
+		m.connections.Inc()
+	}
+}
+
+func (m *Metrics) connClosed() {
+	if m != nil {
+		m.connections.Dec()
+	}
+}
+
+func (m *Metrics) message(messageType string) {
+	if m != nil {
+		m.messages.WithLabelValues(messageType).Inc()
+	}
+}
+
+func (m *Metrics) throttled(scope string) {
+	if m != nil {
+		m.throttledBy.WithLabelValues(scope).Inc()
+	}
+}
+
+func (m *Metrics) disconnected(reason string) {
+	if m != nil {
+		m.disconnects.WithLabelValues(reason).Inc()
+	}
+}
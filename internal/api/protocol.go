@@ -0,0 +1,142 @@
+package server
+
+import (
+	"cyber/pkg/wspb"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lxzan/gws"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Подпротоколы websocket. Клиент, запросивший ProtobufSubprotocol, получает сообщения
+// бинарными кадрами в формате wspb.Envelope, остальные - JSON текстом.
+// Бинарные кадры от клиента принимаются в любом подпротоколе.
+const (
+	JSONSubprotocol     = "cyber.json.v1"
+	ProtobufSubprotocol = "cyber.proto.v1"
+)
+
+// Subprotocols - подпротоколы в порядке предпочтения сервера.
+var Subprotocols = []string{ProtobufSubprotocol, JSONSubprotocol}
+
+var structFullName = (&structpb.Struct{}).ProtoReflect().Descriptor().FullName()
+
+// frame - сообщение, закодированное для отправки соединению.
+type frame struct {
+	opcode gws.Opcode
+	data   []byte
+}
+
+// encodeFrame кодирует сообщение для отправки соединению: в JSON текстом или, если binary, в protobuf.
+func encodeFrame(env Envelope, binary bool) (frame, error) {
+	if !binary {
+		data, err := json.Marshal(env)
+		if err != nil {
+			return frame{}, fmt.Errorf("marshal to JSON error: %v", err)
+		}
+		return frame{opcode: gws.OpcodeText, data: data}, nil
+	}
+	data, err := encodeProto(env)
+	if err != nil {
+		return frame{}, fmt.Errorf("marshal to protobuf error: %v", err)
+	}
+	return frame{opcode: gws.OpcodeBinary, data: data}, nil
+}
+
+// encodeProto кодирует сообщение в wspb.Envelope. Сообщение wspb собирается из данных ответа
+// по их типу, данные типов без отдельного сообщения wspb передаются в поле json.
+func encodeProto(env Envelope) ([]byte, error) {
+	msg := &wspb.Envelope{V: uint32(env.Version), Type: env.Type, RequestId: env.RequestId, Seq: env.Seq}
+	if env.Error != nil {
+		msg.Error = &wspb.Error{Code: int32(env.Error.Code), Message: env.Error.Message}
+		for _, f := range env.Error.Fields {
+			msg.Error.Fields = append(msg.Error.Fields, &wspb.FieldError{Field: f.Field, Message: f.Message})
+		}
+	}
+	switch result := env.result.(type) {
+	case ActionResult:
+		msg.Payload = &wspb.Envelope_Result{Result: protoActionResult(result)}
+	case SubscribeResult:
+		msg.Payload = &wspb.Envelope_Subscribed{Subscribed: protoSubscribeResult(result)}
+	case AreaData:
+		msg.Payload = &wspb.Envelope_AreaData{AreaData: protoAreaData(result)}
+	case UserData:
+		msg.Payload = &wspb.Envelope_UserData{UserData: protoUserData(result)}
+	case WorldState:
+		msg.Payload = &wspb.Envelope_WorldState{WorldState: protoWorldState(result)}
+	case WorldDelta:
+		delta, err := protoWorldDelta(result)
+		if err != nil {
+			return nil, err
+		}
+		msg.Payload = &wspb.Envelope_WorldDelta{WorldDelta: delta}
+	default:
+		if len(env.Payload) > 0 {
+			msg.Payload = &wspb.Envelope_Json{Json: env.Payload}
+		}
+	}
+	return proto.Marshal(msg)
+}
+
+// decodeProto разбирает бинарное сообщение клиента в конверт с данными в JSON,
+// чтобы обработать его так же, как текстовое.
+func decodeProto(data []byte) (Envelope, error) {
+	var msg wspb.Envelope
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	env := Envelope{Version: int(msg.V), Type: msg.Type, RequestId: msg.RequestId, Seq: msg.Seq}
+	switch payload := msg.Payload.(type) {
+	case nil:
+	case *wspb.Envelope_Json:
+		env.Payload = payload.Json
+	default:
+		fd := msg.ProtoReflect().WhichOneof(msg.ProtoReflect().Descriptor().Oneofs().ByName("payload"))
+		data, err := json.Marshal(protoToJSON(msg.ProtoReflect().Get(fd).Message()))
+		if err != nil {
+			return env, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+		env.Payload = data
+	}
+	return checkEnvelope(env)
+}
+
+// protoToJSON переводит сообщение wspb в значения для encoding/json с именами полей как в контрактах.
+// В отличие от protojson, int64 остаются числами, а поле из oneof записывается под именем oneof:
+// характеристики действия любого типа попадают в "characteristics".
+func protoToJSON(m protoreflect.Message) interface{} {
+	if m.Descriptor().FullName() == structFullName {
+		return m.Interface().(*structpb.Struct).AsMap()
+	}
+	object := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			name = string(oneof.Name())
+		}
+		if fd.IsList() {
+			list := make([]interface{}, v.List().Len())
+			for i := range list {
+				list[i] = protoValue(fd, v.List().Get(i))
+			}
+			object[name] = list
+			return true
+		}
+		object[name] = protoValue(fd, v)
+		return true
+	})
+	return object
+}
+
+func protoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return protoToJSON(v.Message())
+	case protoreflect.BytesKind:
+		return json.RawMessage(v.Bytes())
+	}
+	return v.Interface()
+}
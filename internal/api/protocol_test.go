@@ -0,0 +1,429 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lxzan/gws"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"cyber/internal/models"
+	"cyber/pkg/wspb"
+)
+
+func TestDecodeProto(t *testing.T) {
+	tests := []struct {
+		name            string
+		message         *wspb.Envelope
+		expectedType    string
+		expectedPayload string
+	}{
+		{
+			name: "Move action",
+			message: &wspb.Envelope{V: 1, Type: "move", RequestId: "r1", Payload: &wspb.Envelope_Action{Action: &wspb.ActionPayload{
+				AreaId:         4,
+				ObjectSourceId: 5,
+				Characteristics: &wspb.ActionPayload_Move{Move: &wspb.MoveCharacteristics{
+					From:  &wspb.Hex{Q: 1, R: 1},
+					To:    &wspb.Hex{Q: 2, R: 2},
+					Speed: "1.5",
+				}},
+			}}},
+			expectedType:    "move",
+			expectedPayload: `{"area_id":4,"object_source_id":5,"characteristics":{"from":{"q":1,"r":1},"to":{"q":2,"r":2},"speed":"1.5"}}`,
+		},
+		{
+			name: "Group move action",
+			message: &wspb.Envelope{V: 1, Type: "group_move", RequestId: "r2", Payload: &wspb.Envelope_Action{Action: &wspb.ActionPayload{
+				AreaId: 4,
+				Characteristics: &wspb.ActionPayload_GroupMove{GroupMove: &wspb.GroupMoveCharacteristics{
+					Units: []int64{5, 6},
+					To:    &wspb.Hex{Q: 2, R: 2},
+				}},
+			}}},
+			expectedType:    "group_move",
+			expectedPayload: `{"area_id":4,"characteristics":{"units":[5,6],"to":{"q":2,"r":2}}}`,
+		},
+		{
+			name: "Resubscribe",
+			message: &wspb.Envelope{Type: SubscribeType, Payload: &wspb.Envelope_Subscribe{
+				Subscribe: &wspb.SubscribePayload{AreaId: 4, LastSeq: proto.Int64(30)},
+			}},
+			expectedType:    SubscribeType,
+			expectedPayload: `{"area_id":4,"last_seq":30}`,
+		},
+		{
+			name:            "JSON payload",
+			message:         &wspb.Envelope{Type: "get_user_data", Payload: &wspb.Envelope_Json{Json: []byte(`{"user_id":1}`)}},
+			expectedType:    "get_user_data",
+			expectedPayload: `{"user_id":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := proto.Marshal(tt.message)
+			assert.NoError(t, err)
+
+			// Проверяем, что бинарное сообщение разбирается в тот же конверт, что и JSON
+			env, err := decodeProto(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, env.Type)
+			assert.Equal(t, tt.message.RequestId, env.RequestId)
+			assert.JSONEq(t, tt.expectedPayload, string(env.Payload))
+		})
+	}
+
+	// Проверяем, что поврежденное сообщение отклоняется как неверный запрос
+	_, err := decodeProto([]byte{0xff, 0xff})
+	assert.ErrorIs(t, err, ErrBadRequest)
+}
+
+// assertContract проверяет, что сообщение wspb содержит все поля JSON контракта с теми же значениями.
+// Числа int64 protojson передает строками, поэтому значения сравниваются в текстовом виде,
+// а пустой список protobuf не отличается от null.
+func assertContract(t *testing.T, expected interface{}, actual interface{}, path string) {
+	if list, ok := actual.([]interface{}); ok && expected == nil && len(list) == 0 {
+		return
+	}
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !assert.True(t, ok, "%s: expected object, got %v", path, actual) {
+			return
+		}
+		for name, value := range expected {
+			if assert.Contains(t, actual, name, "%s: field missing in protobuf", path) {
+				assertContract(t, value, actual[name], path+"."+name)
+			}
+		}
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !assert.True(t, ok, "%s: expected list, got %v", path, actual) || !assert.Len(t, actual, len(expected), path) {
+			return
+		}
+		for i := range expected {
+			assertContract(t, expected[i], actual[i], fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		assert.Equal(t, fmt.Sprint(expected), fmt.Sprint(actual), path)
+	}
+}
+
+// fullWorld - состояние мира, в котором заполнены все поля объектов, чтобы пропущенное
+// при сборке сообщения wspb поле не совпало с JSON.
+func fullWorld() WorldState {
+	return WorldState{
+		UserId:    1,
+		AreaId:    4,
+		Timestamp: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		AreaObjects: AreaObjects{
+			Neutrals: []models.Neutral{{
+				Id: 1, Name: "Gold mine", Product: "Gold", ProductivityCoefficient: 2,
+				Capacity: decimal.NewFromInt(500), ThresholdLevel1: decimal.NewFromInt(100), ThresholdLevel2: decimal.NewFromInt(50),
+				Size: 1, Coordinates: []models.Hex{{Q: 1, R: 1}},
+			}},
+			Buildings: []models.Building{{
+				Id: 2, Name: "CyMan miner house", Product: "Gold",
+				Charachteristics: models.BuildingCharacteristics{HP: 500, Armor: 10, ProductivityCoefficient: 3, Size: 2},
+				Level:            2, UpgradePrice: models.ResourcePrice{{Id: 1, Name: "Gold", Value: decimal.NewFromInt(1000)}},
+				Coordinates: []models.Hex{{Q: 2, R: 2}, {Q: 2, R: 3}},
+			}},
+			Heroes: []models.Hero{{
+				Id: 3, Name: "Hero",
+				Charachteristics: models.HeroCharacteristics{
+					HP: 100, HPnow: 90, Armor: 5, Speed: decimal.NewFromFloat(1.5), Vision: 4,
+					IsRange: true, AtackRange: decimal.NewFromInt(3), Damage: decimal.NewFromFloat(7.5),
+				},
+				Experience: decimal.NewFromInt(10), ExperienceToUp: decimal.NewFromInt(100), Level: 3,
+				Abilities: []models.Ability{{
+					Id: 11, Name: "Fireball",
+					Charachteristics: models.AbilitytCharacteristics{
+						IsPassive: true, Radius: decimal.NewFromInt(2), Cooldown: 5 * time.Second,
+						Damage: decimal.NewFromInt(20), ProjectilSpeed: decimal.NewFromFloat(2.5),
+					},
+					Level: 1, ImageId: 12,
+				}},
+				Coordinates: []models.Hex{{Q: 3, R: 3}},
+			}},
+			Units: []models.Unit{{
+				Id: 5, Name: "Miner",
+				Charachteristics: models.UnitCharacteristics{
+					HP: 50, HPnow: 40, Armor: 2, Speed: decimal.NewFromInt(1), Vision: 3,
+					IsRange: true, AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(2), ProductivityCoefficient: 4,
+				},
+				Experience: decimal.NewFromInt(5), ExperienceToUp: decimal.NewFromInt(50), Level: 2, ImageId: 13,
+				Coordinates: []models.Hex{{Q: 5, R: 5}},
+			}},
+			Enemies: []models.Enemy{{
+				Id: 6, Name: "Bug",
+				Charachteristics: models.EnemyCharacteristics{
+					HP: 30, Armor: 1, Speed: decimal.NewFromFloat(0.5), Vision: 2, IsRange: true,
+					AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(4), Experience: decimal.NewFromInt(15), Level: 2,
+				},
+				Level: 2, Coordinates: []models.Hex{{Q: 6, R: 6}},
+			}},
+		},
+	}
+}
+
+// assertPopulated проверяет, что в значении заполнены все экспортируемые поля. Так новое поле
+// контракта, не добавленное в данные теста, не пропустит ошибку сборки сообщения wspb.
+func assertPopulated(t *testing.T, v reflect.Value, path string) {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		assert.False(t, z.IsZero(), "%s is not set", path)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// Поля, скрытые из JSON, в контракт не входят
+			if f := v.Type().Field(i); f.IsExported() && f.Tag.Get("json") != "-" {
+				assertPopulated(t, v.Field(i), path+"."+v.Type().Field(i).Name)
+			}
+		}
+	case reflect.Pointer, reflect.Interface:
+		if assert.False(t, v.IsNil(), "%s is not set", path) {
+			assertPopulated(t, v.Elem(), path)
+		}
+	case reflect.Slice:
+		if assert.NotZero(t, v.Len(), "%s is empty", path) && v.Type().Elem().Kind() == reflect.Struct {
+			for i := 0; i < v.Len(); i++ {
+				assertPopulated(t, v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	default:
+		assert.False(t, v.IsZero(), "%s is not set", path)
+	}
+}
+
+// decodeNumbers разбирает JSON, сохраняя числа в исходном виде.
+func decodeNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func TestEncodeProto(t *testing.T) {
+	now := func() time.Time { return time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) }
+	store := &fakeWorld{}
+	h := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	h.authorizer = NewAuthorizer(store, 0)
+	h.actionHandlers["get_world_state"] = &GetWorldStateHandler{store: store, now: now}
+	h.actionHandlers["get_user_data"] = &GetUserDataHandler{store: store, ledger: store}
+	h.actionHandlers["get_area_data"] = &GetAreaDataHandler{store: store}
+	process := func(message string) Envelope {
+		return h.process(&Session{userId: 1}, []byte(message))
+	}
+
+	world := fullWorld()
+	area := AreaData{Id: 4, UserId: 1, Width: 20, Height: 20, CellTypeId: 1, AreaObjects: world.AreaObjects}
+	changed, err := json.Marshal(world.Enemies[0])
+	assert.NoError(t, err)
+	hp, level := 20, 3
+	delta := WorldDelta{
+		Version:   2,
+		Base:      1,
+		Timestamp: world.Timestamp,
+		Changed: []ObjectDelta{
+			{ObjectRef: ObjectRef{Kind: models.UnitObject, Id: 5}, Coordinates: []models.Hex{{Q: 6, R: 5}}, HP: &hp, Level: &level, Object: changed},
+		},
+		Removed:  []ObjectRef{{Kind: models.EnemyObject, Id: 8}},
+		Snapshot: &world,
+	}
+	league := &models.League{Id: 2, Name: "Silver", Authority: 10}
+	user := UserData{
+		Id: 1, Login: "user", Resources: []models.Resource{{Id: 1, Name: "Gold", Value: decimal.NewFromFloat(10.5)}},
+		Subscription: true, LeagueId: 2, League: league, Balance: decimal.NewFromInt(100), Level: 3,
+	}
+	result := ActionResult{Status: "success", Message: "Units can start moving", ActionId: 9, Paths: []UnitPath{{UnitId: 5, Path: []models.Hex{{Q: 1, R: 2}}}}}
+	subscribed := SubscribeResult{Status: "success", Seq: 3, Snapshot: &area}
+	response := func(requestType string, result interface{}) Envelope {
+		// Проверяем, что в данных теста заполнены все поля
+		assertPopulated(t, reflect.ValueOf(result), requestType)
+		env, err := reply(Envelope{Type: requestType, RequestId: "r1"}, result)
+		assert.NoError(t, err)
+		return env
+	}
+
+	tests := []struct {
+		name    string
+		message Envelope
+		payload func(msg *wspb.Envelope) proto.Message // данные в поле своего типа
+	}{
+		{"Area data", process(`{"type":"get_area_data","request_id":"r4","payload":{"area_id":4}}`),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetAreaData() }},
+		{"User data", process(`{"type":"get_user_data","request_id":"r3"}`),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetUserData() }},
+		{"World state", process(`{"type":"get_world_state","request_id":"r1"}`),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetWorldState() }},
+		{"World state objects", response("get_world_state", world),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetWorldState() }},
+		{"Area data objects", response("get_area_data", area),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetAreaData() }},
+		{"User data with league", response("get_user_data", user),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetUserData() }},
+		{"World delta", response(SyncWorldType, delta),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetWorldDelta() }},
+		{"Group move result", response("group_move", result),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetResult() }},
+		{"Subscribe snapshot", response(SubscribeType, subscribed),
+			func(msg *wspb.Envelope) proto.Message { return msg.GetSubscribed() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, tt.message.Error)
+			f, err := encodeFrame(tt.message, true)
+			assert.NoError(t, err)
+			assert.Equal(t, gws.OpcodeBinary, f.opcode)
+
+			// Проверяем, что данные переданы в поле своего типа, а не в поле json
+			var msg wspb.Envelope
+			assert.NoError(t, proto.Unmarshal(f.data, &msg))
+			assert.Equal(t, tt.message.RequestId, msg.RequestId)
+			payload := tt.payload(&msg)
+			if !assert.NotNil(t, payload, "unexpected payload %T", msg.Payload) {
+				return
+			}
+
+			// Проверяем, что сообщение wspb совпадает с JSON контрактом
+			data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(payload)
+			assert.NoError(t, err)
+			var expected, actual interface{}
+			assert.NoError(t, decodeNumbers(tt.message.Payload, &expected))
+			assert.NoError(t, decodeNumbers(data, &actual))
+			assertContract(t, expected, actual, "payload")
+		})
+	}
+
+	// Проверяем, что ошибка передается в контракте ошибок
+	f, err := encodeFrame(process(`{"type":"get_area_data","request_id":"r5","payload":{"area_id":7}}`), true)
+	assert.NoError(t, err)
+	var msg wspb.Envelope
+	assert.NoError(t, proto.Unmarshal(f.data, &msg))
+	assert.Equal(t, int32(http.StatusNotFound), msg.GetError().GetCode())
+
+	// Проверяем, что событие без отдельного сообщения wspb передается в поле json,
+	// даже если его тип совпадает с типом действия
+	event := Envelope{Version: 1, Type: "upgrade", Seq: 3, Payload: json.RawMessage(`{"building_id":5}`), result: map[string]int{"building_id": 5}}
+	f, err = encodeFrame(event, true)
+	assert.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(f.data, &msg))
+	assert.Equal(t, int64(3), msg.GetSeq())
+	assert.Equal(t, `{"building_id":5}`, string(msg.GetJson()))
+
+	// Проверяем, что текстовое соединение получает конверт в JSON
+	f, err = encodeFrame(event, false)
+	assert.NoError(t, err)
+	assert.Equal(t, gws.OpcodeText, f.opcode)
+	assert.JSONEq(t, `{"v":1,"type":"upgrade","seq":3,"payload":{"building_id":5}}`, string(f.data))
+}
+
+// protoHandler передает полученные клиентом бинарные сообщения в канал
+type protoHandler struct {
+	gws.BuiltinEventHandler
+	messages chan *wspb.Envelope
+}
+
+func (p *protoHandler) OnMessage(socket *gws.Conn, message *gws.Message) {
+	defer message.Close()
+	var msg wspb.Envelope
+	if message.Opcode == gws.OpcodeBinary && proto.Unmarshal(message.Bytes(), &msg) == nil {
+		p.messages <- &msg
+	}
+}
+
+func TestWebSocketProtobuf(t *testing.T) {
+	handler := newTestHandler()
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go wsServer.Serve(ln)
+
+	option := newTestClientOption(ln.Addr().String(), 1)
+	option.RequestHeader.Set("Sec-WebSocket-Protocol", ProtobufSubprotocol+", "+JSONSubprotocol)
+	client := &protoHandler{messages: make(chan *wspb.Envelope, 8)}
+	socket, resp, err := gws.NewClient(client, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+
+	// Проверяем, что сервер выбрал protobuf
+	assert.Equal(t, ProtobufSubprotocol, resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	send := func(msg *wspb.Envelope) *wspb.Envelope {
+		data, err := proto.Marshal(msg)
+		assert.NoError(t, err)
+		assert.NoError(t, socket.WriteMessage(gws.OpcodeBinary, data))
+		select {
+		case response := <-client.messages:
+			return response
+		case <-time.After(time.Second):
+			t.Fatal("no response")
+		}
+		return nil
+	}
+
+	// Проверяем, что ответы без отдельного типа приходят в поле json
+	response := send(&wspb.Envelope{V: 1, Type: "whoami", RequestId: "r1", Payload: &wspb.Envelope_Json{Json: []byte(`{}`)}})
+	assert.Equal(t, "r1", response.RequestId)
+	assert.JSONEq(t, `{"user_id":1}`, string(response.GetJson()))
+
+	// Проверяем, что подписка отвечает сообщением своего типа
+	response = send(&wspb.Envelope{V: 1, Type: SubscribeType, RequestId: "r2", Payload: &wspb.Envelope_Subscribe{
+		Subscribe: &wspb.SubscribePayload{AreaId: 4},
+	}})
+	assert.Nil(t, response.Error)
+	assert.Equal(t, "success", response.GetSubscribed().GetStatus())
+
+	// Проверяем, что ошибка разбора возвращается в контракте ошибок
+	response = send(&wspb.Envelope{V: 1, Type: "move", RequestId: "r3", Payload: &wspb.Envelope_Action{Action: &wspb.ActionPayload{AreaId: 4}}})
+	assert.Equal(t, int32(http.StatusBadRequest), response.GetError().GetCode())
+}
+
+// BenchmarkEnvelopeCodec сравнивает размер и скорость кодирования состояния мира в JSON и protobuf.
+func BenchmarkEnvelopeCodec(b *testing.B) {
+	state := testWorld()
+	for i := 0; i < 50; i++ {
+		unit := state.Units[0]
+		unit.Id = int64(100 + i)
+		state.Units = append(state.Units, unit)
+	}
+
+	for _, binary := range []bool{false, true} {
+		name := "JSON"
+		if binary {
+			name = "Protobuf"
+		}
+		b.Run(name, func(b *testing.B) {
+			encode := func() frame {
+				env, err := reply(Envelope{Type: "get_world_state"}, state)
+				if err != nil {
+					b.Fatal(err)
+				}
+				f, err := encodeFrame(env, binary)
+				if err != nil {
+					b.Fatal(err)
+				}
+				return f
+			}
+			b.ReportMetric(float64(len(encode().data)), "bytes/msg")
+			for i := 0; i < b.N; i++ {
+				encode()
+			}
+		})
+	}
+}
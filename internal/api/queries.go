@@ -0,0 +1,226 @@
+package server
+
+import (
+	"cyber/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WorldStore - хранилище, из которого читается состояние мира. Реализуется *postgress.Storage.
+type WorldStore interface {
+	GetUser(userId int64) (models.User, error)
+	GetLeague(leagueId int64) (models.League, error)
+	GetUserArea(userId int64) (models.Area, error)
+	GetArea(areaId int64) (models.Area, error)
+	GetNeutrals(areaId int64) ([]models.Neutral, error)
+	GetBuildings(areaId int64) ([]models.Building, error)
+	GetHeroes(areaId int64) ([]models.Hero, error)
+	GetUnits(areaId int64) ([]models.Unit, error)
+	GetEnemies(areaId int64) ([]models.Enemy, error)
+}
+
+// ResourceLedger - учет ресурсов пользователей. Реализуется *ledger.Ledger.
+type ResourceLedger interface {
+	Resources(userId int64) ([]models.Resource, error)
+	History(userId int64, limit int) ([]models.ResourceTransaction, error)
+}
+
+// AreaObjects - объекты, расположенные на арене.
+type AreaObjects struct {
+	Neutrals  []models.Neutral  `json:"neutrals"`  // Нейтральные объекты
+	Buildings []models.Building `json:"buildings"` // Здания
+	Heroes    []models.Hero     `json:"heroes"`    // Герои
+	Units     []models.Unit     `json:"units"`     // Юниты
+	Enemies   []models.Enemy    `json:"enemies"`   // Враги
+}
+
+// AreaData - ответ на запрос get_area_data.
+type AreaData struct {
+	Id         int64 `json:"id"`           // Идентификатор арены
+	UserId     int64 `json:"user_id"`      // Идентификатор владельца арены
+	Width      int   `json:"width"`        // Ширина арены
+	Height     int   `json:"height"`       // Высота арены
+	CellTypeId int   `json:"cell_type_id"` // Тип клеток арены
+	AreaObjects
+}
+
+// UserData - ответ на запрос get_user_data.
+type UserData struct {
+	Id           int64             `json:"id"`           // Идентификатор пользователя
+	Login        string            `json:"login"`        // Логин пользователя
+	Resources    []models.Resource `json:"resources"`    // Ресурсы пользователя
+	Subscription bool              `json:"subscription"` // Флаг подписки пользователя
+	LeagueId     int64             `json:"league_id"`    // Идентификатор лиги
+	League       *models.League    `json:"league"`       // Лига пользователя, null если пользователь не в лиге
+	Balance      decimal.Decimal   `json:"balance"`      // Баланс пользователя
+	Level        int               `json:"level"`        // Уровень пользователя
+}
+
+// ResourceHistory - ответ на запрос get_resource_history: последние изменения ресурсов, новые первыми.
+type ResourceHistory struct {
+	UserId       int64                        `json:"user_id"`      // Идентификатор пользователя
+	Transactions []models.ResourceTransaction `json:"transactions"` // Изменения ресурсов
+}
+
+// WorldState - ответ на запрос get_world_state: арена пользователя со всеми объектами на ней.
+type WorldState struct {
+	UserId    int64     `json:"user_id"`   // Идентификатор пользователя
+	AreaId    int64     `json:"area_id"`   // Идентификатор арены пользователя
+	Timestamp time.Time `json:"timestamp"` // Время, на которое получено состояние
+	AreaObjects
+}
+
+// GetAreaDataHandler обрабатывает запросы типа "get_area_data".
+type GetAreaDataHandler struct {
+	store WorldStore
+}
+
+func (gh *GetAreaDataHandler) Handle(action *models.Action) (interface{}, error) {
+	area, err := gh.store.GetArea(action.AreaId)
+	if err != nil {
+		return nil, fmt.Errorf("cant read area %v: %w", action.AreaId, err)
+	}
+	objects, err := loadAreaObjects(gh.store, area.Id)
+	if err != nil {
+		return nil, err
+	}
+	return AreaData{
+		Id:          area.Id,
+		UserId:      area.UserId,
+		Width:       area.Width,
+		Height:      area.Height,
+		CellTypeId:  area.CellTypeId,
+		AreaObjects: objects,
+	}, nil
+}
+
+// GetUserDataHandler обрабатывает запросы типа "get_user_data".
+type GetUserDataHandler struct {
+	store  WorldStore
+	ledger ResourceLedger
+}
+
+func (gh *GetUserDataHandler) Handle(action *models.Action) (interface{}, error) {
+	user, err := gh.store.GetUser(action.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("cant read user %v: %w", action.UserId, err)
+	}
+	resources, err := gh.ledger.Resources(user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("cant read resources of user %v: %w", user.Id, err)
+	}
+	if resources == nil {
+		resources = []models.Resource{}
+	}
+
+	var league *models.League
+	if user.LeagueId != 0 {
+		l, err := gh.store.GetLeague(user.LeagueId)
+		if err != nil {
+			return nil, fmt.Errorf("cant read league %v: %w", user.LeagueId, err)
+		}
+		league = &l
+	}
+
+	return UserData{
+		Id:           user.Id,
+		Login:        user.Login,
+		Resources:    resources,
+		Subscription: user.Subscription,
+		LeagueId:     user.LeagueId,
+		League:       league,
+		Balance:      user.Balance,
+		Level:        user.Level,
+	}, nil
+}
+
+// GetResourceHistoryHandler обрабатывает запросы типа "get_resource_history".
+type GetResourceHistoryHandler struct {
+	ledger ResourceLedger
+}
+
+func (gh *GetResourceHistoryHandler) Handle(action *models.Action) (interface{}, error) {
+	var characteristics models.ResourceHistoryCharacteristics
+	if len(action.Characteristics) > 0 {
+		c, err := UnmarshalCharacteristics[models.ResourceHistoryCharacteristics](action.Characteristics)
+		if err != nil {
+			return nil, err
+		}
+		characteristics = *c
+	}
+	transactions, err := gh.ledger.History(action.UserId, characteristics.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("cant read resource history of user %v: %w", action.UserId, err)
+	}
+	if transactions == nil {
+		transactions = []models.ResourceTransaction{}
+	}
+	return ResourceHistory{UserId: action.UserId, Transactions: transactions}, nil
+}
+
+// GetWorldStateHandler обрабатывает запросы типа "get_world_state".
+type GetWorldStateHandler struct {
+	store WorldStore
+	now   func() time.Time // подменяется в тестах
+}
+
+func (gh *GetWorldStateHandler) Handle(action *models.Action) (interface{}, error) {
+	area, err := gh.store.GetUserArea(action.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("cant read area of user %v: %w", action.UserId, err)
+	}
+	objects, err := loadAreaObjects(gh.store, area.Id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now
+	if gh.now != nil {
+		now = gh.now
+	}
+	return WorldState{
+		UserId:      action.UserId,
+		AreaId:      area.Id,
+		Timestamp:   now().UTC(),
+		AreaObjects: objects,
+	}, nil
+}
+
+// loadAreaObjects читает все объекты арены. Пустые списки возвращаются как [], а не null.
+func loadAreaObjects(store WorldStore, areaId int64) (AreaObjects, error) {
+	var objects AreaObjects
+	var err error
+	if objects.Neutrals, err = store.GetNeutrals(areaId); err != nil {
+		return AreaObjects{}, fmt.Errorf("cant read neutrals of area %v: %w", areaId, err)
+	}
+	if objects.Buildings, err = store.GetBuildings(areaId); err != nil {
+		return AreaObjects{}, fmt.Errorf("cant read buildings of area %v: %w", areaId, err)
+	}
+	if objects.Heroes, err = store.GetHeroes(areaId); err != nil {
+		return AreaObjects{}, fmt.Errorf("cant read heroes of area %v: %w", areaId, err)
+	}
+	if objects.Units, err = store.GetUnits(areaId); err != nil {
+		return AreaObjects{}, fmt.Errorf("cant read units of area %v: %w", areaId, err)
+	}
+	if objects.Enemies, err = store.GetEnemies(areaId); err != nil {
+		return AreaObjects{}, fmt.Errorf("cant read enemies of area %v: %w", areaId, err)
+	}
+
+	if objects.Neutrals == nil {
+		objects.Neutrals = []models.Neutral{}
+	}
+	if objects.Buildings == nil {
+		objects.Buildings = []models.Building{}
+	}
+	if objects.Heroes == nil {
+		objects.Heroes = []models.Hero{}
+	}
+	if objects.Units == nil {
+		objects.Units = []models.Unit{}
+	}
+	if objects.Enemies == nil {
+		objects.Enemies = []models.Enemy{}
+	}
+	return objects, nil
+}
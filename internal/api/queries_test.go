@@ -0,0 +1,227 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+// go test ./internal/api -update перезаписывает эталонные ответы в testdata
+var update = flag.Bool("update", false, "update golden files")
+
+// fakeWorld - хранилище с ареной 4 пользователя 1 и ареной 8 пользователя 2.
+type fakeWorld struct {
+	empty bool // арена без объектов
+}
+
+func (f *fakeWorld) GetUser(userId int64) (models.User, error) {
+	if userId != 1 {
+		return models.User{}, storage.ErrNotFound
+	}
+	return models.User{Id: 1, Login: "user1", Password: "secret", Email: "user1@example.com", Subscription: true,
+		LeagueId: 2, Balance: decimal.NewFromInt(1000), Level: 5}, nil
+}
+
+func (f *fakeWorld) GetLeague(leagueId int64) (models.League, error) {
+	return models.League{Id: leagueId, Name: "Silver League", Authority: 200}, nil
+}
+
+func (f *fakeWorld) Resources(userId int64) ([]models.Resource, error) {
+	return []models.Resource{
+		{Id: 1, Name: "Gold", Value: decimal.NewFromInt(1500)},
+		{Id: 2, Name: "Wood", Value: decimal.NewFromInt(800)},
+	}, nil
+}
+
+func (f *fakeWorld) History(userId int64, limit int) ([]models.ResourceTransaction, error) {
+	if f.empty {
+		return nil, nil
+	}
+	created := time.Date(2024, 10, 1, 11, 0, 0, 0, time.UTC)
+	history := []models.ResourceTransaction{
+		{Id: 3, UserId: userId, ResourceName: "Gold", Amount: decimal.NewFromInt(-500), Reason: "upgrade", CreatedAt: created.Add(time.Minute)},
+		{Id: 2, UserId: userId, ResourceName: "Wood", Amount: decimal.NewFromInt(300), Reason: "harvest", CreatedAt: created},
+	}
+	if limit > 0 && limit < len(history) {
+		history = history[:limit]
+	}
+	return history, nil
+}
+
+func (f *fakeWorld) GetResourceNames() ([]string, error) {
+	return []string{"Gold", "Wood", "Stone", "Food"}, nil
+}
+
+func (f *fakeWorld) GetUserArea(userId int64) (models.Area, error) {
+	if userId != 1 {
+		return models.Area{}, storage.ErrNotFound
+	}
+	return f.GetArea(4)
+}
+
+func (f *fakeWorld) GetArea(areaId int64) (models.Area, error) {
+	if areaId < 1 {
+		return models.Area{}, storage.ErrNotValidAreaID
+	}
+	switch areaId {
+	case 4:
+		return models.Area{Id: 4, UserId: 1, Width: 100, Height: 80, CellTypeId: 1}, nil
+	case 8:
+		return models.Area{Id: 8, UserId: 2, Width: 50, Height: 50, CellTypeId: 2}, nil
+	}
+	return models.Area{}, storage.ErrNotFound
+}
+
+// GetAreaObjectKinds возвращает объекты арены 4 из Get* методов. На арене 8 есть только герой 3.
+func (f *fakeWorld) GetAreaObjectKinds(areaId, objectId int64) ([]models.ObjectKind, error) {
+	objects := map[int64]models.ObjectKind{1: models.NeutralObject, 2: models.BuildingObject, 3: models.HeroObject,
+		5: models.UnitObject, 6: models.EnemyObject}
+	if areaId == 8 && objectId == 3 || areaId == 4 && objects[objectId] != "" {
+		return []models.ObjectKind{objects[objectId]}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeWorld) GetNeutrals(areaId int64) ([]models.Neutral, error) {
+	if f.empty {
+		return nil, nil
+	}
+	return []models.Neutral{{
+		Id: 1, Name: "Gold Mine", Product: "Gold", ProductivityCoefficient: 5,
+		Capacity: decimal.NewFromInt(500), ThresholdLevel1: decimal.NewFromInt(50), ThresholdLevel2: decimal.NewFromInt(250),
+		Size: 2, Coordinates: []models.Hex{{Q: 30, R: 40}, {Q: 31, R: 40}},
+	}}, nil
+}
+
+func (f *fakeWorld) GetBuildings(areaId int64) ([]models.Building, error) {
+	if f.empty {
+		return nil, nil
+	}
+	return []models.Building{{
+		Id: 2, Name: "Farm", Product: "Food",
+		Charachteristics: models.BuildingCharacteristics{HP: 600, Armor: 20, ProductivityCoefficient: 4, Size: 1},
+		Level:            2,
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(150)}},
+		Coordinates:      []models.Hex{{Q: 110, R: 120}},
+	}}, nil
+}
+
+func (f *fakeWorld) GetHeroes(areaId int64) ([]models.Hero, error) {
+	if f.empty {
+		return nil, nil
+	}
+	return []models.Hero{{
+		Id: 3, Name: "Hero1",
+		Charachteristics: models.HeroCharacteristics{HP: 500, HPnow: 450, Armor: 10, Speed: decimal.NewFromInt(2),
+			Vision: 3, AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(50)},
+		Experience:     decimal.NewFromInt(10),
+		ExperienceToUp: decimal.NewFromInt(100),
+		Level:          1,
+		Abilities: []models.Ability{{Id: 1, Name: "Fireball", Level: 1,
+			Charachteristics: models.AbilitytCharacteristics{Radius: decimal.NewFromInt(2), Cooldown: 5 * time.Second,
+				Damage: decimal.NewFromInt(100), ProjectilSpeed: decimal.NewFromInt(5)}}},
+		Coordinates: []models.Hex{{Q: 0, R: 0}},
+	}}, nil
+}
+
+func (f *fakeWorld) GetUnits(areaId int64) ([]models.Unit, error) {
+	if f.empty {
+		return nil, nil
+	}
+	return []models.Unit{{
+		Id: 5, Name: "Warrior",
+		Charachteristics: models.UnitCharacteristics{HP: 200, HPnow: 200, Armor: 5, Speed: decimal.NewFromInt(3),
+			Vision: 4, AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(20), ProductivityCoefficient: 2},
+		Experience:     decimal.Zero,
+		ExperienceToUp: decimal.NewFromInt(50),
+		Level:          1,
+		Coordinates:    []models.Hex{{Q: 19, R: 20}},
+	}}, nil
+}
+
+func (f *fakeWorld) GetEnemies(areaId int64) ([]models.Enemy, error) {
+	if f.empty {
+		return nil, nil
+	}
+	return []models.Enemy{{
+		Id: 6, Name: "Goblin",
+		Charachteristics: models.EnemyCharacteristics{HP: 100, Armor: 2, Speed: decimal.NewFromInt(2), Vision: 4,
+			AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(10), Experience: decimal.NewFromInt(20), Level: 1},
+		Level:       1,
+		Coordinates: []models.Hex{{Q: 250, R: 260}},
+	}}, nil
+}
+
+// assertGolden сравнивает ответ с эталоном из testdata/name.golden.json.
+func assertGolden(t *testing.T, name string, response Envelope) {
+	t.Helper()
+	got, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cant read golden file (run with -update to create it): %v", err)
+	}
+	// Payload сравнивается после переформатирования, поэтому эталон не зависит от отступов вложенного JSON
+	var wantIndented, gotIndented bytes.Buffer
+	assert.NoError(t, json.Indent(&wantIndented, want, "", "  "))
+	assert.NoError(t, json.Indent(&gotIndented, got, "", "  "))
+	assert.Equal(t, wantIndented.String(), gotIndented.String())
+}
+
+func TestQueryHandlersGolden(t *testing.T) {
+	now := func() time.Time { return time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) }
+
+	// userId - пользователь сессии соединения, user_id из сообщения не используется
+	tests := []struct {
+		name    string
+		store   *fakeWorld
+		userId  int64
+		message string
+	}{
+		{"world_state", &fakeWorld{}, 1, `{"type":"get_world_state","request_id":"r1","payload":{"user_id":1}}`},
+		{"world_state_empty", &fakeWorld{empty: true}, 1, `{"type":"get_world_state","request_id":"r2"}`},
+		{"user_data", &fakeWorld{}, 1, `{"type":"get_user_data","request_id":"r3","payload":{"user_id":9}}`},
+		{"area_data", &fakeWorld{}, 1, `{"type":"get_area_data","request_id":"r4","payload":{"area_id":4}}`},
+		{"area_not_found", &fakeWorld{}, 1, `{"type":"get_area_data","request_id":"r5","payload":{"area_id":7}}`},
+		{"area_invalid_id", &fakeWorld{}, 1, `{"type":"get_area_data","request_id":"r6","payload":{}}`},
+		{"user_not_found", &fakeWorld{}, 9, `{"type":"get_user_data","request_id":"r7"}`},
+		{"resource_history", &fakeWorld{}, 1, `{"type":"get_resource_history","request_id":"r8"}`},
+		{"resource_history_limit", &fakeWorld{}, 1, `{"type":"get_resource_history","request_id":"r9","payload":{"characteristics":{"limit":1}}}`},
+		{"resource_history_empty", &fakeWorld{empty: true}, 1, `{"type":"get_resource_history","request_id":"r10"}`},
+		{"resource_history_bad_limit", &fakeWorld{}, 1, `{"type":"get_resource_history","request_id":"r11","payload":{"characteristics":{"limit":-1}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+			h.authorizer = NewAuthorizer(tt.store, 0)
+			h.actionHandlers["get_world_state"] = &GetWorldStateHandler{store: tt.store, now: now}
+			h.actionHandlers["get_user_data"] = &GetUserDataHandler{store: tt.store, ledger: tt.store}
+			h.actionHandlers["get_resource_history"] = &GetResourceHistoryHandler{ledger: tt.store}
+			h.actionHandlers["get_area_data"] = &GetAreaDataHandler{store: tt.store}
+
+			// Проверяем, что форма ответа совпадает с эталоном
+			assertGolden(t, tt.name, h.process(&Session{userId: tt.userId}, []byte(tt.message)))
+		})
+	}
+}
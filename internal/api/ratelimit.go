@@ -0,0 +1,221 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrRateLimited        = errors.New("rate limit exceeded")
+	ErrRepeatedViolations = fmt.Errorf("%w repeatedly", ErrRateLimited)
+)
+
+// Limit - ограничение частоты сообщений по алгоритму token bucket.
+type Limit struct {
+	Rate  float64 // Сообщений в секунду, 0 - без ограничения
+	Burst int     // Сообщений, которые можно отправить сверх Rate за раз
+}
+
+func (l Limit) limiter() *rate.Limiter {
+	if l.Rate <= 0 {
+		return nil
+	}
+	burst := l.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(l.Rate), burst)
+}
+
+// Limits - ограничения websocket сообщений.
+type Limits struct {
+	Connection Limit            // Сообщения одного соединения
+	User       Limit            // Сообщения всех соединений пользователя
+	Actions    map[string]Limit // Сообщения пользователя одного типа
+	// Violations - допустимая частота отклоненных сообщений. Соединение,
+	// превысившее ее, закрывается с кодом 1008
+	Violations     Limit
+	MaxMessageSize int // Максимальный размер сообщения в байтах, больше - соединение закрывается с кодом 1009
+}
+
+// HARDCODE DefaultLimits - ограничения websocket сообщений по умолчанию
+var DefaultLimits = Limits{
+	Connection: Limit{Rate: 20, Burst: 40},
+	User:       Limit{Rate: 30, Burst: 60},
+	Actions: map[string]Limit{
+		"move":       {Rate: 10, Burst: 20},
+		"attack":     {Rate: 5, Burst: 10},
+		"harvest":    {Rate: 2, Burst: 5},
+		"build":      {Rate: 1, Burst: 3},
+		"upgrade":    {Rate: 1, Burst: 3},
+		"group_move": {Rate: 2, Burst: 5},
+	},
+	Violations:     Limit{Rate: 20.0 / 60, Burst: 20},
+	MaxMessageSize: 16 * 1024,
+}
+
+// WithActions возвращает копию ограничений, в которой ограничения типов сообщений из actions
+// заменены. Ограничения остальных типов остаются прежними, исходные Limits не меняются.
+func (l Limits) WithActions(actions map[string]Limit) Limits {
+	merged := make(map[string]Limit, len(l.Actions)+len(actions))
+	for messageType, limit := range l.Actions {
+		merged[messageType] = limit
+	}
+	for messageType, limit := range actions {
+		merged[messageType] = limit
+	}
+	l.Actions = merged
+	return l
+}
+
+// RateLimiter ограничивает частоту сообщений соединений и пользователей.
+// Ограничения пользователя общие для всех его соединений и хранятся, пока открыто хотя бы одно из них.
+type RateLimiter struct {
+	limits  Limits
+	metrics *Metrics
+
+	mu    sync.Mutex
+	users map[int64]*userLimiter
+}
+
+type userLimiter struct {
+	all     *rate.Limiter
+	actions map[string]*rate.Limiter
+	conns   int
+}
+
+// Конструктор для RateLimiter. metrics может быть nil.
+func NewRateLimiter(limits Limits, metrics *Metrics) *RateLimiter {
+	return &RateLimiter{limits: limits, metrics: metrics, users: make(map[int64]*userLimiter)}
+}
+
+// Open создает ограничитель нового соединения пользователя userId.
+// Ограничитель освобождается методом Close при закрытии соединения.
+func (rl *RateLimiter) Open(userId int64) *ConnLimiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	user, ok := rl.users[userId]
+	if !ok {
+		user = &userLimiter{all: rl.limits.User.limiter(), actions: make(map[string]*rate.Limiter)}
+		for actionType, limit := range rl.limits.Actions {
+			user.actions[actionType] = limit.limiter()
+		}
+		rl.users[userId] = user
+	}
+	user.conns++
+	rl.metrics.connOpened()
+	return &ConnLimiter{
+		owner:      rl,
+		userId:     userId,
+		user:       user,
+		conn:       rl.limits.Connection.limiter(),
+		violations: rl.limits.Violations.limiter(),
+	}
+}
+
+// ConnLimiter - ограничитель сообщений одного соединения. Методы nil ограничителя ничего не ограничивают.
+type ConnLimiter struct {
+	owner      *RateLimiter
+	userId     int64
+	user       *userLimiter
+	conn       *rate.Limiter
+	violations *rate.Limiter
+
+	mu       sync.Mutex
+	exceeded bool // соединение слишком часто превышало ограничения
+	closed   bool
+}
+
+// Allow проверяет, можно ли обработать сообщение типа messageType. Сообщение учитывается
+// во всех ограничениях сразу: если хотя бы одно превышено, не расходуется ни одно.
+// Возвращает ErrRateLimited, а если отклоненных сообщений слишком много - ErrRepeatedViolations.
+func (c *ConnLimiter) Allow(messageType string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.exceeded {
+		return ErrRepeatedViolations
+	}
+
+	now := time.Now()
+	scope := take(now, []scopedLimiter{
+		{"action", c.user.actions[messageType]},
+		{"connection", c.conn},
+		{"user", c.user.all},
+	})
+	if scope == "" {
+		return nil
+	}
+	c.owner.metrics.throttled(scope)
+	if c.violations != nil && !c.violations.AllowN(now, 1) {
+		c.exceeded = true
+		return ErrRepeatedViolations
+	}
+	return fmt.Errorf("%w: %s limit for %s", ErrRateLimited, scope, messageType)
+}
+
+// Exceeded сообщает, что соединение слишком часто превышало ограничения и должно быть закрыто.
+func (c *ConnLimiter) Exceeded() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.exceeded
+}
+
+// Close освобождает ограничитель. Ограничения пользователя удаляются вместе с последним его соединением.
+func (c *ConnLimiter) Close() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	closed := c.closed
+	c.closed = true
+	c.mu.Unlock()
+	if closed {
+		return
+	}
+
+	rl := c.owner
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	c.user.conns--
+	if c.user.conns == 0 {
+		delete(rl.users, c.userId)
+	}
+	rl.metrics.connClosed()
+}
+
+// scopedLimiter - ограничитель и название его области для метрик.
+type scopedLimiter struct {
+	scope   string
+	limiter *rate.Limiter
+}
+
+// take расходует по одному токену из всех ограничителей limiters. Если в каком-то
+// из них токенов нет, уже взятые токены возвращаются, а результатом будет область этого ограничителя.
+func take(now time.Time, limiters []scopedLimiter) string {
+	var taken []*rate.Reservation
+	for _, l := range limiters {
+		if l.limiter == nil {
+			continue
+		}
+		r := l.limiter.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, t := range taken {
+				t.CancelAt(now)
+			}
+			return l.scope
+		}
+		taken = append(taken, r)
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lxzan/gws"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnLimiterAllow(t *testing.T) {
+	limits := Limits{
+		Connection: Limit{Rate: 0.001, Burst: 3},
+		User:       Limit{Rate: 0.001, Burst: 4},
+		Actions:    map[string]Limit{"build": {Rate: 0.001, Burst: 1}},
+		Violations: Limit{Rate: 0.001, Burst: 2},
+	}
+
+	tests := []struct {
+		name     string
+		messages []string // типы сообщений
+		conns    []int    // номер соединения пользователя для каждого сообщения
+		expected []error
+	}{
+		{
+			name:     "Connection burst",
+			messages: []string{"move", "move", "move", "move"},
+			conns:    []int{0, 0, 0, 0},
+			expected: []error{nil, nil, nil, ErrRateLimited},
+		},
+		{
+			name:     "Action burst does not spend connection tokens",
+			messages: []string{"build", "build", "move", "move"},
+			conns:    []int{0, 0, 0, 0},
+			expected: []error{nil, ErrRateLimited, nil, nil},
+		},
+		{
+			name:     "User limit is shared by connections",
+			messages: []string{"move", "move", "move", "move", "move"},
+			conns:    []int{0, 1, 0, 1, 1},
+			expected: []error{nil, nil, nil, nil, ErrRateLimited},
+		},
+		{
+			name:     "Repeated violations",
+			messages: []string{"build", "build", "build", "build", "move"},
+			conns:    []int{0, 0, 0, 0, 0},
+			expected: []error{nil, ErrRateLimited, ErrRateLimited, ErrRepeatedViolations, ErrRepeatedViolations},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiter(limits, nil)
+			conns := []*ConnLimiter{rl.Open(1), rl.Open(1)}
+			for i, messageType := range tt.messages {
+				err := conns[tt.conns[i]].Allow(messageType)
+
+				// Проверяем, что превышение любого ограничения отклоняет сообщение
+				if tt.expected[i] == nil {
+					assert.NoError(t, err, "message %d", i)
+				} else {
+					assert.ErrorIs(t, err, tt.expected[i], "message %d", i)
+				}
+			}
+			assert.Equal(t, errors.Is(tt.expected[len(tt.expected)-1], ErrRepeatedViolations), conns[0].Exceeded())
+		})
+	}
+}
+
+func TestLimitsWithActions(t *testing.T) {
+	limits := DefaultLimits.WithActions(map[string]Limit{
+		"move":            {Rate: 1, Burst: 1},
+		"get_world_state": {Rate: 0.001, Burst: 2},
+	})
+
+	// Проверяем, что заданные ограничения заменяют ограничения по умолчанию, а остальные сохраняются
+	assert.Equal(t, Limit{Rate: 1, Burst: 1}, limits.Actions["move"])
+	assert.Equal(t, DefaultLimits.Actions["attack"], limits.Actions["attack"])
+	assert.Equal(t, Limit{Rate: 10, Burst: 20}, DefaultLimits.Actions["move"])
+
+	// Ограничение нового типа сообщений применяется
+	conn := NewRateLimiter(limits, nil).Open(1)
+	for i, expected := range []error{nil, nil, ErrRateLimited} {
+		err := conn.Allow("get_world_state")
+		if expected == nil {
+			assert.NoError(t, err, "message %d", i)
+		} else {
+			assert.ErrorIs(t, err, expected, "message %d", i)
+		}
+	}
+}
+
+func TestRateLimiterReleasesUser(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+	rl := NewRateLimiter(Limits{User: Limit{Rate: 0.001, Burst: 1}}, metrics)
+
+	first, second := rl.Open(1), rl.Open(1)
+	assert.NoError(t, first.Allow("move"))
+	assert.ErrorIs(t, second.Allow("move"), ErrRateLimited)
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.connections))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.throttledBy.WithLabelValues("user")))
+
+	// Проверяем, что ограничения пользователя хранятся, пока открыто хотя бы одно соединение
+	first.Close()
+	first.Close()
+	assert.ErrorIs(t, rl.Open(1).Allow("move"), ErrRateLimited)
+	second.Close()
+	assert.Len(t, rl.users, 1)
+
+	var nilLimiter *ConnLimiter
+	assert.NoError(t, nilLimiter.Allow("move"))
+	nilLimiter.Close()
+}
+
+// closeHandler запоминает код закрытия соединения сервером
+type closeHandler struct {
+	messageHandler
+	closed chan error
+}
+
+func (c *closeHandler) OnClose(socket *gws.Conn, err error) {
+	c.closed <- err
+}
+
+func TestWebSocketRateLimit(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+	limiter := NewRateLimiter(Limits{
+		Connection:     Limit{Rate: 0.001, Burst: 2},
+		Violations:     Limit{Rate: 0.001, Burst: 1},
+		MaxMessageSize: 256,
+	}, metrics)
+	handler := NewWebSocketHandler(nil, nil, nil, nil, nil, limiter)
+	handler.actionHandlers["whoami"] = &userEchoHandler{}
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go wsServer.Serve(ln)
+
+	dial := func() (*gws.Conn, *closeHandler) {
+		client := &closeHandler{messageHandler{messages: make(chan Envelope, 8)}, make(chan error, 1)}
+		socket, _, err := gws.NewClient(client, newTestClientOption(ln.Addr().String(), 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		go socket.ReadLoop()
+		return socket, client
+	}
+	closeCode := func(client *closeHandler) uint16 {
+		select {
+		case err := <-client.closed:
+			var closeErr *gws.CloseError
+			if errors.As(err, &closeErr) {
+				return closeErr.Code
+			}
+			t.Fatalf("unexpected close error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("connection was not closed")
+		}
+		return 0
+	}
+
+	// Проверяем, что после исчерпания ограничения клиент получает 429, а затем соединение закрывается
+	socket, client := dial()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, socket.WriteString(`{"type":"whoami","request_id":"r"}`))
+	}
+	var codes []int
+	for i := 0; i < 4; i++ {
+		response := <-client.messages
+		if response.Error != nil {
+			codes = append(codes, response.Error.Code)
+		} else {
+			codes = append(codes, 0)
+		}
+	}
+	assert.Equal(t, []int{0, 0, 429, 429}, codes)
+	assert.Equal(t, uint16(1008), closeCode(client))
+
+	// Проверяем, что слишком большое сообщение закрывает соединение с кодом 1009
+	socket, client = dial()
+	assert.NoError(t, socket.WriteString(`{"type":"whoami","request_id":"`+string(make([]byte, 300))+`"}`))
+	assert.Equal(t, uint16(1009), closeCode(client))
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.connections) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.disconnects.WithLabelValues("rate_limit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.disconnects.WithLabelValues("message_too_large")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.throttledBy.WithLabelValues("connection")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(metrics.messages.WithLabelValues("whoami")))
+}
@@ -0,0 +1,356 @@
+package server
+
+import (
+	"context"
+	"cyber/internal/events"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/lxzan/gws"
+)
+
+// HARDCODE размер очереди отправки одного соединения
+const DefaultQueueSize = 64
+
+var ErrSlowClient = errors.New("client is too slow")
+
+// socketWriter - часть *gws.Conn, используемая для отправки сообщений. Подменяется в тестах.
+type socketWriter interface {
+	WriteMessage(opcode gws.Opcode, payload []byte) error
+	WriteClose(code uint16, reason []byte) error
+}
+
+// Session - websocket соединение аутентифицированного пользователя.
+// Все сообщения соединению кодируются в формате соединения, ставятся в очередь и отправляются
+// отдельной горутиной,
+// поэтому медленный клиент не задерживает обработку событий для остальных.
+type Session struct {
+	conn    socketWriter
+	userId  int64
+	areaId  int64
+	limiter *ConnLimiter // ограничитель частоты сообщений, nil - без ограничений
+	world   worldSync    // состояния мира, отправленные соединению
+	binary  bool         // соединение получает сообщения в protobuf (ProtobufSubprotocol)
+
+	queue   chan frame
+	done    chan struct{} // закрывается, когда сессию нужно закрыть
+	stopped chan struct{} // закрывается, когда отправка сообщений остановлена
+	once    sync.Once
+	code    uint16 // код закрытия соединения, 0 - соединение уже закрыто клиентом
+	reason  error
+}
+
+// Send кодирует сообщение в формате соединения и ставит его в очередь отправки.
+func (s *Session) Send(env Envelope) error {
+	f, err := encodeFrame(env, s.binary)
+	if err != nil {
+		return err
+	}
+	return s.enqueue(f)
+}
+
+// enqueue ставит закодированное сообщение в очередь отправки. Если очередь переполнена, клиент
+// не успевает читать сообщения - соединение закрывается, а клиент должен переподключиться.
+func (s *Session) enqueue(f frame) error {
+	select {
+	case <-s.done:
+		return ErrShuttingDown
+	default:
+	}
+	select {
+	case s.queue <- f:
+		return nil
+	default:
+		s.close(1013, ErrSlowClient)
+		return ErrSlowClient
+	}
+}
+
+// writeLoop отправляет сообщения из очереди, пока сессия не закрыта.
+// При остановке сервера (код 1001) и закрытии за нарушения (код 1008) перед закрытием
+// отправляются уже поставленные в очередь сообщения.
+func (s *Session) writeLoop() {
+	defer close(s.stopped)
+	for {
+		select {
+		case <-s.done:
+			if s.code == 1001 || s.code == 1008 {
+				s.flush()
+			}
+			if s.code != 0 {
+				s.conn.WriteClose(s.code, []byte(s.reason.Error()))
+			}
+			return
+		case f := <-s.queue:
+			if err := s.conn.WriteMessage(f.opcode, f.data); err != nil {
+				log.Printf("failed to send message: %v\n", err)
+				s.close(0, err)
+			}
+		}
+	}
+}
+
+func (s *Session) flush() {
+	for {
+		select {
+		case f := <-s.queue:
+			if err := s.conn.WriteMessage(f.opcode, f.data); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// close закрывает соединение с кодом code. Код 0 означает, что соединение уже закрыто.
+func (s *Session) close(code uint16, reason error) {
+	s.once.Do(func() {
+		s.code, s.reason = code, reason
+		close(s.done)
+	})
+}
+
+// Registry - реестр открытых соединений по пользователю и арене.
+// Registry читает события из шины и рассылает их всем соединениям, наблюдающим арену события,
+// а события, адресованные пользователю, - всем соединениям этого пользователя.
+// События арены нумеруются и хранятся в окне последних событий, чтобы переподключившийся
+// клиент мог получить пропущенные. Окно хранится, пока у арены есть наблюдатели.
+type Registry struct {
+	queueSize int
+	window    int
+
+	mu       sync.RWMutex
+	sessions map[socketWriter]*Session
+	byArea   map[int64]map[*Session]struct{}
+	byUser   map[int64]map[*Session]struct{}
+	streams  map[int64]*areaStream
+}
+
+// Конструктор для Registry. queueSize - размер очереди отправки одного соединения.
+func NewRegistry(queueSize int) *Registry {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Registry{
+		queueSize: queueSize,
+		window:    max(min(DefaultEventWindow, queueSize/2), 1),
+		sessions:  make(map[socketWriter]*Session),
+		byArea:    make(map[int64]map[*Session]struct{}),
+		byUser:    make(map[int64]map[*Session]struct{}),
+		streams:   make(map[int64]*areaStream),
+	}
+}
+
+// Add регистрирует соединение пользователя userId и запускает отправку сообщений в него.
+// С этого момента соединение получает события, адресованные пользователю.
+func (r *Registry) Add(conn socketWriter, userId int64) *Session {
+	s := &Session{
+		conn:    conn,
+		userId:  userId,
+		queue:   make(chan frame, r.queueSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if p, ok := conn.(interface{ SubProtocol() string }); ok {
+		s.binary = p.SubProtocol() == ProtobufSubprotocol
+	}
+	r.mu.Lock()
+	r.sessions[conn] = s
+	index(r.byUser, userId, s)
+	r.mu.Unlock()
+	go s.writeLoop()
+	return s
+}
+
+// Get возвращает сессию соединения.
+func (r *Registry) Get(conn socketWriter) (*Session, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sessions[conn]
+	return s, ok
+}
+
+// Watch привязывает соединение к арене, события которой оно будет получать.
+// Возвращает номер последнего события арены: соединение получит события с большими номерами.
+func (r *Registry) Watch(s *Session, areaId int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watch(s, areaId)
+	if st, ok := r.streams[areaId]; ok {
+		return st.seq
+	}
+	return 0
+}
+
+// Resume привязывает соединение к арене, как Watch, и ставит в его очередь события арены
+// с номерами больше lastSeq. Возвращает номер последнего события арены и false, если пропущенных
+// событий уже нет в окне (или сервер перезапущен) и клиенту нужен снимок арены.
+func (r *Registry) Resume(s *Session, areaId, lastSeq int64) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watch(s, areaId)
+	st, ok := r.streams[areaId]
+	if !ok {
+		return 0, lastSeq == 0
+	}
+	missed, ok := st.since(lastSeq)
+	if !ok {
+		return st.seq, false
+	}
+	for _, env := range missed {
+		if err := s.Send(env); err != nil {
+			return st.seq, false
+		}
+	}
+	return st.seq, true
+}
+
+func (r *Registry) watch(s *Session, areaId int64) {
+	if s.areaId != areaId {
+		r.unwatch(s)
+	}
+	s.areaId = areaId
+	index(r.byArea, areaId, s)
+}
+
+// Remove удаляет соединение из реестра и останавливает отправку сообщений в него.
+func (r *Registry) Remove(conn socketWriter) {
+	r.mu.Lock()
+	s, ok := r.sessions[conn]
+	if ok {
+		delete(r.sessions, conn)
+		r.unindex(s)
+	}
+	r.mu.Unlock()
+	if ok {
+		s.close(0, ErrShuttingDown)
+	}
+}
+
+// CloseAll закрывает все соединения с кодом code и ждет, пока им будут отправлены
+// оставшиеся сообщения, или пока не будет отменен ctx.
+func (r *Registry) CloseAll(ctx context.Context, code uint16, reason error) error {
+	r.mu.RLock()
+	sessions := make([]*Session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+	r.mu.RUnlock()
+	for _, s := range sessions {
+		s.close(code, reason)
+	}
+	for _, s := range sessions {
+		select {
+		case <-s.stopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Publish отправляет событие всем соединениям, наблюдающим его арену или принадлежащим его пользователю.
+// Событию арены присваивается следующий номер. Publish вызывается из одной горутины (Run),
+// поэтому соединения получают события арены в порядке номеров.
+func (r *Registry) Publish(e events.Event) {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		log.Printf("cant marshal event %v: %v\n", e.Type, err)
+		return
+	}
+
+	r.mu.Lock()
+	env := Envelope{Version: ProtocolVersion, Type: e.Type, Payload: payload, result: e.Payload}
+	if e.AreaId != 0 && len(r.byArea[e.AreaId]) > 0 {
+		st := r.stream(e.AreaId)
+		env.Seq = st.seq + 1
+		st.append(env)
+	}
+
+	targets := make(map[*Session]struct{})
+	if e.AreaId != 0 {
+		for s := range r.byArea[e.AreaId] {
+			targets[s] = struct{}{}
+		}
+	}
+	if e.UserId != 0 {
+		for s := range r.byUser[e.UserId] {
+			targets[s] = struct{}{}
+		}
+	}
+	r.mu.Unlock()
+
+	// Событие кодируется один раз для каждого формата соединений
+	frames := make(map[bool]frame, 2)
+	for s := range targets {
+		f, ok := frames[s.binary]
+		if !ok {
+			if f, err = encodeFrame(env, s.binary); err != nil {
+				log.Printf("cant encode event %v: %v\n", e.Type, err)
+				continue
+			}
+			frames[s.binary] = f
+		}
+		if err := s.enqueue(f); err != nil {
+			log.Printf("event %v for area ID- %v not delivered: %v\n", e.Type, e.AreaId, err)
+		}
+	}
+}
+
+// Run рассылает события из шины bus, пока не будет отменен ctx.
+func (r *Registry) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(events.Filter{}, events.DefaultBuffer)
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-sub.C:
+			r.Publish(e)
+		}
+	}
+}
+
+// stream возвращает окно событий арены, создавая его при первом событии арены, у которой есть наблюдатели.
+func (r *Registry) stream(areaId int64) *areaStream {
+	st, ok := r.streams[areaId]
+	if !ok {
+		st = &areaStream{window: r.window}
+		r.streams[areaId] = st
+	}
+	return st
+}
+
+func (r *Registry) unindex(s *Session) {
+	unindex(r.byUser, s.userId, s)
+	r.unwatch(s)
+}
+
+// unwatch отвязывает соединение от арены. Окно событий арены, у которой не осталось
+// наблюдателей, удаляется: переподключившийся клиент получит снимок арены.
+func (r *Registry) unwatch(s *Session) {
+	unindex(r.byArea, s.areaId, s)
+	if _, ok := r.byArea[s.areaId]; !ok {
+		delete(r.streams, s.areaId)
+	}
+}
+
+func index(m map[int64]map[*Session]struct{}, key int64, s *Session) {
+	if key == 0 {
+		return
+	}
+	if m[key] == nil {
+		m[key] = make(map[*Session]struct{})
+	}
+	m[key][s] = struct{}{}
+}
+
+func unindex(m map[int64]map[*Session]struct{}, key int64, s *Session) {
+	delete(m[key], s)
+	if len(m[key]) == 0 {
+		delete(m, key)
+	}
+}
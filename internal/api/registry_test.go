@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lxzan/gws"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/events"
+)
+
+// fakeSocket запоминает отправленные сообщения. Пока block не закрыт, отправка блокируется.
+type fakeSocket struct {
+	mu       sync.Mutex
+	messages []Envelope
+	closed   uint16
+	block    chan struct{}
+}
+
+func (f *fakeSocket) WriteMessage(opcode gws.Opcode, payload []byte) error {
+	if f.block != nil {
+		<-f.block
+	}
+	var env Envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, env)
+	return nil
+}
+
+func (f *fakeSocket) WriteClose(code uint16, reason []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = code
+	return nil
+}
+
+func (f *fakeSocket) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	types := make([]string, 0, len(f.messages))
+	for _, m := range f.messages {
+		types = append(types, m.Type)
+	}
+	return types
+}
+
+func (f *fakeSocket) closeCode() uint16 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+func TestRegistryPublish(t *testing.T) {
+	registry := NewRegistry(DefaultQueueSize)
+	areaWatcher, userWatcher, other := &fakeSocket{}, &fakeSocket{}, &fakeSocket{}
+	registry.Watch(registry.Add(areaWatcher, 1), 10)
+	registry.Watch(registry.Add(userWatcher, 2), 20)
+	registry.Watch(registry.Add(other, 3), 30)
+	notSubscribed := &fakeSocket{}
+	registry.Add(notSubscribed, 2)
+
+	// Проверяем, что событие арены получают наблюдающие ее соединения,
+	// а событие пользователя - все соединения этого пользователя, даже без подписки на арену
+	registry.Publish(events.Event{Type: "upgrade", AreaId: 10, Payload: map[string]int{"level": 2}})
+	registry.Publish(events.Event{Type: "level_up", UserId: 2})
+
+	assert.Eventually(t, func() bool {
+		return len(areaWatcher.received()) == 1 && len(userWatcher.received()) == 1 && len(notSubscribed.received()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"upgrade"}, areaWatcher.received())
+	assert.Equal(t, []string{"level_up"}, userWatcher.received())
+	assert.Equal(t, []string{"level_up"}, notSubscribed.received())
+	assert.Empty(t, other.received())
+
+	// Проверяем, что после отключения соединение больше не получает события
+	registry.Remove(areaWatcher)
+	registry.Publish(events.Event{Type: "upgrade", AreaId: 10})
+	assert.NoError(t, registry.CloseAll(context.Background(), 1001, ErrShuttingDown))
+	assert.Equal(t, []string{"upgrade"}, areaWatcher.received())
+	assert.Zero(t, areaWatcher.closeCode())
+}
+
+func TestRegistrySlowClient(t *testing.T) {
+	registry := NewRegistry(2)
+	slow := &fakeSocket{block: make(chan struct{})}
+	fast := &fakeSocket{}
+	registry.Watch(registry.Add(slow, 1), 10)
+	registry.Watch(registry.Add(fast, 2), 10)
+
+	// Проверяем, что медленный клиент отключается с кодом 1013 при переполнении очереди,
+	// а остальные соединения получают все события
+	for i := 0; i < 5; i++ {
+		registry.Publish(events.Event{Type: "move", AreaId: 10})
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(slow.block)
+
+	assert.Eventually(t, func() bool { return slow.closeCode() == 1013 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return len(fast.received()) == 5 }, time.Second, 10*time.Millisecond)
+}
+
+func TestRegistryCloseAllFlushes(t *testing.T) {
+	registry := NewRegistry(DefaultQueueSize)
+	socket := &fakeSocket{block: make(chan struct{})}
+	registry.Watch(registry.Add(socket, 1), 10)
+
+	for i := 0; i < 3; i++ {
+		registry.Publish(events.Event{Type: "upgrade", AreaId: 10})
+	}
+	closed := make(chan error, 1)
+	go func() { closed <- registry.CloseAll(context.Background(), 1001, ErrShuttingDown) }()
+	close(socket.block)
+
+	// Проверяем, что при остановке сервера уже поставленные в очередь события отправляются до закрытия
+	assert.NoError(t, <-closed)
+	assert.Len(t, socket.received(), 3)
+	assert.Equal(t, uint16(1001), socket.closeCode())
+}
+
+func TestRegistryRun(t *testing.T) {
+	bus := events.NewBus()
+	registry := NewRegistry(DefaultQueueSize)
+	socket := &fakeSocket{}
+	registry.Watch(registry.Add(socket, 1), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		registry.Run(ctx, bus)
+		close(stopped)
+	}()
+
+	// Проверяем, что события из шины доходят до соединения
+	assert.Eventually(t, func() bool {
+		bus.Publish(events.Event{Type: "upgrade", AreaId: 10})
+		return len(socket.received()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-stopped
+}
+
+func (f *fakeSocket) seqs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seqs := make([]int64, 0, len(f.messages))
+	for _, m := range f.messages {
+		seqs = append(seqs, m.Seq)
+	}
+	return seqs
+}
+
+func TestRegistryResume(t *testing.T) {
+	// Окно событий - половина очереди соединения: 3 события
+	registry := NewRegistry(6)
+	live := &fakeSocket{}
+	assert.Zero(t, registry.Watch(registry.Add(live, 1), 10))
+	for i := 0; i < 5; i++ {
+		registry.Publish(events.Event{Type: "move", AreaId: 10})
+	}
+	registry.Publish(events.Event{Type: "level_up", UserId: 1})
+
+	// Проверяем, что события арены нумеруются по порядку, а события пользователя - нет
+	assert.Eventually(t, func() bool { return len(live.received()) == 6 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 0}, live.seqs())
+
+	tests := []struct {
+		name            string
+		areaId          int64
+		lastSeq         int64
+		expectedSeq     int64
+		expectedResumed bool
+		expectedMissed  []int64
+	}{
+		{"Missed events in window", 10, 3, 5, true, []int64{4, 5}},
+		{"Nothing missed", 10, 5, 5, true, []int64{}},
+		{"Gap larger than window", 10, 1, 5, false, []int64{}},
+		{"Sequence from previous server", 10, 9, 5, false, []int64{}},
+		{"Area without events", 20, 0, 0, true, []int64{}},
+		{"Area without events after restart", 20, 4, 0, false, []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socket := &fakeSocket{}
+			seq, resumed := registry.Resume(registry.Add(socket, 2), tt.areaId, tt.lastSeq)
+
+			// Проверяем, что переподключившийся клиент получает только пропущенные события,
+			// а при слишком большом разрыве - признак того, что нужен снимок арены
+			assert.Equal(t, tt.expectedSeq, seq)
+			assert.Equal(t, tt.expectedResumed, resumed)
+			assert.Eventually(t, func() bool { return len(socket.received()) == len(tt.expectedMissed) }, time.Second, 10*time.Millisecond)
+			assert.Equal(t, tt.expectedMissed, socket.seqs())
+		})
+	}
+}
+
+func TestRegistryDropsUnwatchedStreams(t *testing.T) {
+	registry := NewRegistry(6)
+	first, second := &fakeSocket{}, &fakeSocket{}
+	registry.Watch(registry.Add(first, 1), 10)
+	registry.Watch(registry.Add(second, 2), 10)
+	registry.Publish(events.Event{Type: "move", AreaId: 10})
+
+	// Проверяем, что события арены без наблюдателей не хранятся
+	registry.Publish(events.Event{Type: "move", AreaId: 20})
+	assert.NotContains(t, registry.streams, int64(20))
+
+	// Окно событий остается, пока у арены есть наблюдатели
+	registry.Remove(first)
+	assert.Contains(t, registry.streams, int64(10))
+	resumedSession := registry.Add(&fakeSocket{}, 1)
+	seq, resumed := registry.Resume(resumedSession, 10, 1)
+	assert.Equal(t, int64(1), seq)
+	assert.True(t, resumed)
+
+	// После ухода последнего наблюдателя (закрытие или переход на другую арену) окно удаляется, а клиенту нужен снимок арены
+	registry.Remove(second)
+	registry.Watch(resumedSession, 30)
+	assert.NotContains(t, registry.streams, int64(10))
+	seq, resumed = registry.Resume(registry.Add(&fakeSocket{}, 1), 10, 1)
+	assert.Zero(t, seq)
+	assert.False(t, resumed)
+}
+
+func TestSubscribeResume(t *testing.T) {
+	h := newTestHandler()
+	h.actionHandlers["get_area_data"] = &GetAreaDataHandler{store: &fakeWorld{}}
+	// События арены хранятся, пока ее наблюдает хотя бы одно соединение
+	h.registry.Watch(h.registry.Add(&fakeSocket{}, 1), 4)
+	for i := 0; i < DefaultEventWindow+2; i++ {
+		h.registry.Publish(events.Event{Type: "move", AreaId: 4})
+	}
+	lastSeq := int64(DefaultEventWindow + 2)
+
+	tests := []struct {
+		name             string
+		message          string
+		expectedMissed   int
+		expectedSnapshot bool
+	}{
+		{"First subscribe", `{"type":"subscribe","payload":{"area_id":4}}`, 0, false},
+		{"Resume", `{"type":"subscribe","payload":{"area_id":4,"last_seq":30}}`, int(lastSeq - 30), false},
+		{"Resume after long disconnect", `{"type":"subscribe","payload":{"area_id":4,"last_seq":0}}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socket := &fakeSocket{}
+			response := h.process(h.registry.Add(socket, 1), []byte(tt.message))
+
+			// Проверяем, что ответ содержит номер последнего события, а снимок - только если события потеряны
+			assert.Nil(t, response.Error)
+			var result struct {
+				Seq      int64     `json:"seq"`
+				Snapshot *AreaData `json:"snapshot"`
+			}
+			assert.NoError(t, json.Unmarshal(response.Payload, &result))
+			assert.Equal(t, lastSeq, result.Seq)
+			assert.Equal(t, tt.expectedSnapshot, result.Snapshot != nil)
+			if tt.expectedSnapshot {
+				assert.Equal(t, int64(4), result.Snapshot.Id)
+			}
+			assert.Eventually(t, func() bool { return len(socket.received()) == tt.expectedMissed }, time.Second, 10*time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxStateBodySize - максимальный размер тела запроса state в байтах.
+const MaxStateBodySize = 4 * 1024
+
+// StateRequest - тело запроса state из инициализирующего контракта.
+type StateRequest struct {
+	Data struct {
+		UserId int64 `json:"user_id"` // Идентификатор пользователя
+	} `json:"data"`
+}
+
+// StateHandler обслуживает REST endpoint state: возвращает арену пользователя со всеми объектами.
+// При первом входе пользователя арена для него создается.
+type StateHandler struct {
+	store  WorldStore
+	create func(userId int64) (int64, error) // создает арену пользователя и возвращает ее ID
+	now    func() time.Time                  // подменяется в тестах
+
+	mu sync.Mutex // арены создаются по одной, чтобы одновременные запросы не создали две арены
+}
+
+// Конструктор для StateHandler. create вызывается, если у пользователя еще нет арены.
+func NewStateHandler(store WorldStore, create func(userId int64) (int64, error)) *StateHandler {
+	return &StateHandler{store: store, create: create, now: time.Now}
+}
+
+// ServeHTTP возвращает состояние арены пользователя сессии. Обработчик должен быть
+// обернут в Authenticator.Require. user_id из запроса необязателен и, если указан,
+// должен совпадать с пользователем сессии.
+func (sh *StateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userId, ok := requestUserId(r)
+	if !ok {
+		writeError(w, ErrUnauthorized)
+		return
+	}
+	requested, err := stateUserId(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if requested != 0 && requested != userId {
+		writeError(w, fmt.Errorf("%w: state of user %v", ErrForbidden, requested))
+		return
+	}
+
+	state, created, err := sh.load(userId)
+	if err != nil {
+		log.Printf("cant load world of user ID- %v: %v\n", userId, err)
+		writeError(w, err)
+		return
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		writeError(w, fmt.Errorf("marshal to JSON error: %v", err))
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, Envelope{Version: ProtocolVersion, Type: "world_state", Payload: payload})
+}
+
+// load возвращает состояние арены пользователя и признак того, что арена была создана этим запросом.
+func (sh *StateHandler) load(userId int64) (WorldState, bool, error) {
+	if _, err := sh.store.GetUser(userId); err != nil {
+		return WorldState{}, false, fmt.Errorf("cant read user %v: %w", userId, err)
+	}
+
+	created := false
+	area, err := sh.store.GetUserArea(userId)
+	if errors.Is(err, storage.ErrNotFound) {
+		area, created, err = sh.createArea(userId)
+	}
+	if err != nil {
+		return WorldState{}, false, fmt.Errorf("cant read area of user %v: %w", userId, err)
+	}
+
+	objects, err := loadAreaObjects(sh.store, area.Id)
+	if err != nil {
+		return WorldState{}, false, err
+	}
+	return WorldState{
+		UserId:      userId,
+		AreaId:      area.Id,
+		Timestamp:   sh.now().UTC(),
+		AreaObjects: objects,
+	}, created, nil
+}
+
+// createArea создает арену пользователя, если ее не создал параллельный запрос.
+func (sh *StateHandler) createArea(userId int64) (area models.Area, created bool, err error) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	area, err = sh.store.GetUserArea(userId)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return area, false, err
+	}
+	if _, err := sh.create(userId); err != nil {
+		return area, false, fmt.Errorf("cant create world: %w", err)
+	}
+	area, err = sh.store.GetUserArea(userId)
+	return area, err == nil, err
+}
+
+// stateUserId читает user_id из параметров GET запроса или из тела POST запроса.
+// Если user_id не указан, возвращает 0. Тело больше MaxStateBodySize отклоняется с кодом 413.
+func stateUserId(w http.ResponseWriter, r *http.Request) (int64, error) {
+	switch r.Method {
+	case http.MethodGet:
+		param := r.URL.Query().Get("user_id")
+		if param == "" {
+			return 0, nil
+		}
+		userId, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid user_id", ErrBadRequest)
+		}
+		return userId, nil
+	case http.MethodPost:
+		var req StateRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxStateBodySize)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return 0, &Error{Code: http.StatusRequestEntityTooLarge, Message: http.StatusText(http.StatusRequestEntityTooLarge)}
+			}
+			return 0, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+		return req.Data.UserId, nil
+	default:
+		return 0, &Error{Code: http.StatusMethodNotAllowed, Message: http.StatusText(http.StatusMethodNotAllowed)}
+	}
+}
+
+// writeError отправляет ошибку в формате контракта ошибок с соответствующим HTTP статусом.
+func writeError(w http.ResponseWriter, err error) {
+	e := toError(err)
+	if e.Code == http.StatusMethodNotAllowed && w.Header().Get("Allow") == "" {
+		w.Header().Set("Allow", "GET, POST")
+	}
+	if e.Code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	writeJSON(w, e.Code, Envelope{Version: ProtocolVersion, Type: ErrorType, Error: e})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response Envelope) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("failed to send response: %v\n", err)
+	}
+}
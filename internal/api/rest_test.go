@@ -0,0 +1,255 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+// firstLoginWorld - хранилище, в котором у пользователя 1 арена появляется только после create.
+type firstLoginWorld struct {
+	fakeWorld
+	mu      sync.Mutex
+	created int
+	fail    bool
+}
+
+func (f *firstLoginWorld) GetUserArea(userId int64) (models.Area, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.created == 0 {
+		return models.Area{}, storage.ErrNotFound
+	}
+	return f.fakeWorld.GetUserArea(userId)
+}
+
+func (f *firstLoginWorld) create(userId int64) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return 0, errors.New("database error")
+	}
+	f.created++
+	return 4, nil
+}
+
+func (f *firstLoginWorld) createdCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.created
+}
+
+func newStateServer(world *firstLoginWorld) *WebSocketServer {
+	authenticator := newTestAuthenticator()
+	wsServer := NewWebsocketServer(NewWebSocketHandler(nil, nil, nil, nil, nil, nil), authenticator)
+	state := NewStateHandler(world, world.create)
+	state.now = func() time.Time { return time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) }
+	wsServer.Handle("/state", authenticator.Require(state))
+	return wsServer
+}
+
+// newStateRequest создает запрос state от имени пользователя userId. При userId 0 запрос без токена.
+func newStateRequest(userId int64, method, target, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if userId != 0 {
+		r.Header.Set("Authorization", "Bearer "+testToken(userId))
+	}
+	return r
+}
+
+func TestStateHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		world         *firstLoginWorld
+		userId        int64 // пользователь сессии, 0 - запрос без токена
+		method        string
+		target        string
+		body          string
+		expectedCode  int
+		expectedType  string
+		expectedError int
+		created       int
+	}{
+		{
+			name:         "Existing world",
+			world:        &firstLoginWorld{created: 1},
+			userId:       1,
+			method:       http.MethodGet,
+			target:       "/state",
+			expectedCode: http.StatusOK,
+			expectedType: "world_state",
+			created:      1,
+		},
+		{
+			name:         "Contract body",
+			world:        &firstLoginWorld{created: 1},
+			userId:       1,
+			method:       http.MethodPost,
+			target:       "/state",
+			body:         `{"data":{"user_id":1}}`,
+			expectedCode: http.StatusOK,
+			expectedType: "world_state",
+			created:      1,
+		},
+		{
+			name:         "First login creates world",
+			world:        &firstLoginWorld{},
+			userId:       1,
+			method:       http.MethodGet,
+			target:       "/state?user_id=1",
+			expectedCode: http.StatusCreated,
+			expectedType: "world_state",
+			created:      1,
+		},
+		{
+			name:          "No token",
+			world:         &firstLoginWorld{created: 1},
+			method:        http.MethodGet,
+			target:        "/state?user_id=1",
+			expectedCode:  http.StatusUnauthorized,
+			expectedType:  ErrorType,
+			expectedError: http.StatusUnauthorized,
+			created:       1,
+		},
+		{
+			name:          "State of other user",
+			world:         &firstLoginWorld{created: 1},
+			userId:        1,
+			method:        http.MethodPost,
+			target:        "/state",
+			body:          `{"data":{"user_id":2}}`,
+			expectedCode:  http.StatusForbidden,
+			expectedType:  ErrorType,
+			expectedError: http.StatusForbidden,
+			created:       1,
+		},
+		{
+			name:          "Unknown user",
+			world:         &firstLoginWorld{},
+			userId:        9,
+			method:        http.MethodGet,
+			target:        "/state",
+			expectedCode:  http.StatusNotFound,
+			expectedType:  ErrorType,
+			expectedError: http.StatusNotFound,
+		},
+		{
+			name:          "Invalid user id",
+			world:         &firstLoginWorld{},
+			userId:        1,
+			method:        http.MethodGet,
+			target:        "/state?user_id=abc",
+			expectedCode:  http.StatusBadRequest,
+			expectedType:  ErrorType,
+			expectedError: http.StatusBadRequest,
+		},
+		{
+			name:          "Invalid body",
+			world:         &firstLoginWorld{},
+			userId:        1,
+			method:        http.MethodPost,
+			target:        "/state",
+			body:          `{"data":`,
+			expectedCode:  http.StatusBadRequest,
+			expectedType:  ErrorType,
+			expectedError: http.StatusBadRequest,
+		},
+		{
+			name:          "Body too large",
+			world:         &firstLoginWorld{},
+			userId:        1,
+			method:        http.MethodPost,
+			target:        "/state",
+			body:          `{"data":{"user_id":1},"padding":"` + strings.Repeat("a", MaxStateBodySize) + `"}`,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+			expectedType:  ErrorType,
+			expectedError: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "Method not allowed",
+			world:         &firstLoginWorld{},
+			userId:        1,
+			method:        http.MethodDelete,
+			target:        "/state",
+			expectedCode:  http.StatusMethodNotAllowed,
+			expectedType:  ErrorType,
+			expectedError: http.StatusMethodNotAllowed,
+		},
+		{
+			name:          "World creation failed",
+			world:         &firstLoginWorld{fail: true},
+			userId:        1,
+			method:        http.MethodGet,
+			target:        "/state",
+			expectedCode:  http.StatusInternalServerError,
+			expectedType:  ErrorType,
+			expectedError: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newStateServer(tt.world).ServeHTTP(rec, newStateRequest(tt.userId, tt.method, tt.target, tt.body))
+
+			// Проверяем HTTP статус и формат ответа
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var response Envelope
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedType, response.Type)
+			if tt.expectedError != 0 {
+				assert.Equal(t, tt.expectedError, response.Error.Code)
+			} else {
+				var state WorldState
+				assert.NoError(t, json.Unmarshal(response.Payload, &state))
+				assert.Equal(t, int64(4), state.AreaId)
+				assert.Len(t, state.Neutrals, 1)
+			}
+
+			// Проверяем, что арена создается только при первом входе
+			assert.Equal(t, tt.created, tt.world.createdCount())
+		})
+	}
+}
+
+func TestStateHandlerConcurrentFirstLogin(t *testing.T) {
+	world := &firstLoginWorld{}
+	wsServer := newStateServer(world)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			wsServer.ServeHTTP(rec, newStateRequest(1, http.MethodGet, "/state", ""))
+			codes <- rec.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	// Проверяем, что одновременные запросы создают одну арену, а ровно один из них получает 201
+	created := 0
+	for code := range codes {
+		if code == http.StatusCreated {
+			created++
+		} else {
+			assert.Equal(t, http.StatusOK, code)
+		}
+	}
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, world.createdCount())
+}
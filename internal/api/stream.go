@@ -0,0 +1,34 @@
+package server
+
+// HARDCODE количество последних событий арены, хранимых для переподключившихся клиентов.
+// Не больше половины очереди соединения, чтобы пропущенные события поместились в нее вместе с новыми.
+const DefaultEventWindow = 32
+
+// areaStream - последние события арены с порядковыми номерами.
+// Номера начинаются с 1 и растут на 1 с каждым событием арены.
+type areaStream struct {
+	seq    int64      // номер последнего события
+	events []Envelope // последние события, events[len(events)-1] имеет номер seq
+	window int        // сколько последних событий хранится
+}
+
+// append сохраняет событие с номером seq+1 и вытесняет самое старое, если окно заполнено.
+func (st *areaStream) append(env Envelope) {
+	st.seq++
+	if len(st.events) < st.window {
+		st.events = append(st.events, env)
+		return
+	}
+	copy(st.events, st.events[1:])
+	st.events[len(st.events)-1] = env
+}
+
+// since возвращает события с номерами больше lastSeq. ok равен false, если часть
+// этих событий уже вытеснена из окна или lastSeq больше номера последнего события.
+func (st *areaStream) since(lastSeq int64) (missed []Envelope, ok bool) {
+	count := st.seq - lastSeq
+	if count < 0 || count > int64(len(st.events)) {
+		return nil, false
+	}
+	return append([]Envelope(nil), st.events[int64(len(st.events))-count:]...), true
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"cyber/internal/game"
+	"cyber/internal/models"
+	"cyber/internal/validation"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ResourceCatalog - справочник ресурсов. Реализуется *postgress.Storage.
+type ResourceCatalog interface {
+	GetResourceNames() ([]string, error)
+}
+
+// Catalog - допустимые названия для правил oneof в характеристиках действий:
+// ресурсы из справочника resources, здания из каталога зданий и типы атакующих объектов.
+// Справочник ресурсов читается при первой проверке и больше не меняется.
+type Catalog struct {
+	resources ResourceCatalog
+	buildings []string
+
+	mu    sync.Mutex
+	names map[string][]string
+}
+
+// Конструктор для Catalog. resources nil - справочник ресурсов пуст.
+func NewCatalog(resources ResourceCatalog, buildings game.BuildingCatalog) *Catalog {
+	return &Catalog{resources: resources, buildings: buildings.Names()}
+}
+
+// Names возвращает наборы названий по именам правил oneof. Если справочник ресурсов
+// прочитать не удалось, он будет прочитан при следующем вызове.
+func (c *Catalog) Names() (map[string][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.names != nil {
+		return c.names, nil
+	}
+	var resources []string
+	if c.resources != nil {
+		var err error
+		if resources, err = c.resources.GetResourceNames(); err != nil {
+			return nil, fmt.Errorf("cant read resource names: %w", err)
+		}
+	}
+	c.names = map[string][]string{
+		"resource": resources,
+		"object":   c.buildings,
+		"attacker": {string(models.HeroObject), string(models.UnitObject)},
+	}
+	return c.names, nil
+}
+
+// characteristicsTypes - типы характеристик действий. Характеристики проверяются
+// по тегам validate до передачи действия обработчику.
+var characteristicsTypes = map[string]func() interface{}{
+	"move":       func() interface{} { return new(models.MoveActionCharacteristics) },
+	"harvest":    func() interface{} { return new(models.HarvestActionCharacteristics) },
+	"build":      func() interface{} { return new(models.BuildActionCharacteristics) },
+	"attack":     func() interface{} { return new(models.AttackActionCharacteristics) },
+	"upgrade":    func() interface{} { return new(models.UpgradeActionCharacteristics) },
+	"group_move": func() interface{} { return new(models.GroupMoveActionCharacteristics) },
+
+	"get_resource_history": func() interface{} { return new(models.ResourceHistoryCharacteristics) },
+}
+
+// validateCharacteristics проверяет характеристики действия. Координаты сравниваются с размером арены area,
+// названия ресурсов и зданий - с каталогом catalog.
+// Ошибки возвращаются списком по полям в формате validation.Errors.
+func validateCharacteristics(action *models.Action, area models.Area, catalog *Catalog) error {
+	newCharacteristics, ok := characteristicsTypes[action.ActionType]
+	if !ok {
+		return nil
+	}
+	characteristics := newCharacteristics()
+	if len(action.Characteristics) > 0 {
+		if err := json.Unmarshal(action.Characteristics, characteristics); err != nil {
+			return fmt.Errorf("%w: unmarshal charachteristics error: %v", ErrBadRequest, err)
+		}
+	}
+	names, err := catalog.Names()
+	if err != nil {
+		return err
+	}
+	return validation.Struct(characteristics, validation.Context{Width: area.Width, Height: area.Height, Names: names})
+}
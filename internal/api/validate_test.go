@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/game"
+)
+
+func TestValidateCharacteristics(t *testing.T) {
+	h := newTestHandler()
+
+	// Арена 4 пользователя 1 имеет размер 100x80
+	tests := []struct {
+		name          string
+		message       string
+		expectedError string
+	}{
+		{
+			name:          "Move outside area",
+			message:       `{"type":"move","request_id":"v1","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":0,"r":0},"to":{"q":100,"r":-1},"speed":-3}}}`,
+			expectedError: `{"code":400,"message":"invalid characteristics","fields":[{"field":"to","message":"must be within area 100x80"},{"field":"speed","message":"must be positive"}]}`,
+		},
+		{
+			name:          "Build unknown object",
+			message:       `{"type":"build","request_id":"v2","payload":{"area_id":4,"object_source_id":5,"characteristics":{"object":"Castle","construction_time":0,"place":{"q":1,"r":1}}}}`,
+			expectedError: `{"code":400,"message":"invalid characteristics","fields":[{"field":"object","message":"unknown object \"Castle\""},{"field":"construction_time","message":"must be positive"}]}`,
+		},
+		{
+			name:          "Harvest unknown resource",
+			message:       `{"type":"harvest","request_id":"v3","payload":{"area_id":4,"object_source_id":5,"object_dest_id":1,"characteristics":{"resource":"Mithril"}}}`,
+			expectedError: `{"code":400,"message":"invalid characteristics","fields":[{"field":"resource","message":"unknown resource \"Mithril\""}]}`,
+		},
+		{
+			name:          "Move between cells",
+			message:       `{"type":"move","request_id":"v5","payload":{"area_id":4,"object_source_id":3,"characteristics":{"from":{"q":0,"r":0},"to":{"q":2.5,"r":1}}}}`,
+			expectedError: `{"code":400,"message":"invalid characteristics","fields":[{"field":"to","message":"must be a cell with integer coordinates"}]}`,
+		},
+		{
+			name:          "Attack with negative damage",
+			message:       `{"type":"attack","request_id":"v4","payload":{"area_id":4,"object_source_id":3,"object_dest_id":6,"characteristics":{"atacker_kind":"hero","damage":-10}}}`,
+			expectedError: `{"code":400,"message":"invalid characteristics","fields":[{"field":"damage","message":"must be positive"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := h.process(&Session{userId: 1}, []byte(tt.message))
+
+			// Проверяем, что ошибки возвращаются по полям в формате контракта ошибок
+			assert.Equal(t, ErrorType, response.Type)
+			data, err := json.Marshal(response.Error)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expectedError, string(data))
+		})
+	}
+}
+
+// flakyResources - справочник ресурсов, первое чтение которого завершается ошибкой
+type flakyResources struct {
+	reads int
+}
+
+func (f *flakyResources) GetResourceNames() ([]string, error) {
+	f.reads++
+	if f.reads == 1 {
+		return nil, errors.New("connection refused")
+	}
+	return []string{"Gold"}, nil
+}
+
+func TestCatalogNames(t *testing.T) {
+	resources := &flakyResources{}
+	catalog := NewCatalog(resources, game.BuildingCatalog{"Farm": {}, "Barracks": {}})
+
+	// Проверяем, что ошибка чтения справочника не запоминается
+	_, err := catalog.Names()
+	assert.Error(t, err)
+
+	names, err := catalog.Names()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"resource": {"Gold"}, "object": {"Barracks", "Farm"}, "attacker": {"hero", "unit"}}, names)
+
+	// Прочитанный справочник больше не запрашивается
+	_, err = catalog.Names()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resources.reads)
+}
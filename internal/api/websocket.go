@@ -1,29 +1,106 @@
 package server
 
 import (
-	//"encoding/json"
-	//"fmt"
+	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
-
-	//"cyber/internal/models"
+	"time"
 
 	"github.com/lxzan/gws"
 )
 
+// HARDCODE: таймауты чтения HTTP запросов. ReadTimeout ограничивает чтение REST запросов
+// целиком (например, тела /state), для websocket gws снимает его после установки соединения.
+const (
+	ReadHeaderTimeout = 5 * time.Second
+	ReadTimeout       = 10 * time.Second
+)
+
 type WebSocketServer struct {
-	server *gws.Server
+	handler  *WebSocketHandler
+	auth     *Authenticator
+	upgrader *gws.Upgrader // для клиентов, запросивших подпротокол
+	plain    *gws.Upgrader // для клиентов без подпротокола, они получают JSON
+	mux      *http.ServeMux
+	http     *http.Server
+}
+
+// Конструктор для WebSocketServer. Запросы, для которых не зарегистрирован
+// REST обработчик, переводятся в websocket. Соединение открывается только
+// для запросов с действующим токеном сессии. Размер сообщений ограничивается
+// ограничителем handler. Формат сообщений выбирается подпротоколом (Subprotocols).
+func NewWebsocketServer(handler *WebSocketHandler, authenticator *Authenticator) *WebSocketServer {
+	maxSize := handler.limiter.limits.MaxMessageSize
+	s := &WebSocketServer{
+		handler:  handler,
+		auth:     authenticator,
+		upgrader: gws.NewUpgrader(handler, &gws.ServerOption{ReadMaxPayloadSize: maxSize, SubProtocols: Subprotocols}),
+		plain:    gws.NewUpgrader(handler, &gws.ServerOption{ReadMaxPayloadSize: maxSize}),
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/", s.serveWebSocket)
+	s.http = &http.Server{Handler: s.mux, ReadHeaderTimeout: ReadHeaderTimeout, ReadTimeout: ReadTimeout}
+	return s
+}
+
+// Handle регистрирует REST обработчик рядом с websocket. Вызывается до запуска сервера.
+func (s *WebSocketServer) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// ServeHTTP передает запрос REST обработчику или переводит HTTP соединение в websocket.
+func (s *WebSocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serveWebSocket проверяет токен сессии и переводит HTTP соединение в websocket.
+// Пользователь сессии сохраняется в соединении до начала чтения сообщений.
+func (s *WebSocketServer) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	userId, err := s.auth.UserId(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// gws отклоняет клиентов без подпротокола, если подпротоколы заданы
+	upgrader := s.plain
+	if r.Header.Get("Sec-WebSocket-Protocol") != "" {
+		upgrader = s.upgrader
+	}
+	socket, err := upgrader.Upgrade(w, r)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v\n", err)
+		return
+	}
+	socket.Session().Store(sessionUserKey, userId)
+	go socket.ReadLoop()
+}
+
+// Start запускает websocket сервер на адресе addr и блокируется до остановки сервера.
+func (s *WebSocketServer) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
 }
 
-func NewWebsocketServer() *WebSocketServer {
-	return &WebSocketServer{
-		server: gws.NewServer(NewWebSocketHandler(), nil),
+// Serve принимает соединения на ln и блокируется до остановки сервера.
+func (s *WebSocketServer) Serve(ln net.Listener) error {
+	log.Printf("WebSocket server starting at: %s...\n", ln.Addr())
+	if err := s.http.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
 	}
+	return nil
 }
 
-func (s *WebSocketServer) Start(addr string) {
-	log.Printf("WebSocket server starting at: %s...\n", addr)
-	if err := http.ListenAndServe(addr, s.Server); err != nil {
-		log.Fatalf("WebSocket server failed: %v", err)
+// Shutdown перестает принимать новые соединения, дожидается обработки
+// уже полученных сообщений и закрывает открытые websocket соединения.
+func (s *WebSocketServer) Shutdown(ctx context.Context) error {
+	err := s.http.Shutdown(ctx)
+	if drainErr := s.handler.Drain(ctx); drainErr != nil && err == nil {
+		err = drainErr
 	}
+	return err
 }
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lxzan/gws"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/events"
+	"cyber/internal/game"
+	"cyber/internal/models"
+)
+
+// slowActionHandler блокируется, пока тест не разрешит завершить действие
+type slowActionHandler struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished chan struct{}
+}
+
+func (sh *slowActionHandler) Handle(action *models.Action) (interface{}, error) {
+	close(sh.started)
+	<-sh.release
+	close(sh.finished)
+	return map[string]string{"status": "done"}, nil
+}
+
+// clientHandler запоминает закрытие соединения сервером
+type clientHandler struct {
+	gws.BuiltinEventHandler
+	closed chan struct{}
+}
+
+func (c *clientHandler) OnClose(socket *gws.Conn, err error) {
+	close(c.closed)
+}
+
+func TestWebSocketServerGracefulShutdown(t *testing.T) {
+	slow := &slowActionHandler{started: make(chan struct{}), release: make(chan struct{}), finished: make(chan struct{})}
+	handler := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	handler.actionHandlers["slow"] = slow
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- wsServer.Serve(ln) }()
+
+	client := &clientHandler{closed: make(chan struct{})}
+	socket, _, err := gws.NewClient(client, newTestClientOption(ln.Addr().String(), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+
+	assert.NoError(t, socket.WriteString(`{"type":"slow","request_id":"r1"}`))
+	<-slow.started
+
+	shutdownDone := make(chan error, 1)
+	go func() { shutdownDone <- wsServer.Shutdown(context.Background()) }()
+
+	// Проверяем, что остановка ждет окончания начатого действия
+	select {
+	case <-shutdownDone:
+		t.Fatal("shutdown finished before in-flight action")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(slow.release)
+	select {
+	case err := <-shutdownDone:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not finish")
+	}
+	<-slow.finished
+	assert.NoError(t, <-served)
+
+	select {
+	case <-client.closed:
+	case <-time.After(time.Second):
+		t.Fatal("client connection was not closed")
+	}
+}
+
+func TestWebSocketServerReadTimeouts(t *testing.T) {
+	wsServer := NewWebsocketServer(NewWebSocketHandler(nil, nil, nil, nil, nil, nil), newTestAuthenticator())
+
+	// Проверяем, что медленный клиент не держит соединение бесконечно
+	assert.Equal(t, ReadHeaderTimeout, wsServer.http.ReadHeaderTimeout)
+	assert.Equal(t, ReadTimeout, wsServer.http.ReadTimeout)
+}
+
+func TestWebSocketServerShutdownTimeout(t *testing.T) {
+	slow := &slowActionHandler{started: make(chan struct{}), release: make(chan struct{}), finished: make(chan struct{})}
+	defer close(slow.release)
+	handler := NewWebSocketHandler(nil, nil, nil, nil, nil, nil)
+	handler.actionHandlers["slow"] = slow
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go wsServer.Serve(ln)
+
+	socket, _, err := gws.NewClient(&clientHandler{closed: make(chan struct{})}, newTestClientOption(ln.Addr().String(), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+	assert.NoError(t, socket.WriteString(`{"type":"slow","request_id":"r1"}`))
+	<-slow.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, wsServer.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+// fakeActions хранит действия и их статусы в памяти
+type fakeActions struct {
+	mu       sync.Mutex
+	added    []models.Action
+	statuses map[int64]string
+}
+
+func (f *fakeActions) AddAction(a models.Action) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a.Id = int64(len(f.added) + 1)
+	f.added = append(f.added, a)
+	return a.Id, nil
+}
+
+func (f *fakeActions) UpdateActionStatus(actionId int64, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[actionId] = status
+	return nil
+}
+
+func TestWebSocketHarvestAndBuild(t *testing.T) {
+	handler := newTestHandler()
+	store := &fakeActions{statuses: make(map[int64]string)}
+	done := make(chan models.Action, 2)
+	scheduler := game.NewScheduler(store, func(e game.ActionEvent) {
+		handler.registry.Publish(events.Event{Type: e.Type, UserId: e.UserId, AreaId: e.AreaId, ActionId: e.ActionId, Payload: e})
+	}, func(a models.Action) { done <- a })
+	defer scheduler.Shutdown(context.Background())
+	handler.actionHandlers["harvest"] = &HarvestActionHandler{actions: scheduler, now: time.Now}
+	handler.actionHandlers["build"] = &BuildActionHandler{actions: scheduler, now: time.Now}
+
+	wsServer := NewWebsocketServer(handler, newTestAuthenticator())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go wsServer.Serve(ln)
+	defer wsServer.Shutdown(context.Background())
+
+	client := &messageHandler{messages: make(chan Envelope, 16)}
+	socket, _, err := gws.NewClient(client, newTestClientOption(ln.Addr().String(), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+
+	// response ждет ответ на запрос requestId, пропуская события
+	response := func(requestId string) Envelope {
+		for {
+			select {
+			case env := <-client.messages:
+				if env.RequestId == requestId {
+					return env
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("no response to %v", requestId)
+			}
+		}
+	}
+
+	// Проверяем, что добыча запускается планировщиком от имени пользователя сессии
+	assert.NoError(t, socket.WriteString(`{"type":"harvest","request_id":"h1","payload":{"area_id":4,"object_source_id":5,"characteristics":{"neutral_id":1}}}`))
+	env := response("h1")
+	assert.Nil(t, env.Error)
+	assert.JSONEq(t, `{"status":"success","message":"Resource collection can be started","action_id":1}`, string(env.Payload))
+
+	// Проверяем, что строительство завершается через construction_time и передается
+	// обработчику завершенных действий, который начисляет опыт
+	assert.NoError(t, socket.WriteString(`{"type":"build","request_id":"b1","payload":{"area_id":4,"characteristics":{"builder":5,"object":"CyMan miner house","construction_time":1,"place":{"q":10,"r":10}}}}`))
+	env = response("b1")
+	assert.Nil(t, env.Error)
+	assert.JSONEq(t, `{"status":"success","message":"Construction can begin","action_id":2}`, string(env.Payload))
+	select {
+	case a := <-done:
+		assert.Equal(t, int64(2), a.Id)
+		assert.Equal(t, "build", a.ActionType)
+		assert.Equal(t, int64(5), a.ObjectSourceId)
+		assert.Equal(t, time.Second, a.Duration)
+	case <-time.After(3 * time.Second):
+		t.Fatal("construction was not finished")
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if assert.Len(t, store.added, 2) {
+		harvest := store.added[0]
+		assert.Equal(t, models.Action{
+			Id:              1,
+			UserId:          1,
+			AreaId:          4,
+			ObjectSourceId:  5,
+			ObjectDestId:    1,
+			ActionType:      "harvest",
+			Characteristics: harvest.Characteristics,
+			StartTime:       harvest.StartTime,
+			Duration:        HarvestDuration,
+			Status:          models.ActionInProgress,
+		}, harvest)
+	}
+	assert.Equal(t, models.ActionDone, store.statuses[2])
+}
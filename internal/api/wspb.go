@@ -0,0 +1,248 @@
+package server
+
+import (
+	"cyber/internal/models"
+	"cyber/pkg/wspb"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Сборка сообщений wspb из данных ответов. Поля сообщений совпадают с полями JSON контрактов,
+// дробные значения передаются строками, как в JSON.
+
+func protoActionResult(r ActionResult) *wspb.ActionResult {
+	msg := &wspb.ActionResult{Status: r.Status, Message: r.Message, ActionId: r.ActionId}
+	for _, p := range r.Paths {
+		msg.Paths = append(msg.Paths, &wspb.UnitPath{UnitId: p.UnitId, Path: protoHexes(p.Path)})
+	}
+	return msg
+}
+
+func protoSubscribeResult(r SubscribeResult) *wspb.SubscribeResult {
+	msg := &wspb.SubscribeResult{Status: r.Status, Seq: r.Seq}
+	if r.Snapshot != nil {
+		msg.Snapshot = protoAreaData(*r.Snapshot)
+	}
+	return msg
+}
+
+func protoAreaData(a AreaData) *wspb.AreaData {
+	return &wspb.AreaData{
+		Id:         a.Id,
+		UserId:     a.UserId,
+		Width:      int32(a.Width),
+		Height:     int32(a.Height),
+		CellTypeId: int32(a.CellTypeId),
+		Neutrals:   protoList(a.Neutrals, protoNeutral),
+		Buildings:  protoList(a.Buildings, protoBuilding),
+		Heroes:     protoList(a.Heroes, protoHero),
+		Units:      protoList(a.Units, protoUnit),
+		Enemies:    protoList(a.Enemies, protoEnemy),
+	}
+}
+
+func protoWorldState(w WorldState) *wspb.WorldState {
+	return &wspb.WorldState{
+		UserId:    w.UserId,
+		AreaId:    w.AreaId,
+		Timestamp: timestamppb.New(w.Timestamp),
+		Neutrals:  protoList(w.Neutrals, protoNeutral),
+		Buildings: protoList(w.Buildings, protoBuilding),
+		Heroes:    protoList(w.Heroes, protoHero),
+		Units:     protoList(w.Units, protoUnit),
+		Enemies:   protoList(w.Enemies, protoEnemy),
+	}
+}
+
+func protoUserData(u UserData) *wspb.UserData {
+	msg := &wspb.UserData{
+		Id:           u.Id,
+		Login:        u.Login,
+		Resources:    protoResources(u.Resources),
+		Subscription: u.Subscription,
+		LeagueId:     u.LeagueId,
+		Balance:      u.Balance.String(),
+		Level:        int32(u.Level),
+	}
+	if u.League != nil {
+		msg.League = &wspb.League{Id: u.League.Id, Name: u.League.Name, Authority: int32(u.League.Authority)}
+	}
+	return msg
+}
+
+// protoWorldDelta собирает изменения состояния мира. Объект целиком передается в google.protobuf.Struct.
+func protoWorldDelta(d WorldDelta) (*wspb.WorldDelta, error) {
+	msg := &wspb.WorldDelta{Version: d.Version, Base: d.Base, Timestamp: timestamppb.New(d.Timestamp)}
+	for _, c := range d.Changed {
+		delta := &wspb.ObjectDelta{Kind: string(c.Kind), Id: c.Id, Coordinates: protoHexes(c.Coordinates)}
+		if c.HP != nil {
+			hp := int32(*c.HP)
+			delta.Hp = &hp
+		}
+		if c.Level != nil {
+			level := int32(*c.Level)
+			delta.Level = &level
+		}
+		if len(c.Object) > 0 {
+			var object map[string]interface{}
+			if err := json.Unmarshal(c.Object, &object); err != nil {
+				return nil, fmt.Errorf("object %v %v: %v", c.Kind, c.Id, err)
+			}
+			s, err := structpb.NewStruct(object)
+			if err != nil {
+				return nil, fmt.Errorf("object %v %v: %v", c.Kind, c.Id, err)
+			}
+			delta.Object = s
+		}
+		msg.Changed = append(msg.Changed, delta)
+	}
+	for _, r := range d.Removed {
+		msg.Removed = append(msg.Removed, &wspb.ObjectRef{Kind: string(r.Kind), Id: r.Id})
+	}
+	if d.Snapshot != nil {
+		msg.Snapshot = protoWorldState(*d.Snapshot)
+	}
+	return msg, nil
+}
+
+func protoNeutral(n models.Neutral) *wspb.Neutral {
+	return &wspb.Neutral{
+		Id:                      n.Id,
+		Name:                    n.Name,
+		Product:                 n.Product,
+		ProductivityCoefficient: int32(n.ProductivityCoefficient),
+		Capacity:                n.Capacity.String(),
+		ThresholdLevel1:         n.ThresholdLevel1.String(),
+		ThresholdLevel2:         n.ThresholdLevel2.String(),
+		Size:                    int32(n.Size),
+		Coordinates:             protoHexes(n.Coordinates),
+	}
+}
+
+func protoBuilding(b models.Building) *wspb.Building {
+	return &wspb.Building{
+		Id:      b.Id,
+		Name:    b.Name,
+		Product: b.Product,
+		Characteristics: &wspb.BuildingCharacteristics{
+			Hp:      int32(b.Charachteristics.HP),
+			Armor:   int32(b.Charachteristics.Armor),
+			ProdCof: int32(b.Charachteristics.ProductivityCoefficient),
+			Size:    int32(b.Charachteristics.Size),
+		},
+		Level:        int32(b.Level),
+		UpgradePrice: protoResources(b.UpgradePrice),
+		Coordinates:  protoHexes(b.Coordinates),
+	}
+}
+
+func protoHero(h models.Hero) *wspb.Hero {
+	c := h.Charachteristics
+	return &wspb.Hero{
+		Id:   h.Id,
+		Name: h.Name,
+		Characteristics: &wspb.HeroCharacteristics{
+			Hp:         int32(c.HP),
+			HpNow:      int32(c.HPnow),
+			Armor:      int32(c.Armor),
+			Speed:      c.Speed.String(),
+			Vision:     int32(c.Vision),
+			Range:      c.IsRange,
+			AtackRange: c.AtackRange.String(),
+			Damage:     c.Damage.String(),
+		},
+		Experience:     h.Experience.String(),
+		ExperienceToUp: h.ExperienceToUp.String(),
+		Level:          int32(h.Level),
+		Abilities:      protoList(h.Abilities, protoAbility),
+		Coordinates:    protoHexes(h.Coordinates),
+	}
+}
+
+func protoAbility(a models.Ability) *wspb.Ability {
+	c := a.Charachteristics
+	return &wspb.Ability{
+		Id:   a.Id,
+		Name: a.Name,
+		Characteristics: &wspb.AbilityCharacteristics{
+			IsPassive:      c.IsPassive,
+			Radius:         c.Radius.String(),
+			Cooldown:       int64(c.Cooldown),
+			Damage:         c.Damage.String(),
+			ProjectilSpeed: c.ProjectilSpeed.String(),
+		},
+		Level:   int32(a.Level),
+		ImageId: a.ImageId,
+	}
+}
+
+func protoUnit(u models.Unit) *wspb.Unit {
+	c := u.Charachteristics
+	return &wspb.Unit{
+		Id:   u.Id,
+		Name: u.Name,
+		Characteristics: &wspb.UnitCharacteristics{
+			Hp:         int32(c.HP),
+			HpNow:      int32(c.HPnow),
+			Armor:      int32(c.Armor),
+			Speed:      c.Speed.String(),
+			Vision:     int32(c.Vision),
+			Range:      c.IsRange,
+			AtackRange: c.AtackRange.String(),
+			Damage:     c.Damage.String(),
+			ProdCof:    int32(c.ProductivityCoefficient),
+		},
+		Experience:     u.Experience.String(),
+		ExperienceToUp: u.ExperienceToUp.String(),
+		Level:          int32(u.Level),
+		ImageId:        u.ImageId,
+		Coordinates:    protoHexes(u.Coordinates),
+	}
+}
+
+func protoEnemy(e models.Enemy) *wspb.Enemy {
+	c := e.Charachteristics
+	return &wspb.Enemy{
+		Id:   e.Id,
+		Name: e.Name,
+		Characteristics: &wspb.EnemyCharacteristics{
+			Hp:         int32(c.HP),
+			Armor:      int32(c.Armor),
+			Speed:      c.Speed.String(),
+			Vision:     int32(c.Vision),
+			Range:      c.IsRange,
+			AtackRange: c.AtackRange.String(),
+			Damage:     c.Damage.String(),
+			Experience: c.Experience.String(),
+			Level:      int32(c.Level),
+		},
+		Level:       int32(e.Level),
+		Coordinates: protoHexes(e.Coordinates),
+	}
+}
+
+func protoResources(resources []models.Resource) []*wspb.Resource {
+	return protoList(resources, func(r models.Resource) *wspb.Resource {
+		return &wspb.Resource{Id: int32(r.Id), Name: r.Name, Value: r.Value.String()}
+	})
+}
+
+func protoHexes(hexes []models.Hex) []*wspb.Hex {
+	return protoList(hexes, func(h models.Hex) *wspb.Hex {
+		return &wspb.Hex{Q: h.Q, R: h.R}
+	})
+}
+
+func protoList[T any, M any](items []T, convert func(T) *M) []*M {
+	if len(items) == 0 {
+		return nil
+	}
+	list := make([]*M, len(items))
+	for i, item := range items {
+		list[i] = convert(item)
+	}
+	return list
+}
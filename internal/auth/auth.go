@@ -0,0 +1,131 @@
+/*
+Пакет auth отвечает за аутентификацию пользователей.
+Пароли хранятся в БД только в виде bcrypt хешей. После регистрации или входа
+пользователь получает подписанный токен сессии, который передается серверу
+при открытии websocket соединения и в REST запросах. Идентификатор пользователя
+сервер берет из токена, а не из данных запроса.
+*/
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/crypto/bcrypt"
+
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+// HARDCODE параметры новых пользователей и требования к паролю
+const (
+	DefaultLeagueId   = 1 // Новые пользователи начинают в первой лиге
+	MinPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt учитывает только первые 72 байта пароля
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrWeakPassword       = fmt.Errorf("password must be from %d to %d bytes long", MinPasswordLength, maxPasswordLength)
+	ErrEmptyLogin         = errors.New("login required")
+)
+
+// dummyHash сравнивается с паролем, если пользователь не найден, чтобы время ответа
+// не выдавало, существует ли логин.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// HashPassword возвращает bcrypt хеш пароля.
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength || len(password) > maxPasswordLength {
+		return "", ErrWeakPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("cant hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// CheckPassword сравнивает пароль с хешем. При несовпадении возвращает ErrInvalidCredentials.
+func CheckPassword(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// UserStore - хранилище пользователей. Реализуется *postgress.Storage.
+type UserStore interface {
+	AddUser(u models.User) error
+	GetUserByLogin(login string) (models.User, error)
+}
+
+// Session - сессия пользователя, выдаваемая при регистрации и входе.
+type Session struct {
+	UserId    int64     `json:"user_id"`    // Идентификатор пользователя
+	Token     string    `json:"token"`      // Токен сессии
+	ExpiresAt time.Time `json:"expires_at"` // Время окончания действия токена
+}
+
+// Service регистрирует пользователей и выдает им сессии.
+type Service struct {
+	users  UserStore
+	tokens *Tokens
+}
+
+// Конструктор для Service.
+func NewService(users UserStore, tokens *Tokens) *Service {
+	return &Service{users: users, tokens: tokens}
+}
+
+// Register создает пользователя и открывает для него сессию.
+// Если логин или email уже заняты, возвращается storage.ErrAlreadyExists.
+func (s *Service) Register(login, email, password string) (Session, error) {
+	if login == "" {
+		return Session{}, ErrEmptyLogin
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return Session{}, err
+	}
+	err = s.users.AddUser(models.User{
+		Login:    login,
+		Password: hash,
+		Email:    email,
+		LeagueId: DefaultLeagueId,
+		Balance:  decimal.Zero,
+		Level:    1,
+	})
+	if err != nil {
+		return Session{}, fmt.Errorf("cant add user %v: %w", login, err)
+	}
+	user, err := s.users.GetUserByLogin(login)
+	if err != nil {
+		return Session{}, fmt.Errorf("cant read user %v: %w", login, err)
+	}
+	return s.open(user.Id), nil
+}
+
+// Login проверяет логин и пароль и открывает сессию пользователя.
+func (s *Service) Login(login, password string) (Session, error) {
+	user, err := s.users.GetUserByLogin(login)
+	if errors.Is(err, storage.ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return Session{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Session{}, fmt.Errorf("cant read user %v: %w", login, err)
+	}
+	if err := CheckPassword(user.Password, password); err != nil {
+		return Session{}, err
+	}
+	return s.open(user.Id), nil
+}
+
+func (s *Service) open(userId int64) Session {
+	token, expiresAt := s.tokens.Issue(userId)
+	return Session{UserId: userId, Token: token, ExpiresAt: expiresAt}
+}
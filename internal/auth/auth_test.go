@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+// memoryUsers - хранилище пользователей в памяти.
+type memoryUsers struct {
+	mu    sync.Mutex
+	users []models.User
+}
+
+func (m *memoryUsers) AddUser(u models.User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.users {
+		if existing.Login == u.Login || existing.Email == u.Email {
+			return storage.ErrAlreadyExists
+		}
+	}
+	u.Id = int64(len(m.users) + 1)
+	m.users = append(m.users, u)
+	return nil
+}
+
+func (m *memoryUsers) GetUserByLogin(login string) (models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, u := range m.users {
+		if u.Login == login {
+			return u, nil
+		}
+	}
+	return models.User{}, storage.ErrNotFound
+}
+
+func newTestTokens(now time.Time) *Tokens {
+	tokens := NewTokens([]byte("test secret"), time.Hour)
+	tokens.now = func() time.Time { return now }
+	return tokens
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("password1")
+	assert.NoError(t, err)
+
+	// Проверяем, что пароль не хранится в открытом виде и проверяется по хешу
+	assert.NotContains(t, hash, "password1")
+	assert.NoError(t, CheckPassword(hash, "password1"))
+	assert.ErrorIs(t, CheckPassword(hash, "password2"), ErrInvalidCredentials)
+
+	// Проверяем ограничения длины пароля
+	_, err = HashPassword("short")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	_, err = HashPassword(strings.Repeat("a", 73))
+	assert.ErrorIs(t, err, ErrWeakPassword)
+}
+
+func TestTokens(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	tokens := newTestTokens(now)
+	token, expiresAt := tokens.Issue(7)
+	assert.Equal(t, now.Add(time.Hour), expiresAt)
+
+	otherSecret := NewTokens([]byte("other secret"), time.Hour)
+	otherSecret.now = tokens.now
+	forged, _ := otherSecret.Issue(8)
+	payload, signature, _ := strings.Cut(token, ".")
+	otherPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name          string
+		token         string
+		now           time.Time
+		expectedUser  int64
+		expectedError error
+	}{
+		{name: "Valid token", token: token, now: now, expectedUser: 7},
+		{name: "Expired token", token: token, now: expiresAt, expectedError: ErrTokenExpired},
+		{name: "Other secret", token: forged, now: now, expectedError: ErrInvalidToken},
+		{name: "Changed payload", token: otherPayload + "." + signature, now: now, expectedError: ErrInvalidToken},
+		{name: "No signature", token: payload, now: now, expectedError: ErrInvalidToken},
+		{name: "Empty token", token: "", now: now, expectedError: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens.now = func() time.Time { return tt.now }
+
+			// Проверяем, что принимается только подписанный нашим ключом и не истекший токен
+			userId, err := tokens.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedUser, userId)
+		})
+	}
+}
+
+func TestServiceRegisterAndLogin(t *testing.T) {
+	users := &memoryUsers{}
+	tokens := newTestTokens(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC))
+	service := NewService(users, tokens)
+
+	session, err := service.Register("user1", "user1@example.com", "password1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), session.UserId)
+
+	// Проверяем, что в хранилище попадает хеш пароля, а пользователь получает лигу по умолчанию
+	stored, _ := users.GetUserByLogin("user1")
+	assert.NotEqual(t, "password1", stored.Password)
+	assert.Equal(t, int64(DefaultLeagueId), stored.LeagueId)
+	userId, err := tokens.Verify(session.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), userId)
+
+	tests := []struct {
+		name          string
+		login         string
+		password      string
+		expectedUser  int64
+		expectedError error
+	}{
+		{name: "Valid password", login: "user1", password: "password1", expectedUser: 1},
+		{name: "Wrong password", login: "user1", password: "password2", expectedError: ErrInvalidCredentials},
+		{name: "Unknown login", login: "user2", password: "password1", expectedError: ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что неверный пароль и неизвестный логин неотличимы для клиента
+			session, err := service.Login(tt.login, tt.password)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedUser, session.UserId)
+		})
+	}
+
+	// Проверяем ошибки регистрации
+	_, err = service.Register("user1", "other@example.com", "password1")
+	assert.ErrorIs(t, err, storage.ErrAlreadyExists)
+	_, err = service.Register("user3", "user3@example.com", "short")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	_, err = service.Register("", "user4@example.com", "password1")
+	assert.ErrorIs(t, err, ErrEmptyLogin)
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// HARDCODE время жизни токена сессии по умолчанию
+const DefaultSessionTTL = 24 * time.Hour
+
+var (
+	ErrInvalidToken = errors.New("invalid session token")
+	ErrTokenExpired = errors.New("session token expired")
+)
+
+// claims - данные, подписываемые в токене.
+type claims struct {
+	UserId    int64 `json:"uid"` // Идентификатор пользователя
+	ExpiresAt int64 `json:"exp"` // Время окончания действия токена, unix секунды
+}
+
+// Tokens выдает и проверяет токены сессий.
+// Токен имеет вид base64(claims).base64(HMAC-SHA256(claims)), поэтому
+// для проверки не нужна БД, а изменить данные токена без секрета нельзя.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time // подменяется в тестах
+}
+
+// Конструктор для Tokens. secret - ключ подписи, ttl - время жизни токена.
+func NewTokens(secret []byte, ttl time.Duration) *Tokens {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &Tokens{secret: secret, ttl: ttl, now: time.Now}
+}
+
+// Issue выдает токен пользователю userId и возвращает его вместе со временем окончания действия.
+func (t *Tokens) Issue(userId int64) (string, time.Time) {
+	expiresAt := t.now().Add(t.ttl).Truncate(time.Second).UTC()
+	// Маршалинг структуры из двух чисел не может завершиться ошибкой
+	data, _ := json.Marshal(claims{UserId: userId, ExpiresAt: expiresAt.Unix()})
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload)), expiresAt
+}
+
+// Verify проверяет подпись и срок действия токена и возвращает идентификатор пользователя.
+func (t *Tokens) Verify(token string) (int64, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, t.sign(payload)) {
+		return 0, ErrInvalidToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(data, &c); err != nil || c.UserId < 1 {
+		return 0, ErrInvalidToken
+	}
+	if !t.now().Before(time.Unix(c.ExpiresAt, 0)) {
+		return 0, ErrTokenExpired
+	}
+	return c.UserId, nil
+}
+
+func (t *Tokens) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
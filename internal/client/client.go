@@ -4,36 +4,186 @@ package client
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"strconv"
 	"time"
 
-	pb "cyberball/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cyber/internal/interceptors"
+	"cyber/internal/logic"
+	"cyber/internal/models"
+	"cyber/pkg/pb"
 )
 
-// GameLogic Обрабатывает gRPC соединения с другими сервисами
-type GameLogicClient struct {
-	pb.UnimplementedGameLogicServiceServer
+// Options - параметры клиента.
+type Options struct {
+	Target      string            // Адрес сервиса game-actions
+	Timeout     time.Duration     // Время на вызов, если в контексте вызывающего нет дедлайна. 0 - без ограничения
+	MaxRetries  int               // Сколько раз повторять вызов, если сервис недоступен
+	Backoff     time.Duration     // Пауза перед первым повтором, удваивается с каждым повтором
+	MaxBackoff  time.Duration     // Наибольшая пауза между повторами
+	Token       string            // Общий токен сервисов, если сервис проверяет вызовы по токену
+	Insecure    bool              // Передавать токен без TLS. Только для разработки
+	DialOptions []grpc.DialOption // Дополнительные параметры соединения, например TLS
 }
 
-func (s *GameLogic) GetAction(ctx context.Context, rexq *pb.ActionRequest) (*pb.ActionResponse, error) {
+// HARDCODE DefaultOptions - параметры клиента по умолчанию
+var DefaultOptions = Options{
+	Target:     "localhost:50051",
+	Timeout:    time.Second,
+	MaxRetries: 3,
+	Backoff:    100 * time.Millisecond,
+	MaxBackoff: time.Second,
+}
 
+// GameLogic - клиент сервиса game-actions. Создается один раз и использует одно соединение
+// во всех вызовах, безопасен для одновременного использования.
+type GameLogic struct {
+	conn   *grpc.ClientConn
+	client pb.GameLogicServiceClient
+	opts   Options
+	sleep  func(ctx context.Context, d time.Duration) error // пауза между повторами, подменяется в тестах
 }
 
-func (s *GameLogic) AddAction(ctx context.Context, rexq *pb.LogicRequest) (*pb.ActionResponse, error) {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInesecure(), grpc.WithBlock())
+// Конструктор для GameLogic. Соединение устанавливается при первом вызове и
+// восстанавливается автоматически. Без TLS в opts.DialOptions соединение не шифруется,
+// а токен передается только с opts.Insecure.
+func New(opts Options) (*GameLogic, error) {
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if opts.Token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(interceptors.TokenCredentials{Token: opts.Token, Insecure: opts.Insecure}))
+	}
+	dialOptions = append(dialOptions, opts.DialOptions...)
+	conn, err := grpc.NewClient(opts.Target, dialOptions...)
 	if err != nil {
-		log.Printf("cant connect game-logic client by gRPC:%v\n", err)
+		return nil, fmt.Errorf("cant create game-logic client for %v: %w", opts.Target, err)
 	}
-	defer conn.Close()
+	return &GameLogic{conn: conn, client: pb.NewGameLogicServiceClient(conn), opts: opts, sleep: sleep}, nil
+}
 
-	client := pb.NewGameLogicServiceClient(conn)
+// Close закрывает соединение с сервисом.
+func (c *GameLogic) Close() error {
+	return c.conn.Close()
+}
+
+// AddAction добавляет действие и возвращает его ID.
+// Повтор после Unavailable может добавить действие дважды, если сервер успел его сохранить.
+func (c *GameLogic) AddAction(ctx context.Context, a models.Action) (int64, error) {
+	req := LogicRequest(a)
+	var header metadata.MD
+	err := c.call(ctx, func(ctx context.Context) error {
+		_, err := c.client.AddAction(ctx, req, grpc.Header(&header))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	ids := header.Get(logic.ActionIdHeader)
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(ids[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid action ID %q: %w", ids[0], err)
+	}
+	return id, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// GetAction возвращает действие по ID. Если действия нет, возвращается ошибка с кодом NotFound.
+func (c *GameLogic) GetAction(ctx context.Context, actionId int64) (models.Action, error) {
+	var resp *pb.ActionResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.GetAction(ctx, &pb.ActionRequest{Id: actionId})
+		return err
+	})
+	if err != nil {
+		return models.Action{}, err
+	}
+	return Action(resp), nil
+}
 
-	actionResponse, err := client.AddAction(ctx)
+// call выполняет вызов с дедлайном из ctx или opts.Timeout и повторяет его с растущей паузой,
+// пока сервис недоступен. Остальные ошибки возвращаются сразу.
+func (c *GameLogic) call(ctx context.Context, f func(ctx context.Context) error) error {
+	if _, ok := ctx.Deadline(); !ok && c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+
+	backoff := c.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		err := f(ctx)
+		if status.Code(err) != codes.Unavailable || attempt >= c.opts.MaxRetries {
+			return err
+		}
+		if sleepErr := c.sleep(ctx, backoff); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+		if c.opts.MaxBackoff > 0 && backoff > c.opts.MaxBackoff {
+			backoff = c.opts.MaxBackoff
+		}
+	}
+}
+
+// sleep ждет d или отмены ctx.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// LogicRequest переводит действие в запрос AddAction. ID действия не передается:
+// его назначает сервис. Нулевое время начала означает "сейчас".
+func LogicRequest(a models.Action) *pb.LogicRequest {
+	req := &pb.LogicRequest{
+		UserId:          a.UserId,
+		AreaId:          a.AreaId,
+		ObjectSourceId:  a.ObjectSourceId,
+		ObjectDestId:    a.ObjectDestId,
+		ActionType:      a.ActionType,
+		Characteristics: string(a.Characteristics),
+		Duration:        durationpb.New(a.Duration),
+		Status:          a.Status,
+	}
+	if !a.StartTime.IsZero() {
+		req.StartTime = timestamppb.New(a.StartTime)
+	}
+	return req
+}
+
+// Action переводит ответ GetAction в действие.
+func Action(resp *pb.ActionResponse) models.Action {
+	a := models.Action{
+		Id:             resp.GetId(),
+		UserId:         resp.GetUserId(),
+		AreaId:         resp.GetAreaId(),
+		ObjectSourceId: resp.GetObjectSourceId(),
+		ObjectDestId:   resp.GetObjectDestId(),
+		ActionType:     resp.GetActionType(),
+		Status:         resp.GetStatus(),
+	}
+	if c := resp.GetCharacteristics(); c != "" {
+		a.Characteristics = []byte(c)
+	}
+	if resp.StartTime != nil {
+		a.StartTime = resp.StartTime.AsTime()
+	}
+	if resp.Duration != nil {
+		a.Duration = resp.Duration.AsDuration()
+	}
+	return a
 }
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"cyber/internal/interceptors"
+	"cyber/internal/logic"
+	"cyber/internal/models"
+	"cyber/pkg/pb"
+)
+
+// flakyServer отвечает Unavailable на первые failures вызовов и запоминает полученные запросы
+type flakyServer struct {
+	pb.UnimplementedGameLogicServiceServer
+	mu       sync.Mutex
+	failures int
+	calls    int
+	requests []*pb.LogicRequest
+	block    bool // вызов ждет отмены контекста
+}
+
+func (f *flakyServer) fail() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failures {
+		return status.Error(codes.Unavailable, "restarting")
+	}
+	return nil
+}
+
+func (f *flakyServer) AddAction(ctx context.Context, req *pb.LogicRequest) (*emptypb.Empty, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+	grpc.SetHeader(ctx, metadata.Pairs(logic.ActionIdHeader, "42"))
+	return &emptypb.Empty{}, nil
+}
+
+func (f *flakyServer) GetAction(ctx context.Context, req *pb.ActionRequest) (*pb.ActionResponse, error) {
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	if req.Id != 42 {
+		return nil, status.Error(codes.NotFound, "object not found")
+	}
+	return &pb.ActionResponse{Id: 42, UserId: 1, AreaId: 4, ActionType: "move", Status: models.ActionDone}, nil
+}
+
+// newTestClient запускает srv на локальном порту и возвращает клиента к нему без пауз между повторами
+func newTestClient(t *testing.T, srv pb.GameLogicServiceServer) (*GameLogic, *[]time.Duration) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterGameLogicServiceServer(grpcServer, srv)
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+
+	opts := DefaultOptions
+	opts.Target = ln.Addr().String()
+	c, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	var pauses []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		pauses = append(pauses, d)
+		return nil
+	}
+	return c, &pauses
+}
+
+func TestGameLogicRetries(t *testing.T) {
+	tests := []struct {
+		name           string
+		failures       int
+		expectedCode   codes.Code
+		expectedPauses []time.Duration
+	}{
+		{"Available", 0, codes.OK, nil},
+		{"Restarting", 2, codes.OK, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"Down", 10, codes.Unavailable, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &flakyServer{failures: tt.failures}
+			c, pauses := newTestClient(t, srv)
+
+			// Проверяем, что вызов повторяется с растущей паузой, пока сервис недоступен
+			action, err := c.GetAction(context.Background(), 42)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedPauses, *pauses)
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, models.Action{Id: 42, UserId: 1, AreaId: 4, ActionType: "move", Status: models.ActionDone}, action)
+			}
+		})
+	}
+}
+
+func TestGameLogicDoesNotRetryOtherErrors(t *testing.T) {
+	c, pauses := newTestClient(t, &flakyServer{})
+
+	// Проверяем, что ошибки, кроме Unavailable, возвращаются без повторов
+	_, err := c.GetAction(context.Background(), 7)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, *pauses)
+}
+
+func TestGameLogicDeadline(t *testing.T) {
+	c, pauses := newTestClient(t, &flakyServer{block: true})
+
+	// Проверяем, что дедлайн вызывающего передается серверу
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetAction(ctx, 42)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Empty(t, *pauses)
+}
+
+func TestGameLogicToken(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(interceptors.ServerOptions(interceptors.NewServiceAuth("service-token"), nil)...)
+	pb.RegisterGameLogicServiceServer(grpcServer, &flakyServer{})
+	go grpcServer.Serve(ln)
+	defer grpcServer.Stop()
+
+	tests := []struct {
+		name         string
+		token        string
+		insecure     bool
+		expectedErr  bool
+		expectedCode codes.Code
+	}{
+		{"With token", "service-token", true, false, codes.OK},
+		{"Without token", "", false, false, codes.Unauthenticated},
+		{"Token without TLS", "service-token", false, true, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions
+			opts.Target = ln.Addr().String()
+			opts.Token = tt.token
+			opts.Insecure = tt.insecure
+			c, err := New(opts)
+			// Проверяем, что без TLS токен передается только с явным разрешением
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			// Проверяем, что токен из Options передается в каждом вызове
+			_, err = c.GetAction(context.Background(), 42)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestGameLogicAddAction(t *testing.T) {
+	srv := &flakyServer{failures: 1}
+	c, _ := newTestClient(t, srv)
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// Проверяем, что действие переводится в запрос, а ID приходит из заголовка ответа
+	id, err := c.AddAction(context.Background(), models.Action{
+		UserId:          1,
+		AreaId:          4,
+		ActionType:      "build",
+		Characteristics: []byte(`{"object":"house"}`),
+		StartTime:       start,
+		Duration:        time.Minute,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	if assert.Len(t, srv.requests, 1) {
+		req := srv.requests[0]
+		assert.Equal(t, "build", req.ActionType)
+		assert.Equal(t, `{"object":"house"}`, req.Characteristics)
+		assert.Equal(t, start, req.StartTime.AsTime())
+		assert.Equal(t, time.Minute, req.Duration.AsDuration())
+	}
+}
+
+func TestActionConversion(t *testing.T) {
+	action := models.Action{
+		UserId:          1,
+		AreaId:          4,
+		ObjectSourceId:  5,
+		ObjectDestId:    6,
+		ActionType:      "attack",
+		Characteristics: []byte(`{"atacker":5,"defenser":6}`),
+		StartTime:       time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		Duration:        3 * time.Second,
+		Status:          models.ActionInProgress,
+	}
+	req := LogicRequest(action)
+
+	// Проверяем, что действие, переданное запросом и прочитанное ответом, не меняется
+	resp := &pb.ActionResponse{
+		Id:              9,
+		UserId:          req.UserId,
+		AreaId:          req.AreaId,
+		ObjectSourceId:  req.ObjectSourceId,
+		ObjectDestId:    req.ObjectDestId,
+		ActionType:      req.ActionType,
+		Characteristics: req.Characteristics,
+		StartTime:       req.StartTime,
+		Duration:        req.Duration,
+		Status:          req.Status,
+	}
+	action.Id = 9
+	assert.Equal(t, action, Action(resp))
+
+	// Проверяем, что без времени начала сервис начинает действие сразу
+	assert.Nil(t, LogicRequest(models.Action{ActionType: "move"}).StartTime)
+}
@@ -0,0 +1,262 @@
+/*
+Конфигурация сервера.
+Значения читаются из переменных окружения, файл .env (если он есть) загружается
+перед чтением. Для всех параметров, кроме пароля БД и ключа подписи токенов сессий,
+заданы значения по умолчанию.
+*/
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config - настройки сервера.
+type Config struct {
+	WSAddr          string        // Адрес websocket сервера
+	GRPCAddr        string        // Адрес gRPC сервера GameLogicService и WorldService
+	MetricsAddr     string        // Адрес внутреннего HTTP сервера метрик /metrics, не публикуется наружу
+	DBHost          string        // Хост БД
+	DBPort          int           // Порт БД
+	DBUser          string        // Пользователь БД
+	DBPassword      string        // Пароль БД
+	DBName          string        // Название БД
+	ShutdownTimeout time.Duration // Максимальное время на корректную остановку сервера
+	AuthSecret      string        // Ключ подписи токенов сессий
+	SessionTTL      time.Duration // Время жизни токена сессии
+
+	MaxMessageSize int     // Максимальный размер сообщения websocket в байтах
+	ConnRate       float64 // Сообщений в секунду с одного соединения
+	ConnBurst      int     // Сообщений, которые соединение может отправить сверх ConnRate за раз
+	UserRate       float64 // Сообщений в секунду со всех соединений пользователя
+	UserBurst      int     // Сообщений, которые пользователь может отправить сверх UserRate за раз
+	MaxViolations  int     // Отклоненных за минуту сообщений, после которых соединение закрывается
+	// ActionLimits - ограничения сообщений пользователя по типам действий, заменяют ограничения
+	// по умолчанию для перечисленных типов
+	ActionLimits map[string]RateLimit
+
+	MaxGroupUnits int // Юнитов в одном групповом перемещении
+
+	GRPCToken    string   // Общий токен сервисов, вызывающих gRPC API. Пустой - вход по токену отключен
+	GRPCCert     string   // Файл сертификата gRPC сервера. Пустой - gRPC без TLS
+	GRPCKey      string   // Файл ключа сертификата gRPC сервера
+	GRPCClientCA string   // Файл УЦ клиентских сертификатов для mTLS. Пустой - вход по сертификату отключен
+	GRPCServices []string // Имена (CN) сервисов, которым разрешен вход по сертификату. Пустой - любые
+	GRPCInsecure bool     // gRPC без проверки сервисов-клиентов и токен без TLS. Только для разработки
+}
+
+// RateLimit - ограничение частоты сообщений одного типа.
+type RateLimit struct {
+	Rate  float64 // Сообщений в секунду
+	Burst int     // Сообщений, которые можно отправить сверх Rate за раз
+}
+
+// HARDCODE значения по умолчанию
+const (
+	defaultWSAddr          = ":8080"
+	defaultGRPCAddr        = ":50051"
+	defaultMetricsAddr     = "127.0.0.1:9100"
+	defaultDBHost          = "localhost"
+	defaultDBPort          = 5432
+	defaultDBUser          = "postgres"
+	defaultDBName          = "cyberball"
+	defaultShutdownTimeout = 15 * time.Second
+	defaultSessionTTL      = 24 * time.Hour
+	minAuthSecretLength    = 32
+	defaultMaxMessageSize  = 16 * 1024
+	defaultConnRate        = 20
+	defaultConnBurst       = 40
+	defaultUserRate        = 30
+	defaultUserBurst       = 60
+	defaultMaxViolations   = 20
+	defaultMaxGroupUnits   = 20
+)
+
+// Load загружает конфигурацию из .env и переменных окружения.
+func Load() (Config, error) {
+	if err := godotenv.Load(); err != nil {
+		log.Println("cant loading .env file, using environment variables")
+	}
+
+	c := Config{
+		WSAddr:          getEnv("WS_ADDR", defaultWSAddr),
+		GRPCAddr:        getEnv("GRPC_ADDR", defaultGRPCAddr),
+		MetricsAddr:     getEnv("METRICS_ADDR", defaultMetricsAddr),
+		DBHost:          getEnv("DBHOST", defaultDBHost),
+		DBUser:          getEnv("DBUSER", defaultDBUser),
+		DBPassword:      os.Getenv("DBPASSWORD"),
+		DBName:          getEnv("DBNAME", defaultDBName),
+		DBPort:          defaultDBPort,
+		ShutdownTimeout: defaultShutdownTimeout,
+		AuthSecret:      os.Getenv("AUTH_SECRET"),
+		SessionTTL:      defaultSessionTTL,
+		MaxMessageSize:  defaultMaxMessageSize,
+		ConnRate:        defaultConnRate,
+		ConnBurst:       defaultConnBurst,
+		UserRate:        defaultUserRate,
+		UserBurst:       defaultUserBurst,
+		MaxViolations:   defaultMaxViolations,
+		MaxGroupUnits:   defaultMaxGroupUnits,
+		GRPCToken:       os.Getenv("GRPC_TOKEN"),
+		GRPCCert:        os.Getenv("GRPC_TLS_CERT"),
+		GRPCKey:         os.Getenv("GRPC_TLS_KEY"),
+		GRPCClientCA:    os.Getenv("GRPC_CLIENT_CA"),
+	}
+
+	// Без ключа подписи любой клиент мог бы выдать себе токен, поэтому значения по умолчанию нет
+	if len(c.AuthSecret) < minAuthSecretLength {
+		return Config{}, fmt.Errorf("AUTH_SECRET must be at least %d bytes long", minAuthSecretLength)
+	}
+
+	if v := os.Getenv("DBPORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil || port <= 0 {
+			return Config{}, fmt.Errorf("invalid DBPORT %q", v)
+		}
+		c.DBPort = port
+	}
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			return Config{}, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q", v)
+		}
+		c.ShutdownTimeout = timeout
+	}
+	if v := os.Getenv("SESSION_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return Config{}, fmt.Errorf("invalid SESSION_TTL %q", v)
+		}
+		c.SessionTTL = ttl
+	}
+
+	// Ограничения websocket сообщений
+	for key, value := range map[string]*int{
+		"WS_MAX_MESSAGE_SIZE": &c.MaxMessageSize,
+		"WS_CONN_BURST":       &c.ConnBurst,
+		"WS_USER_BURST":       &c.UserBurst,
+		"WS_MAX_VIOLATIONS":   &c.MaxViolations,
+	} {
+		if v := os.Getenv(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return Config{}, fmt.Errorf("invalid %s %q", key, v)
+			}
+			*value = n
+		}
+	}
+	for key, value := range map[string]*float64{
+		"WS_CONN_RATE": &c.ConnRate,
+		"WS_USER_RATE": &c.UserRate,
+	} {
+		if v := os.Getenv(key); v != "" {
+			rate, err := strconv.ParseFloat(v, 64)
+			if err != nil || rate <= 0 {
+				return Config{}, fmt.Errorf("invalid %s %q", key, v)
+			}
+			*value = rate
+		}
+	}
+	if v := os.Getenv("WS_ACTION_LIMITS"); v != "" {
+		limits, err := parseActionLimits(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid WS_ACTION_LIMITS %q: %v", v, err)
+		}
+		c.ActionLimits = limits
+	}
+
+	// Настройки игры
+	if v := os.Getenv("GAME_MAX_GROUP_UNITS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("invalid GAME_MAX_GROUP_UNITS %q", v)
+		}
+		c.MaxGroupUnits = n
+	}
+	// Сертификат и ключ задаются вместе, а mTLS невозможен без TLS
+	if (c.GRPCCert == "") != (c.GRPCKey == "") {
+		return Config{}, fmt.Errorf("GRPC_TLS_CERT and GRPC_TLS_KEY must be set together")
+	}
+	if c.GRPCClientCA != "" && c.GRPCCert == "" {
+		return Config{}, fmt.Errorf("GRPC_CLIENT_CA requires GRPC_TLS_CERT and GRPC_TLS_KEY")
+	}
+	// gRPC API без проверки вызывающих сервисов открыт всем, кто может подключиться,
+	// поэтому такой режим включается только явно
+	if v := os.Getenv("GRPC_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid GRPC_INSECURE %q", v)
+		}
+		c.GRPCInsecure = insecure
+	}
+	if !c.GRPCInsecure {
+		if c.GRPCToken == "" && c.GRPCClientCA == "" {
+			return Config{}, fmt.Errorf("GRPC_TOKEN or GRPC_CLIENT_CA must be set, GRPC_INSECURE=true disables gRPC authentication for development")
+		}
+		if c.GRPCToken != "" && c.GRPCCert == "" {
+			return Config{}, fmt.Errorf("GRPC_TOKEN requires GRPC_TLS_CERT and GRPC_TLS_KEY unless GRPC_INSECURE=true")
+		}
+	}
+	if v := os.Getenv("GRPC_ALLOWED_SERVICES"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				c.GRPCServices = append(c.GRPCServices, name)
+			}
+		}
+	}
+	return c, nil
+}
+
+// DSN возвращает строку подключения к БД.
+func (c Config) DSN() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.DBUser, c.DBPassword),
+		Host:   fmt.Sprintf("%s:%d", c.DBHost, c.DBPort),
+		Path:   c.DBName,
+	}
+	return u.String()
+}
+
+// parseActionLimits разбирает ограничения по типам действий вида "move=10:20,attack=5:10",
+// где 10 - сообщений в секунду, а 20 - сообщений сверх этого за раз.
+func parseActionLimits(v string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		action, limit, ok := strings.Cut(item, "=")
+		rate, burst, ok2 := strings.Cut(limit, ":")
+		action = strings.TrimSpace(action)
+		if !ok || !ok2 || action == "" {
+			return nil, fmt.Errorf("%q is not action=rate:burst", item)
+		}
+		r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("invalid rate of %s %q", action, rate)
+		}
+		b, err := strconv.Atoi(strings.TrimSpace(burst))
+		if err != nil || b <= 0 {
+			return nil, fmt.Errorf("invalid burst of %s %q", action, burst)
+		}
+		limits[action] = RateLimit{Rate: r, Burst: b}
+	}
+	return limits, nil
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
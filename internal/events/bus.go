@@ -0,0 +1,117 @@
+/*
+Шина событий игры.
+Любая игровая система (улучшения, ИИ врагов, опыт, действия) публикует события в шину,
+а подписчики (websocket сессии, gRPC потоки) получают только интересующие их события.
+Публикация никогда не блокируется: если подписчик не успевает читать события,
+новые события для него отбрасываются и учитываются в счетчике Dropped.
+*/
+
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event - событие игры.
+type Event struct {
+	Type     string      `json:"type"`                // Тип события: upgrade, enemy, level_up, move и т.д.
+	UserId   int64       `json:"user_id,omitempty"`   // Пользователь, которому адресовано событие (0 - всем наблюдающим арену)
+	AreaId   int64       `json:"area_id,omitempty"`   // Арена, на которой произошло событие
+	ActionId int64       `json:"action_id,omitempty"` // Действие, к которому относится событие
+	Payload  interface{} `json:"payload"`             // Данные события, отправляемые клиенту
+	Time     time.Time   `json:"time"`                // Время события
+}
+
+// Filter отбирает события по пользователю, арене и действию. Нулевое поле означает любое значение.
+type Filter struct {
+	UserId   int64
+	AreaId   int64
+	ActionId int64
+}
+
+// Match проверяет, подходит ли событие под фильтр.
+func (f Filter) Match(e Event) bool {
+	return (f.UserId == 0 || f.UserId == e.UserId) &&
+		(f.AreaId == 0 || f.AreaId == e.AreaId) &&
+		(f.ActionId == 0 || f.ActionId == e.ActionId)
+}
+
+// HARDCODE размер буфера подписки по умолчанию
+const DefaultBuffer = 256
+
+// Subscription - подписка на события шины.
+type Subscription struct {
+	C <-chan Event // Канал событий подписки
+
+	ch      chan Event
+	filter  Filter
+	bus     *Bus
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+// Dropped возвращает количество событий, отброшенных из-за переполнения буфера подписки.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close отменяет подписку и закрывает ее канал.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		delete(s.bus.subs, s)
+		s.bus.mu.Unlock()
+		close(s.ch)
+	})
+}
+
+// Bus - шина событий.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+	now  func() time.Time
+}
+
+// Конструктор для Bus
+func NewBus() *Bus {
+	return &Bus{
+		subs: make(map[*Subscription]struct{}),
+		now:  time.Now,
+	}
+}
+
+// Subscribe подписывается на события, подходящие под filter. buffer - размер буфера подписки,
+// при buffer <= 0 используется DefaultBuffer.
+func (b *Bus) Subscribe(filter Filter, buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	ch := make(chan Event, buffer)
+	s := &Subscription{C: ch, ch: ch, filter: filter, bus: b}
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+// Publish отправляет событие всем подходящим подписчикам. Если время события не задано,
+// подставляется текущее.
+func (b *Bus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = b.now()
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	e := Event{Type: "upgrade", UserId: 1, AreaId: 2, ActionId: 3}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{name: "Empty filter", filter: Filter{}, expected: true},
+		{name: "Same area", filter: Filter{AreaId: 2}, expected: true},
+		{name: "Other area", filter: Filter{AreaId: 5}, expected: false},
+		{name: "User and action", filter: Filter{UserId: 1, ActionId: 3}, expected: true},
+		{name: "Other action", filter: Filter{UserId: 1, ActionId: 4}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(e))
+		})
+	}
+}
+
+func TestBusPublish(t *testing.T) {
+	bus := NewBus()
+	now := time.Date(2025, 1, 22, 4, 39, 28, 0, time.UTC)
+	bus.now = func() time.Time { return now }
+
+	area := bus.Subscribe(Filter{AreaId: 1}, 4)
+	all := bus.Subscribe(Filter{}, 4)
+	defer all.Close()
+
+	bus.Publish(Event{Type: "upgrade", AreaId: 1, Payload: "processing"})
+	bus.Publish(Event{Type: "enemy", AreaId: 2})
+
+	got := <-area.C
+	assert.Equal(t, "upgrade", got.Type)
+	assert.Equal(t, now, got.Time)
+	assert.Len(t, area.C, 0)
+	assert.Len(t, all.C, 2)
+
+	// Проверяем, что после отмены подписки канал закрыт и события не приходят
+	area.Close()
+	area.Close()
+	bus.Publish(Event{Type: "upgrade", AreaId: 1})
+	_, ok := <-area.C
+	assert.False(t, ok)
+}
+
+func TestBusSlowSubscriber(t *testing.T) {
+	bus := NewBus()
+	slow := bus.Subscribe(Filter{}, 2)
+	defer slow.Close()
+
+	// Публикация не блокируется, лишние события отбрасываются
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: "enemy", ActionId: int64(i)})
+	}
+	assert.Equal(t, int64(3), slow.Dropped())
+	assert.Equal(t, int64(0), (<-slow.C).ActionId)
+	assert.Equal(t, int64(1), (<-slow.C).ActionId)
+}
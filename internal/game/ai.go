@@ -0,0 +1,436 @@
+/*
+ИИ врагов на арене.
+Каждый враг управляется конечным автоматом: покой, патруль вокруг точки появления,
+преследование игрока, замеченного в пределах Vision, атака в радиусе AtackRange
+и возврат на точку появления, если враг отошел от нее дальше LeashRadius.
+ИИ работает по тикам на состоянии арены в памяти (MemoryWorld), а все случайные
+решения принимаются через переданный генератор, поэтому при одинаковом seed
+поведение полностью воспроизводимо. Перемещения врагов, урон и гибель объектов игрока
+сохраняются в БД, чтобы арена, загруженная заново, совпадала с той, что была в памяти.
+*/
+
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"cyber/internal/models"
+)
+
+// EnemyState - состояние врага.
+type EnemyState string
+
+const (
+	EnemyIdle   EnemyState = "idle"   // Враг стоит на месте
+	EnemyPatrol EnemyState = "patrol" // Враг патрулирует вокруг точки появления
+	EnemyChase  EnemyState = "chase"  // Враг преследует цель
+	EnemyAttack EnemyState = "attack" // Враг атакует цель в радиусе атаки
+	EnemyLeash  EnemyState = "leash"  // Враг возвращается на точку появления
+)
+
+// Behaviour описывает поведение одного типа врагов.
+type Behaviour struct {
+	PatrolRadius   int     // Радиус патруля вокруг точки появления (0 - враг не патрулирует)
+	PatrolChance   float64 // Вероятность начать патруль из состояния покоя за один тик
+	LeashRadius    int     // Максимальное удаление от точки появления (0 - без ограничений)
+	AttackCooldown int     // Перезарядка атаки в тиках (1 - атака каждый тик)
+}
+
+// BehaviourTable - поведение врагов по названию. Запись "default" используется для остальных врагов.
+type BehaviourTable map[string]Behaviour
+
+// For возвращает поведение врага с названием name.
+func (t BehaviourTable) For(name string) Behaviour {
+	if b, ok := t[name]; ok {
+		return b
+	}
+	return t["default"]
+}
+
+// HARDCODE DefaultBehaviours - поведение врагов по умолчанию
+var DefaultBehaviours = BehaviourTable{
+	"default": {PatrolRadius: 3, PatrolChance: 0.2, LeashRadius: 12, AttackCooldown: 1},
+	"Goblin":  {PatrolRadius: 5, PatrolChance: 0.4, LeashRadius: 10, AttackCooldown: 1},
+	"Orc":     {PatrolRadius: 3, PatrolChance: 0.2, LeashRadius: 15, AttackCooldown: 2},
+	"Dragon":  {PatrolRadius: 0, PatrolChance: 0, LeashRadius: 20, AttackCooldown: 3},
+}
+
+// AIEvent - событие ИИ врага, отправляемое клиенту.
+type AIEvent struct {
+	Type       string     `json:"type"`                  // Тип события - enemy
+	AreaId     int64      `json:"area_id"`               // Идентификатор арены
+	EnemyId    int64      `json:"enemy_id"`              // Идентификатор врага
+	State      EnemyState `json:"state"`                 // Новое состояние врага
+	TargetId   int64      `json:"target_id,omitempty"`   // Идентификатор цели
+	TargetKind string     `json:"target_kind,omitempty"` // Вид цели - hero, unit или building
+	Position   models.Hex `json:"position"`              // Позиция врага
+	Message    string     `json:"message,omitempty"`     // Например "unit 456 killed"
+}
+
+// BattleStore сохраняет изменения арены, сделанные ИИ врагов. Реализуется *postgress.Storage.
+type BattleStore interface {
+	UpdateEnemy(e models.Enemy) error
+	UpdateHeroProgress(h models.Hero) error
+	UpdateUnitProgress(u models.Unit) error
+	UpdateBuilding(b models.Building) error
+	DeleteHero(heroId int64) error
+	DeleteUnit(unitId int64) error
+	DeleteBuilding(buildingId int64) error
+}
+
+// enemyBrain - состояние автомата одного врага.
+type enemyBrain struct {
+	home      Hex
+	state     EnemyState
+	target    Target
+	hasTarget bool
+	path      []Hex
+	cooldown  int
+	objective Target // Цель штурма, к которой враг идет независимо от дальности видимости
+	assault   bool
+}
+
+// AI управляет врагами одной арены.
+type AI struct {
+	world      *MemoryWorld
+	behaviours BehaviourTable
+	store      BattleStore
+	rng        *rand.Rand
+	notify     func(AIEvent)
+	brains     map[int64]*enemyBrain
+}
+
+// Конструктор для AI. store может быть nil, тогда изменения арены остаются только в памяти.
+// notify может быть nil, тогда события не отправляются.
+func NewAI(world *MemoryWorld, behaviours BehaviourTable, store BattleStore, rng *rand.Rand, notify func(AIEvent)) *AI {
+	return &AI{
+		world:      world,
+		behaviours: behaviours,
+		store:      store,
+		rng:        rng,
+		notify:     notify,
+		brains:     make(map[int64]*enemyBrain),
+	}
+}
+
+// State возвращает текущее состояние врага.
+func (a *AI) State(enemyId int64) EnemyState {
+	if b, ok := a.brains[enemyId]; ok {
+		return b.state
+	}
+	return EnemyIdle
+}
+
+// Assault отправляет врага штурмовать объект игрока, например здание.
+// Пока цель штурма цела, враг не возвращается на точку появления.
+func (a *AI) Assault(enemyId int64, target Target) {
+	e, ok := a.world.Enemies[enemyId]
+	if !ok || len(e.Coordinates) == 0 {
+		return
+	}
+	brain := a.brainOf(e)
+	brain.objective = target
+	brain.assault = true
+}
+
+// Run выполняет тики ИИ с интервалом interval, пока не будет отменен ctx.
+// Каждый тик выполняется под блокировкой арены.
+func (a *AI) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.world.Lock()
+			a.Tick()
+			a.world.Unlock()
+		}
+	}
+}
+
+// Tick выполняет один шаг ИИ для всех врагов арены в порядке их ID.
+func (a *AI) Tick() {
+	ids := make([]int64, 0, len(a.world.Enemies))
+	for id := range a.world.Enemies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for id := range a.brains {
+		if _, ok := a.world.Enemies[id]; !ok {
+			delete(a.brains, id)
+		}
+	}
+	for _, id := range ids {
+		a.step(a.world.Enemies[id])
+	}
+}
+
+// step выполняет один шаг автомата врага.
+func (a *AI) step(e *models.Enemy) {
+	if len(e.Coordinates) == 0 {
+		return
+	}
+	pos := Hex(e.Coordinates[0])
+	brain := a.brainOf(e)
+	behaviour := a.behaviours.For(e.Name)
+	if brain.cooldown > 0 {
+		brain.cooldown--
+	}
+
+	// Возврат на точку появления - цели игнорируются, пока враг не вернется
+	if brain.state == EnemyLeash {
+		if pos == brain.home || (pos.Distance(brain.home) <= 1 && a.world.Blocked(brain.home)) {
+			a.setState(e, brain, EnemyIdle)
+			return
+		}
+		a.moveAlong(e, brain, brain.home, 0)
+		return
+	}
+	if !brain.assault && behaviour.LeashRadius > 0 && pos.Distance(brain.home) > behaviour.LeashRadius {
+		brain.hasTarget = false
+		brain.path = nil
+		a.setState(e, brain, EnemyLeash)
+		a.moveAlong(e, brain, brain.home, 0)
+		return
+	}
+
+	// Проверяем, жива ли текущая цель, иначе ищем новую в пределах видимости
+	if brain.hasTarget {
+		brain.target, brain.hasTarget = a.world.Target(brain.target.Kind, brain.target.Id)
+	}
+	if !brain.hasTarget {
+		brain.target, brain.hasTarget = a.nearestVisible(pos, e.Charachteristics.Vision)
+		if !brain.hasTarget && brain.assault {
+			brain.target, brain.hasTarget = a.world.Target(brain.objective.Kind, brain.objective.Id)
+			if !brain.hasTarget {
+				// Цель штурма уничтожена - враг остается на месте как обычный враг
+				brain.assault = false
+				brain.home = pos
+			}
+		}
+		if brain.hasTarget {
+			brain.path = nil
+			a.setState(e, brain, EnemyChase)
+		}
+	}
+
+	if brain.hasTarget {
+		attackRange := rangeOf(e)
+		if pos.Distance(brain.target.Position) <= attackRange {
+			a.setState(e, brain, EnemyAttack)
+			a.attack(e, brain, behaviour)
+			return
+		}
+		a.setState(e, brain, EnemyChase)
+		brain.path = nil // цель двигается, путь пересчитывается каждый тик
+		a.moveAlong(e, brain, brain.target.Position, attackRange)
+		return
+	}
+
+	a.wander(e, brain, behaviour, pos)
+}
+
+// brainOf возвращает состояние автомата врага, создавая его при первом обращении.
+func (a *AI) brainOf(e *models.Enemy) *enemyBrain {
+	brain, ok := a.brains[e.Id]
+	if !ok {
+		brain = &enemyBrain{home: Hex(e.Coordinates[0]), state: EnemyIdle}
+		a.brains[e.Id] = brain
+	}
+	return brain
+}
+
+// wander управляет врагом без цели: покой и патруль вокруг точки появления.
+func (a *AI) wander(e *models.Enemy, brain *enemyBrain, behaviour Behaviour, pos Hex) {
+	if brain.state == EnemyPatrol && len(brain.path) > 0 {
+		a.moveAlong(e, brain, brain.path[len(brain.path)-1], 0)
+		if len(brain.path) == 0 {
+			a.setState(e, brain, EnemyIdle)
+		}
+		return
+	}
+	if brain.state != EnemyIdle {
+		a.setState(e, brain, EnemyIdle)
+	}
+	if behaviour.PatrolRadius <= 0 || a.rng.Float64() >= behaviour.PatrolChance {
+		return
+	}
+
+	// Выбираем случайную свободную точку в радиусе патруля
+	r := behaviour.PatrolRadius
+	dq := float64(a.rng.Intn(2*r+1) - r)
+	dr := float64(a.rng.Intn(2*r+1) - r)
+	goal := Hex{Q: brain.home.Q + dq, R: brain.home.R + dr}
+	if goal == pos || brain.home.Distance(goal) > r {
+		return
+	}
+	path := a.world.FindPath(pos, goal)
+	if len(path) == 0 {
+		return
+	}
+	brain.path = path
+	a.setState(e, brain, EnemyPatrol)
+}
+
+// attack атакует текущую цель, если атака перезарядилась.
+func (a *AI) attack(e *models.Enemy, brain *enemyBrain, behaviour Behaviour) {
+	if brain.cooldown > 0 {
+		return
+	}
+	brain.cooldown = behaviour.AttackCooldown
+	killed := a.world.DamageTarget(brain.target, e.Charachteristics.Damage)
+	a.saveTarget(brain.target, killed)
+	if !killed {
+		return
+	}
+	a.publish(AIEvent{
+		Type:       "enemy",
+		AreaId:     a.world.AreaId,
+		EnemyId:    e.Id,
+		State:      brain.state,
+		TargetId:   brain.target.Id,
+		TargetKind: brain.target.Kind,
+		Position:   e.Coordinates[0],
+		Message:    fmt.Sprintf("%v %v killed", brain.target.Kind, brain.target.Id),
+	})
+	brain.hasTarget = false
+	a.setState(e, brain, EnemyIdle)
+}
+
+// moveAlong передвигает врага к goal не больше чем на Speed клеток за тик
+// и останавливается, как только расстояние до goal не превышает stopAt.
+func (a *AI) moveAlong(e *models.Enemy, brain *enemyBrain, goal Hex, stopAt int) {
+	pos := Hex(e.Coordinates[0])
+	if len(brain.path) == 0 || brain.path[len(brain.path)-1] != goal {
+		brain.path = a.world.FindPathTo(pos, goal)
+	}
+	start := pos
+	defer func() {
+		if pos != start {
+			a.saveEnemy(e)
+		}
+	}()
+	for steps := speedOf(e); steps > 0 && len(brain.path) > 0; steps-- {
+		if pos.Distance(goal) <= stopAt {
+			break
+		}
+		next := brain.path[0]
+		if a.world.Blocked(next) {
+			// Клетку занял другой объект - путь будет пересчитан на следующем тике
+			brain.path = nil
+			break
+		}
+		a.world.MoveEnemy(e.Id, next)
+		brain.path = brain.path[1:]
+		pos = next
+	}
+}
+
+// saveEnemy сохраняет врага после перемещения.
+func (a *AI) saveEnemy(e *models.Enemy) {
+	if a.store == nil {
+		return
+	}
+	if err := a.store.UpdateEnemy(*e); err != nil {
+		log.Printf("Cant save enemy ID- %v: %v\n", e.Id, err)
+	}
+}
+
+// saveTarget сохраняет здоровье атакованной цели или удаляет погибшую цель.
+func (a *AI) saveTarget(target Target, killed bool) {
+	if a.store == nil {
+		return
+	}
+	var err error
+	switch target.Kind {
+	case KindBuilding:
+		if killed {
+			err = a.store.DeleteBuilding(target.Id)
+		} else if b, ok := a.world.Buildings[target.Id]; ok {
+			err = a.store.UpdateBuilding(*b)
+		}
+	case KindHero:
+		if killed {
+			err = a.store.DeleteHero(target.Id)
+		} else if h, ok := a.world.Heroes[target.Id]; ok {
+			err = a.store.UpdateHeroProgress(*h)
+		}
+	case KindUnit:
+		if killed {
+			err = a.store.DeleteUnit(target.Id)
+		} else if u, ok := a.world.Units[target.Id]; ok {
+			err = a.store.UpdateUnitProgress(*u)
+		}
+	}
+	if err != nil {
+		log.Printf("Cant save %v ID- %v after enemy attack: %v\n", target.Kind, target.Id, err)
+	}
+}
+
+// nearestVisible возвращает ближайшую цель игрока в пределах видимости vision.
+func (a *AI) nearestVisible(pos Hex, vision int) (Target, bool) {
+	var best Target
+	found := false
+	bestDistance := 0
+	for _, t := range a.world.Targets() {
+		d := pos.Distance(t.Position)
+		if d > vision {
+			continue
+		}
+		if !found || d < bestDistance {
+			best, bestDistance, found = t, d, true
+		}
+	}
+	return best, found
+}
+
+func (a *AI) setState(e *models.Enemy, brain *enemyBrain, state EnemyState) {
+	if brain.state == state {
+		return
+	}
+	brain.state = state
+	event := AIEvent{
+		Type:     "enemy",
+		AreaId:   a.world.AreaId,
+		EnemyId:  e.Id,
+		State:    state,
+		Position: e.Coordinates[0],
+	}
+	if brain.hasTarget {
+		event.TargetId = brain.target.Id
+		event.TargetKind = brain.target.Kind
+	}
+	a.publish(event)
+}
+
+func (a *AI) publish(event AIEvent) {
+	if a.notify != nil {
+		a.notify(event)
+	}
+}
+
+// speedOf возвращает скорость врага в клетках за тик, не меньше 1.
+func speedOf(e *models.Enemy) int {
+	speed := int(e.Charachteristics.Speed.IntPart())
+	if speed < 1 {
+		speed = 1
+	}
+	return speed
+}
+
+// rangeOf возвращает дальность атаки врага в клетках, не меньше 1.
+func rangeOf(e *models.Enemy) int {
+	r := int(e.Charachteristics.AtackRange.Ceil().IntPart())
+	if r < 1 {
+		r = 1
+	}
+	return r
+}
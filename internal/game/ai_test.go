@@ -0,0 +1,272 @@
+package game
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+var testBehaviours = BehaviourTable{
+	"default": {PatrolRadius: 3, PatrolChance: 0.5, LeashRadius: 8, AttackCooldown: 1},
+	"Statue":  {PatrolRadius: 0, LeashRadius: 8, AttackCooldown: 0},
+}
+
+// fakeBattleStore запоминает изменения арены, сохраненные ИИ и атаками
+type fakeBattleStore struct {
+	mu           sync.Mutex
+	savedEnemies map[int64]models.Enemy // последнее сохраненное состояние врагов
+	savedUnits   map[int64]models.Unit  // последнее сохраненное состояние юнитов
+	removed      []Target               // удаленные объекты игрока
+}
+
+func (s *fakeBattleStore) UpdateEnemy(e models.Enemy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.savedEnemies == nil {
+		s.savedEnemies = make(map[int64]models.Enemy)
+	}
+	s.savedEnemies[e.Id] = e
+	return nil
+}
+
+func (s *fakeBattleStore) UpdateHeroProgress(h models.Hero) error {
+	return nil
+}
+
+func (s *fakeBattleStore) UpdateUnitProgress(u models.Unit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.savedUnits == nil {
+		s.savedUnits = make(map[int64]models.Unit)
+	}
+	s.savedUnits[u.Id] = u
+	return nil
+}
+
+func (s *fakeBattleStore) UpdateBuilding(b models.Building) error {
+	return nil
+}
+
+func (s *fakeBattleStore) DeleteHero(heroId int64) error {
+	return s.remove(KindHero, heroId)
+}
+
+func (s *fakeBattleStore) DeleteUnit(unitId int64) error {
+	return s.remove(KindUnit, unitId)
+}
+
+func (s *fakeBattleStore) DeleteBuilding(buildingId int64) error {
+	return s.remove(KindBuilding, buildingId)
+}
+
+func (s *fakeBattleStore) remove(kind string, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removed = append(s.removed, Target{Id: id, Kind: kind})
+	return nil
+}
+
+func testEnemy(id int64, name string, at Hex) models.Enemy {
+	return models.Enemy{
+		Id:   id,
+		Name: name,
+		Charachteristics: models.EnemyCharacteristics{
+			HP:         100,
+			Speed:      decimal.NewFromInt(1),
+			Vision:     4,
+			AtackRange: decimal.NewFromInt(1),
+			Damage:     decimal.NewFromInt(30),
+		},
+		Coordinates: []models.Hex{models.Hex(at)},
+		Camp:        1,
+	}
+}
+
+// campEnemy переносит врага в лагерь camp, 0 - делает врагом волны
+func campEnemy(e models.Enemy, camp int) models.Enemy {
+	e.Camp = camp
+	return e
+}
+
+func testUnit(id int64, at Hex, hp int) models.Unit {
+	return models.Unit{
+		Id:               id,
+		Name:             "Miner",
+		Charachteristics: models.UnitCharacteristics{HP: hp, HPnow: hp, Armor: 5},
+		Coordinates:      []models.Hex{models.Hex(at)},
+	}
+}
+
+func newTestWorld() *MemoryWorld {
+	return NewMemoryWorld(models.Area{Id: 1, Width: 30, Height: 30})
+}
+
+func TestFindPath(t *testing.T) {
+	world := newTestWorld()
+	// Стена по q=5 от r=0 до r=8 - путь должен обойти ее снизу
+	var wall []models.Hex
+	for r := 0; r <= 8; r++ {
+		wall = append(wall, models.Hex{Q: 5, R: float64(r)})
+	}
+	world.AddBuilding(models.Building{Id: 1, Coordinates: wall})
+
+	start, goal := Hex{Q: 3, R: 3}, Hex{Q: 7, R: 3}
+	path := FindPath(start, goal, world.Blocked)
+	if assert.NotEmpty(t, path) {
+		assert.Equal(t, goal, path[len(path)-1])
+	}
+	prev := start
+	for _, h := range path {
+		assert.False(t, world.Blocked(h), "hex %v is blocked", h)
+		assert.Equal(t, 1, prev.Distance(h), "path must move to neighbour hexes")
+		prev = h
+	}
+	assert.Greater(t, len(path), start.Distance(goal))
+
+	assert.Empty(t, FindPath(Hex{Q: 3, R: 3}, Hex{Q: 3, R: 3}, world.Blocked))
+	assert.Nil(t, FindPath(start, Hex{Q: 5, R: 4}, world.Blocked))
+	assert.Nil(t, FindPath(start, Hex{Q: 40, R: 0}, world.Blocked))
+}
+
+func TestMemoryWorldFindPathTo(t *testing.T) {
+	world := newTestWorld()
+	world.AddUnit(testUnit(5, Hex{Q: 10, R: 10}, 100))
+	start, goal := Hex{Q: 2, R: 10}, Hex{Q: 10, R: 10}
+
+	// Проверяем, что путь к занятой клетке идет по свободным клеткам и заканчивается в ней
+	path := world.FindPathTo(start, goal)
+	if assert.Len(t, path, start.Distance(goal)) {
+		assert.Equal(t, goal, path[len(path)-1])
+		for _, h := range path[:len(path)-1] {
+			assert.False(t, world.Blocked(h))
+		}
+	}
+	assert.Equal(t, []Hex{goal}, world.FindPathTo(Hex{Q: 9, R: 10}, goal))
+
+	// Проверяем, что к свободной клетке путь тот же, что у FindPath
+	assert.Equal(t, world.FindPath(start, Hex{Q: 12, R: 3}), world.FindPathTo(start, Hex{Q: 12, R: 3}))
+}
+
+func TestAIIdleWithoutPlayers(t *testing.T) {
+	world := newTestWorld()
+	world.AddEnemy(testEnemy(1, "Statue", Hex{Q: 10, R: 10}))
+	ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(1)), nil)
+
+	for i := 0; i < 20; i++ {
+		ai.Tick()
+	}
+	assert.Equal(t, EnemyIdle, ai.State(1))
+	assert.Equal(t, models.Hex{Q: 10, R: 10}, world.Enemies[1].Coordinates[0])
+}
+
+func TestAIPatrolStaysNearHome(t *testing.T) {
+	world := newTestWorld()
+	world.AddEnemy(testEnemy(1, "Goblin", Hex{Q: 10, R: 10}))
+	ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(7)), nil)
+
+	home := Hex{Q: 10, R: 10}
+	moved := false
+	for i := 0; i < 50; i++ {
+		ai.Tick()
+		pos := Hex(world.Enemies[1].Coordinates[0])
+		assert.LessOrEqual(t, home.Distance(pos), 3)
+		if pos != home {
+			moved = true
+		}
+	}
+	assert.True(t, moved, "enemy should patrol around home")
+}
+
+func TestAIDeterministicWithSeed(t *testing.T) {
+	run := func() []models.Hex {
+		world := newTestWorld()
+		world.AddEnemy(testEnemy(1, "Goblin", Hex{Q: 10, R: 10}))
+		world.AddEnemy(testEnemy(2, "Goblin", Hex{Q: 20, R: 20}))
+		ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(42)), nil)
+		var trace []models.Hex
+		for i := 0; i < 30; i++ {
+			ai.Tick()
+			trace = append(trace, world.Enemies[1].Coordinates[0], world.Enemies[2].Coordinates[0])
+		}
+		return trace
+	}
+	assert.Equal(t, run(), run())
+}
+
+func TestAIAggroChaseAttackAndKill(t *testing.T) {
+	world := newTestWorld()
+	world.AddEnemy(testEnemy(1, "Statue", Hex{Q: 10, R: 10}))
+	world.AddUnit(testUnit(5, Hex{Q: 13, R: 10}, 50))
+
+	var events []AIEvent
+	store := &fakeBattleStore{}
+	ai := NewAI(world, testBehaviours, store, rand.New(rand.NewSource(1)), func(e AIEvent) { events = append(events, e) })
+
+	// Юнит в пределах видимости - враг начинает преследование, новая позиция врага сохраняется
+	ai.Tick()
+	assert.Equal(t, EnemyChase, ai.State(1))
+	assert.Equal(t, 2, Hex(world.Enemies[1].Coordinates[0]).Distance(Hex{Q: 13, R: 10}))
+	assert.Equal(t, world.Enemies[1].Coordinates, store.savedEnemies[1].Coordinates)
+
+	// Подходит на дистанцию атаки
+	ai.Tick()
+	assert.Equal(t, 1, Hex(world.Enemies[1].Coordinates[0]).Distance(Hex{Q: 13, R: 10}))
+
+	// Атакует: 30 урона - 5 брони = 25 за удар, два удара убивают юнита
+	// Здоровье раненого юнита сохраняется, погибший юнит удаляется из БД
+	ai.Tick()
+	assert.Equal(t, EnemyAttack, ai.State(1))
+	assert.Equal(t, 25, world.Units[5].Charachteristics.HPnow)
+	assert.Equal(t, 25, store.savedUnits[5].Charachteristics.HPnow)
+	ai.Tick()
+	assert.NotContains(t, world.Units, int64(5))
+	assert.Equal(t, EnemyIdle, ai.State(1))
+	assert.Equal(t, []Target{{Id: 5, Kind: KindUnit}}, store.removed)
+
+	var killed bool
+	for _, e := range events {
+		if e.Message == "unit 5 killed" {
+			killed = true
+			assert.Equal(t, int64(1), e.AreaId)
+			assert.Equal(t, KindUnit, e.TargetKind)
+		}
+	}
+	assert.True(t, killed, "kill event should be published")
+}
+
+func TestAILeashBackHome(t *testing.T) {
+	world := newTestWorld()
+	enemy := testEnemy(1, "Statue", Hex{Q: 5, R: 5})
+	enemy.Charachteristics.Vision = 20
+	world.AddEnemy(enemy)
+	// Юнит далеко за пределами радиуса поводка (8 клеток)
+	world.AddUnit(testUnit(5, Hex{Q: 25, R: 5}, 1000))
+	ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(1)), nil)
+
+	leashed := false
+	for i := 0; i < 12; i++ {
+		ai.Tick()
+		if ai.State(1) == EnemyLeash {
+			leashed = true
+			break
+		}
+	}
+	assert.True(t, leashed, "enemy should leash when too far from home")
+
+	for i := 0; i < 12 && ai.State(1) == EnemyLeash; i++ {
+		ai.Tick()
+	}
+	assert.Equal(t, models.Hex{Q: 5, R: 5}, world.Enemies[1].Coordinates[0])
+	assert.NotEqual(t, EnemyLeash, ai.State(1))
+	assert.Equal(t, 1000, world.Units[5].Charachteristics.HPnow)
+}
+
+func TestBehaviourTableFor(t *testing.T) {
+	assert.Equal(t, testBehaviours["Statue"], testBehaviours.For("Statue"))
+	assert.Equal(t, testBehaviours["default"], testBehaviours.For("Unknown"))
+}
@@ -0,0 +1,437 @@
+/*
+Арены в памяти сервера.
+Арена загружается из БД в MemoryWorld при запуске сервера или при первом обращении к ней,
+после чего на ней по тикам работают ИИ врагов и спавнер, возрождающий лагеря и отправляющий волны.
+События ИИ передаются в notify, откуда сервер рассылает их подписчикам арены.
+*/
+
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+var (
+	ErrAreasStopped = errors.New("areas are stopped")
+	ErrNotOnArea    = errors.New("object is not on area")
+	ErrOutOfRange   = errors.New("target is out of attack range")
+)
+
+// AreaStore - хранилище арен и их объектов. Реализуется *postgress.Storage.
+type AreaStore interface {
+	EnemyStore
+	BattleStore
+	DifficultyStore
+	GetAreas() ([]models.Area, error)
+	GetArea(areaId int64) (models.Area, error)
+	GetNeutrals(areaId int64) ([]models.Neutral, error)
+	GetBuildings(areaId int64) ([]models.Building, error)
+	GetHeroes(areaId int64) ([]models.Hero, error)
+	GetUnits(areaId int64) ([]models.Unit, error)
+	GetEnemies(areaId int64) ([]models.Enemy, error)
+}
+
+// AreasConfig - настройки арен в памяти.
+type AreasConfig struct {
+	Behaviours    BehaviourTable // Поведение врагов
+	AITick        time.Duration  // Интервал между тиками ИИ
+	Spawn         SpawnConfig    // Настройки появления врагов
+	SpawnTick     time.Duration  // Интервал между тиками спавнера
+	ClusterSize   int            // Сторона кластера иерархического поиска пути, 0 - поиск A*
+	MaxGroupUnits int            // Юнитов в одном групповом перемещении
+}
+
+// HARDCODE DefaultAreasConfig - настройки арен в памяти по умолчанию
+var DefaultAreasConfig = AreasConfig{
+	Behaviours:    DefaultBehaviours,
+	AITick:        time.Second,
+	Spawn:         DefaultSpawnConfig,
+	SpawnTick:     10 * time.Second,
+	ClusterSize:   DefaultClusterSize,
+	MaxGroupUnits: 20,
+}
+
+// LiveArea - арена в памяти вместе с управляющим ее врагами ИИ и спавнером.
+type LiveArea struct {
+	World   *MemoryWorld
+	AI      *AI
+	Spawner *Spawner
+
+	stop context.CancelFunc // останавливает тики арены
+}
+
+// Areas хранит загруженные в память арены и запускает на них ИИ врагов и спавнеры.
+type Areas struct {
+	store      AreaStore
+	config     AreasConfig
+	experience *Experience
+	notify     func(AIEvent)
+
+	ctx     context.Context // отменяется при остановке и завершает тики всех арен
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+
+	mu      sync.Mutex
+	areas   map[int64]*LiveArea
+	unloads map[int64]int // количество выгрузок арены, загрузка, начатая до выгрузки, повторяется
+	stopped bool
+}
+
+// Конструктор для Areas. experience начисляет опыт героям и юнитам арен, может быть nil,
+// тогда опыт не начисляется. notify получает события ИИ всех арен, может быть nil.
+func NewAreas(store AreaStore, config AreasConfig, experience *Experience, notify func(AIEvent)) *Areas {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Areas{
+		store:      store,
+		config:     config,
+		experience: experience,
+		notify:     notify,
+		ctx:        ctx,
+		cancel:     cancel,
+		areas:      make(map[int64]*LiveArea),
+		unloads:    make(map[int64]int),
+	}
+}
+
+// LoadAll загружает все арены из БД. Арена, которую не удалось загрузить,
+// пропускается и будет загружена при первом обращении к ней.
+func (a *Areas) LoadAll() error {
+	areas, err := a.store.GetAreas()
+	if err != nil {
+		return err
+	}
+	for _, area := range areas {
+		if _, err := a.Get(area.Id); err != nil {
+			log.Printf("Cant load area ID- %v: %v\n", area.Id, err)
+		}
+	}
+	return nil
+}
+
+// Get возвращает арену в памяти, при первом обращении загружая ее из БД.
+// Арена читается из БД без общей блокировки, чтобы загрузка одной арены не задерживала
+// обращения к остальным. Если арену одновременно загрузили несколько запросов,
+// все они получают ту, что была сохранена первой.
+func (a *Areas) Get(areaId int64) (*LiveArea, error) {
+	for {
+		a.mu.Lock()
+		if a.stopped {
+			a.mu.Unlock()
+			return nil, ErrAreasStopped
+		}
+		if live, ok := a.areas[areaId]; ok {
+			a.mu.Unlock()
+			return live, nil
+		}
+		unloads := a.unloads[areaId]
+		a.mu.Unlock()
+
+		world, difficulty, err := a.load(areaId)
+		if err != nil {
+			return nil, err
+		}
+
+		a.mu.Lock()
+		if a.stopped {
+			a.mu.Unlock()
+			return nil, ErrAreasStopped
+		}
+		if live, ok := a.areas[areaId]; ok {
+			a.mu.Unlock()
+			return live, nil
+		}
+		// Арену выгрузили во время загрузки, например после удаления - прочитанное устарело
+		if a.unloads[areaId] != unloads {
+			a.mu.Unlock()
+			continue
+		}
+		live := a.start(world, difficulty)
+		a.areas[areaId] = live
+		a.mu.Unlock()
+		return live, nil
+	}
+}
+
+// Unload останавливает тики арены и выгружает ее из памяти, например после удаления арены.
+// При следующем обращении арена будет снова загружена из БД.
+func (a *Areas) Unload(areaId int64) {
+	a.mu.Lock()
+	live, ok := a.areas[areaId]
+	delete(a.areas, areaId)
+	a.unloads[areaId]++
+	a.mu.Unlock()
+	if ok {
+		live.stop()
+	}
+}
+
+// Shutdown останавливает тики всех арен и ждет их завершения или отмены ctx.
+func (a *Areas) Shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	a.stopped = true
+	a.mu.Unlock()
+	a.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		a.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FindPath находит путь по загруженной арене от start до goal в обход ее объектов.
+func (a *Areas) FindPath(areaId int64, start, goal Hex) ([]Hex, error) {
+	live, err := a.Get(areaId)
+	if err != nil {
+		return nil, err
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	return live.World.Path(start, goal)
+}
+
+// FindRoute находит путь по загруженной арене от start до goal в обход ее объектов
+// и оценивает время его прохождения со скоростью speed клеток в секунду.
+func (a *Areas) FindRoute(areaId int64, start, goal Hex, speed float64) (Route, error) {
+	if speed <= 0 {
+		return Route{}, ErrNotValidSpeed
+	}
+	live, err := a.Get(areaId)
+	if err != nil {
+		return Route{}, err
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	return live.World.Route(start, goal, speed)
+}
+
+// GroupRoutes находит пути группы юнитов загруженной арены к клетке goal по одному полю потока.
+func (a *Areas) GroupRoutes(areaId int64, unitIds []int64, goal Hex) ([][]Hex, error) {
+	live, err := a.Get(areaId)
+	if err != nil {
+		return nil, err
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	return live.World.GroupRoutes(unitIds, goal)
+}
+
+// MaxGroupUnits возвращает максимальное количество юнитов в одном групповом перемещении.
+func (a *Areas) MaxGroupUnits() int {
+	return a.config.MaxGroupUnits
+}
+
+// UpdateBuilding заменяет уровень, характеристики и стоимость улучшения здания b
+// на загруженной арене. Не загруженная арена получит здание из БД при загрузке.
+func (a *Areas) UpdateBuilding(areaId int64, b models.Building) {
+	a.mu.Lock()
+	live, ok := a.areas[areaId]
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	if building, ok := live.World.Buildings[b.Id]; ok {
+		building.Level = b.Level
+		building.Charachteristics = b.Charachteristics
+		building.UpgradePrice = b.UpgradePrice
+	}
+}
+
+// Attack наносит врагу enemyId урон героя или юнита attackerId. ID героев и юнитов могут совпадать,
+// поэтому атакующий ищется по его типу attackerKind.
+// Здоровье раненого врага сохраняется в БД, убитый враг удаляется с арены и из БД, а атакующий получает опыт за убийство.
+// Возвращает true, если враг погиб.
+func (a *Areas) Attack(areaId int64, attackerKind models.ObjectKind, attackerId, enemyId int64) (bool, error) {
+	live, err := a.Get(areaId)
+	if err != nil {
+		return false, err
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	w := live.World
+
+	enemy, ok := w.Enemies[enemyId]
+	if !ok || len(enemy.Coordinates) == 0 {
+		return false, fmt.Errorf("%w: enemy %v", ErrNotOnArea, enemyId)
+	}
+	var coordinates []models.Hex
+	var damage, attackRange decimal.Decimal
+	var hero *models.Hero
+	var unit *models.Unit
+	switch attackerKind {
+	case models.HeroObject:
+		if hero = w.Heroes[attackerId]; hero != nil {
+			coordinates, damage, attackRange = hero.Coordinates, hero.Charachteristics.Damage, hero.Charachteristics.AtackRange
+		}
+	case models.UnitObject:
+		if unit = w.Units[attackerId]; unit != nil {
+			coordinates, damage, attackRange = unit.Coordinates, unit.Charachteristics.Damage, unit.Charachteristics.AtackRange
+		}
+	}
+	if len(coordinates) == 0 {
+		return false, fmt.Errorf("%w: %v %v", ErrNotOnArea, attackerKind, attackerId)
+	}
+	if Hex(coordinates[0]).Distance(Hex(enemy.Coordinates[0])) > int(attackRange.Ceil().IntPart()) {
+		return false, fmt.Errorf("%w: enemy %v", ErrOutOfRange, enemyId)
+	}
+
+	killed := *enemy
+	if !w.DamageEnemy(enemyId, damage) {
+		return false, a.store.UpdateEnemy(*enemy)
+	}
+	if err := a.store.DeleteEnemy(enemyId); err != nil {
+		return true, err
+	}
+	if a.experience == nil {
+		return true, nil
+	}
+	xp := a.experience.KillReward(killed)
+	if hero != nil {
+		return true, a.experience.AwardHero(areaId, hero, xp)
+	}
+	return true, a.experience.AwardUnit(areaId, unit, xp)
+}
+
+// Reward начисляет опыт юниту, завершившему действие a: за добычу ресурсов - по количеству
+// добытого (скорость добычи, а если она не задана - производительность юнита, умноженная
+// на длительность), за строительство - по времени строительства. Остальные действия опыт не дают.
+func (a *Areas) Reward(action models.Action) error {
+	if a.experience == nil || (action.ActionType != "harvest" && action.ActionType != "build") {
+		return nil
+	}
+	live, err := a.Get(action.AreaId)
+	if err != nil {
+		return err
+	}
+	live.World.Lock()
+	defer live.World.Unlock()
+	unit, ok := live.World.Units[action.ObjectSourceId]
+	if !ok {
+		return fmt.Errorf("%w: unit %v", ErrNotOnArea, action.ObjectSourceId)
+	}
+
+	var xp decimal.Decimal
+	switch action.ActionType {
+	case "harvest":
+		var c models.HarvestActionCharacteristics
+		if err := json.Unmarshal(action.Characteristics, &c); err != nil {
+			return fmt.Errorf("cant read harvest of action %v: %w", action.Id, err)
+		}
+		speed := c.Speed
+		if !speed.IsPositive() {
+			speed = decimal.NewFromInt(int64(unit.Charachteristics.ProductivityCoefficient))
+		}
+		xp = a.experience.HarvestReward(speed.Mul(decimal.NewFromFloat(action.Duration.Seconds())))
+	case "build":
+		var c models.BuildActionCharacteristics
+		if err := json.Unmarshal(action.Characteristics, &c); err != nil {
+			return fmt.Errorf("cant read build of action %v: %w", action.Id, err)
+		}
+		seconds := c.ConstructionTime
+		if seconds <= 0 {
+			seconds = int(action.Duration.Seconds())
+		}
+		xp = a.experience.ConstructionReward(seconds)
+	}
+	return a.experience.AwardUnit(action.AreaId, unit, xp)
+}
+
+// load читает арену и все ее объекты из БД и определяет сложность арены по ее владельцу.
+func (a *Areas) load(areaId int64) (*MemoryWorld, Difficulty, error) {
+	area, err := a.store.GetArea(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	difficulty, err := difficultyFor(a.store, area.UserId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	world := NewMemoryWorld(area)
+	neutrals, err := a.store.GetNeutrals(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	for _, n := range neutrals {
+		world.AddNeutral(n)
+	}
+	buildings, err := a.store.GetBuildings(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	for _, b := range buildings {
+		world.AddBuilding(b)
+	}
+	heroes, err := a.store.GetHeroes(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	for _, h := range heroes {
+		world.AddHero(h)
+	}
+	units, err := a.store.GetUnits(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	for _, u := range units {
+		world.AddUnit(u)
+	}
+	enemies, err := a.store.GetEnemies(areaId)
+	if err != nil {
+		return nil, Difficulty{}, err
+	}
+	for _, e := range enemies {
+		world.AddEnemy(e)
+	}
+	// Граф иерархического поиска строится по уже размещенным объектам
+	if a.config.ClusterSize > 0 {
+		world.EnableHPA(a.config.ClusterSize)
+	}
+	return world, difficulty, nil
+}
+
+// start запускает ИИ врагов и спавнер загруженной арены.
+// Лагеря спавнера и штурм оставшихся волн восстанавливаются по врагам, сохраненным в БД.
+func (a *Areas) start(world *MemoryWorld, difficulty Difficulty) *LiveArea {
+	ctx, stop := context.WithCancel(a.ctx)
+	seed := time.Now().UnixNano() + world.AreaId
+	ai := NewAI(world, a.config.Behaviours, a.store, rand.New(rand.NewSource(seed)), a.notify)
+	live := &LiveArea{
+		World:   world,
+		AI:      ai,
+		Spawner: NewSpawner(world, a.config.Spawn, difficulty, a.store, rand.New(rand.NewSource(seed+1)), ai),
+		stop:    stop,
+	}
+	if err := live.Spawner.RestoreCamps(); err != nil {
+		log.Printf("Cant restore camps of area ID- %v: %v\n", world.AreaId, err)
+	}
+
+	a.running.Add(2)
+	go func() {
+		defer a.running.Done()
+		live.AI.Run(ctx, a.config.AITick)
+	}()
+	go func() {
+		defer a.running.Done()
+		live.Spawner.Run(ctx, a.config.SpawnTick)
+	}()
+	return live
+}
@@ -0,0 +1,479 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+var errAreaNotFound = errors.New("area not found")
+
+// fakeAreaStore - арены и их объекты в памяти
+type fakeAreaStore struct {
+	fakeBattleStore
+	mu        sync.Mutex
+	nextId    int64
+	areas     []models.Area
+	buildings map[int64][]models.Building
+	heroes    map[int64][]models.Hero
+	units     map[int64][]models.Unit
+	enemies   map[int64][]models.Enemy
+	spawned   map[int64][]int64 // ID врагов, созданных спавнером, по аренам
+	deleted   []int64           // ID убитых врагов
+}
+
+func (s *fakeAreaStore) AddEnemy(e models.Enemy) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	return s.nextId, nil
+}
+
+func (s *fakeAreaStore) AddEnemyAtArea(enemyId, areaId int64, camp int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spawned[areaId] = append(s.spawned[areaId], enemyId)
+	return nil
+}
+
+func (s *fakeAreaStore) DeleteEnemy(enemyId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted = append(s.deleted, enemyId)
+	return nil
+}
+
+func (s *fakeAreaStore) spawnedAt(areaId int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.spawned[areaId])
+}
+
+func (s *fakeAreaStore) GetUser(userId int64) (models.User, error) {
+	return models.User{Id: userId, Level: 1}, nil
+}
+
+func (s *fakeAreaStore) GetLeague(leagueId int64) (models.League, error) {
+	return models.League{}, nil
+}
+
+func (s *fakeAreaStore) GetAreas() ([]models.Area, error) {
+	return s.areas, nil
+}
+
+func (s *fakeAreaStore) GetArea(areaId int64) (models.Area, error) {
+	for _, a := range s.areas {
+		if a.Id == areaId {
+			return a, nil
+		}
+	}
+	return models.Area{}, errAreaNotFound
+}
+
+func (s *fakeAreaStore) GetNeutrals(areaId int64) ([]models.Neutral, error) {
+	return nil, nil
+}
+
+func (s *fakeAreaStore) GetBuildings(areaId int64) ([]models.Building, error) {
+	return s.buildings[areaId], nil
+}
+
+func (s *fakeAreaStore) GetHeroes(areaId int64) ([]models.Hero, error) {
+	return s.heroes[areaId], nil
+}
+
+func (s *fakeAreaStore) GetUnits(areaId int64) ([]models.Unit, error) {
+	return s.units[areaId], nil
+}
+
+func (s *fakeAreaStore) GetEnemies(areaId int64) ([]models.Enemy, error) {
+	return s.enemies[areaId], nil
+}
+
+func newFakeAreaStore() *fakeAreaStore {
+	return &fakeAreaStore{
+		nextId: 100,
+		areas:  []models.Area{{Id: 1, UserId: 1, Width: 30, Height: 30}, {Id: 2, UserId: 1, Width: 30, Height: 30}},
+		// На арене 2 стоит здание, на которое спавнер отправляет волны
+		buildings: map[int64][]models.Building{2: {{
+			Id:               3,
+			Name:             "CyMan miner house",
+			Charachteristics: models.BuildingCharacteristics{HP: 100},
+			Coordinates:      []models.Hex{{Q: 15, R: 15}},
+		}}},
+		// На арене 1 враг видит юнита и начинает преследование на первом тике.
+		// Враг 9 остался от волны, а зданий на арене 1 нет
+		units: map[int64][]models.Unit{1: {testUnit(5, Hex{Q: 10, R: 10}, 100)}},
+		enemies: map[int64][]models.Enemy{1: {
+			testEnemy(7, "Statue", Hex{Q: 13, R: 10}),
+			campEnemy(testEnemy(8, "Statue", Hex{Q: 25, R: 25}), 2),
+			campEnemy(testEnemy(9, "Statue", Hex{Q: 13, R: 20}), 0),
+		}},
+		spawned: make(map[int64][]int64),
+	}
+}
+
+func testAreasConfig() AreasConfig {
+	spawn := DefaultSpawnConfig
+	spawn.WaveInterval = time.Millisecond
+	spawn.RespawnCooldown = time.Hour
+	return AreasConfig{Behaviours: testBehaviours, AITick: time.Millisecond, Spawn: spawn, SpawnTick: time.Millisecond}
+}
+
+func TestAreasLoad(t *testing.T) {
+	store := newFakeAreaStore()
+	events := make(chan AIEvent, 16)
+	areas := NewAreas(store, testAreasConfig(), nil, func(e AIEvent) {
+		select {
+		case events <- e:
+		default:
+		}
+	})
+	assert.NoError(t, areas.LoadAll())
+
+	// Проверяем, что объекты арены загружены из БД, а повторное обращение не загружает арену заново
+	live, err := areas.Get(1)
+	assert.NoError(t, err)
+	live.World.Lock()
+	assert.Contains(t, live.World.Units, int64(5))
+	assert.True(t, live.World.Blocked(Hex{Q: 10, R: 10}))
+	live.World.Unlock()
+	again, err := areas.Get(1)
+	assert.NoError(t, err)
+	assert.Same(t, live, again)
+
+	// Проверяем, что ИИ загруженной арены работает и отправляет события
+	select {
+	case e := <-events:
+		assert.Equal(t, int64(1), e.AreaId)
+		assert.Equal(t, int64(7), e.EnemyId)
+	case <-time.After(5 * time.Second):
+		t.Fatal("AI of loaded area does not run")
+	}
+
+	// Проверяем, что лагеря восстановлены по номерам лагерей врагов,
+	// а враг волны без зданий для штурма удален с арены и из БД
+	live.World.Lock()
+	camps := live.Spawner.Camps()
+	_, waveLeft := live.World.Enemies[9]
+	live.World.Unlock()
+	if assert.Len(t, camps, 2) {
+		assert.Equal(t, []int64{7}, camps[0].Enemies)
+		assert.Equal(t, []int64{8}, camps[1].Enemies)
+	}
+	assert.False(t, waveLeft)
+	store.mu.Lock()
+	assert.Equal(t, []int64{9}, store.deleted)
+	store.mu.Unlock()
+
+	// Проверяем, что спавнер арены со зданием работает и отправляет волны
+	_, err = areas.Get(2)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return store.spawnedAt(2) > 0 }, 5*time.Second, time.Millisecond)
+
+	// Проверяем, что выгруженная арена загружается заново, а неизвестная не загружается
+	areas.Unload(1)
+	reloaded, err := areas.Get(1)
+	assert.NoError(t, err)
+	assert.NotSame(t, live, reloaded)
+	_, err = areas.Get(9)
+	assert.ErrorIs(t, err, errAreaNotFound)
+
+	// Проверяем, что после остановки тики завершены и арены больше не загружаются
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, areas.Shutdown(ctx))
+	_, err = areas.Get(2)
+	assert.ErrorIs(t, err, ErrAreasStopped)
+}
+
+// slowAreaStore задерживает чтение арены 1, пока не будет закрыт release
+type slowAreaStore struct {
+	*fakeAreaStore
+	started chan struct{}
+	release chan struct{}
+	reads   chan int64
+}
+
+func (s *slowAreaStore) GetArea(areaId int64) (models.Area, error) {
+	s.reads <- areaId
+	if areaId == 1 {
+		s.started <- struct{}{}
+		<-s.release
+	}
+	return s.fakeAreaStore.GetArea(areaId)
+}
+
+func TestAreasGetLoadsOutsideLock(t *testing.T) {
+	store := &slowAreaStore{
+		fakeAreaStore: newFakeAreaStore(),
+		started:       make(chan struct{}, 2),
+		release:       make(chan struct{}),
+		reads:         make(chan int64, 16),
+	}
+	config := testAreasConfig()
+	config.AITick, config.SpawnTick = time.Hour, time.Hour
+	areas := NewAreas(store, config, nil, nil)
+	defer areas.Shutdown(context.Background())
+
+	loaded := make(chan *LiveArea, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			live, err := areas.Get(1)
+			assert.NoError(t, err)
+			loaded <- live
+		}()
+	}
+	<-store.started
+	<-store.started
+
+	// Проверяем, что пока арена 1 читается из БД, другие арены доступны
+	done := make(chan error, 1)
+	go func() {
+		_, err := areas.Get(2)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("area 2 is blocked by loading of area 1")
+	}
+
+	// Проверяем, что одновременные загрузки арены возвращают одну и ту же арену
+	close(store.release)
+	first, second := <-loaded, <-loaded
+	assert.Same(t, first, second)
+}
+
+func TestAreasGetReloadsAfterUnload(t *testing.T) {
+	store := &slowAreaStore{
+		fakeAreaStore: newFakeAreaStore(),
+		started:       make(chan struct{}, 2),
+		release:       make(chan struct{}),
+		reads:         make(chan int64, 16),
+	}
+	config := testAreasConfig()
+	config.AITick, config.SpawnTick = time.Hour, time.Hour
+	areas := NewAreas(store, config, nil, nil)
+	defer areas.Shutdown(context.Background())
+
+	loaded := make(chan *LiveArea, 1)
+	go func() {
+		live, err := areas.Get(1)
+		assert.NoError(t, err)
+		loaded <- live
+	}()
+	<-store.started
+
+	// Проверяем, что арена, выгруженная во время загрузки, читается из БД заново
+	areas.Unload(1)
+	close(store.release)
+	live := <-loaded
+	assert.NotNil(t, live)
+	assert.Equal(t, []int64{1, 1}, []int64{<-store.reads, <-store.reads})
+	again, err := areas.Get(1)
+	assert.NoError(t, err)
+	assert.Same(t, live, again)
+}
+
+func TestAreasGroupRoutes(t *testing.T) {
+	areas := NewAreas(newFakeAreaStore(), testAreasConfig(), nil, nil)
+	defer areas.Shutdown(context.Background())
+
+	// Проверяем, что пути группы ищутся по юнитам загруженной арены
+	paths, err := areas.GroupRoutes(1, []int64{5}, Hex{Q: 10, R: 13})
+	if assert.NoError(t, err) && assert.Len(t, paths, 1) {
+		assert.Equal(t, Hex{Q: 10, R: 13}, paths[0][len(paths[0])-1])
+	}
+	_, err = areas.GroupRoutes(1, []int64{5, 6}, Hex{Q: 10, R: 13})
+	assert.ErrorIs(t, err, ErrNotOnArea)
+	_, err = areas.GroupRoutes(9, []int64{5}, Hex{Q: 10, R: 13})
+	assert.ErrorIs(t, err, errAreaNotFound)
+}
+
+func TestAreasFindRoute(t *testing.T) {
+	config := testAreasConfig()
+	config.Spawn.WaveInterval = 0
+	config.ClusterSize = 10
+	areas := NewAreas(newFakeAreaStore(), config, nil, nil)
+	defer areas.Shutdown(context.Background())
+
+	// Проверяем, что на загруженной арене включен иерархический поиск пути
+	live, err := areas.Get(2)
+	assert.NoError(t, err)
+	assert.NotNil(t, live.World.paths)
+
+	tests := []struct {
+		name          string
+		areaId        int64
+		goal          Hex
+		speed         float64
+		expectedError error
+	}{
+		{"Path through several clusters", 2, Hex{Q: 29, R: 29}, 1, nil},
+		{"Goal is occupied by building", 2, Hex{Q: 15, R: 15}, 1, ErrNoPath},
+		{"Goal is out of area", 2, Hex{Q: 30, R: 0}, 1, ErrOutOfArea},
+		{"Zero speed", 2, Hex{Q: 29, R: 29}, 0, ErrNotValidSpeed},
+		{"Unknown area", 9, Hex{Q: 29, R: 29}, 1, errAreaNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что путь ищется по загруженной арене и ведет в обход ее объектов
+			route, err := areas.FindRoute(tt.areaId, Hex{}, tt.goal, tt.speed)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+			assert.Equal(t, tt.goal, route.Path[len(route.Path)-1])
+			assert.NotContains(t, route.Path, Hex{Q: 15, R: 15})
+			assert.Equal(t, time.Duration(len(route.Path))*time.Second, route.TravelTime)
+
+			path, err := areas.FindPath(tt.areaId, Hex{}, tt.goal)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.goal, path[len(path)-1])
+		})
+	}
+}
+
+func TestAreasUpdateBuilding(t *testing.T) {
+	config := testAreasConfig()
+	config.Spawn.WaveInterval = 0
+	areas := NewAreas(newFakeAreaStore(), config, nil, nil)
+	defer areas.Shutdown(context.Background())
+
+	live, err := areas.Get(2)
+	assert.NoError(t, err)
+
+	// Проверяем, что здание загруженной арены получает новые уровень, характеристики и стоимость
+	upgraded := models.Building{
+		Id:               3,
+		Level:            2,
+		Charachteristics: models.BuildingCharacteristics{HP: 200, Armor: 5},
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(10)}},
+	}
+	areas.UpdateBuilding(2, upgraded)
+	live.World.Lock()
+	building := *live.World.Buildings[3]
+	live.World.Unlock()
+	assert.Equal(t, 2, building.Level)
+	assert.Equal(t, upgraded.Charachteristics, building.Charachteristics)
+	assert.Equal(t, upgraded.UpgradePrice, building.UpgradePrice)
+	assert.Equal(t, "CyMan miner house", building.Name)
+	assert.Equal(t, []models.Hex{{Q: 15, R: 15}}, building.Coordinates)
+
+	// Проверяем, что не загруженная арена не загружается ради здания
+	areas.UpdateBuilding(1, upgraded)
+	areas.mu.Lock()
+	assert.NotContains(t, areas.areas, int64(1))
+	areas.mu.Unlock()
+}
+
+func TestAreasExperience(t *testing.T) {
+	store := newFakeAreaStore()
+	store.areas = append(store.areas, models.Area{Id: 3, UserId: 1, Width: 30, Height: 30})
+	store.heroes = map[int64][]models.Hero{3: {{
+		Id:               4,
+		Level:            1,
+		ExperienceToUp:   decimal.NewFromInt(100),
+		Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(200)},
+		Coordinates:      []models.Hex{{Q: 5, R: 5}},
+	}}}
+	unit := testUnit(5, Hex{Q: 9, R: 9}, 80)
+	unit.Charachteristics.ProductivityCoefficient = 3
+	store.units[3] = []models.Unit{unit}
+	statue := testEnemy(9, "Statue", Hex{Q: 6, R: 5})
+	statue.Charachteristics.Experience = decimal.NewFromInt(150)
+	guard := testEnemy(11, "Statue", Hex{Q: 4, R: 5})
+	guard.Charachteristics.HP = 1000
+	store.enemies[3] = []models.Enemy{statue, testEnemy(10, "Statue", Hex{Q: 20, R: 20}), guard}
+
+	progress := &fakeProgressStore{}
+	var levelUps []LevelUpEvent
+	experience := NewExperience(testExperienceConfig, progress, func(e LevelUpEvent) { levelUps = append(levelUps, e) })
+	// Тики ИИ и спавнера не успевают сработать во время теста
+	config := testAreasConfig()
+	config.AITick, config.SpawnTick = time.Hour, time.Hour
+	areas := NewAreas(store, config, experience, nil)
+	defer areas.Shutdown(context.Background())
+
+	attacks := []struct {
+		name           string
+		attackerKind   models.ObjectKind
+		attackerId     int64
+		enemyId        int64
+		expectedKilled bool
+		expectedError  error
+	}{
+		{"Unknown enemy", models.HeroObject, 4, 99, false, ErrNotOnArea},
+		{"Unknown attacker", models.HeroObject, 99, 9, false, ErrNotOnArea},
+		{"Hero ID given as unit", models.UnitObject, 4, 9, false, ErrNotOnArea},
+		{"Enemy out of attack range", models.UnitObject, 5, 9, false, ErrOutOfRange},
+		{"Hero wounds enemy", models.HeroObject, 4, 11, false, nil},
+		{"Hero kills enemy", models.HeroObject, 4, 9, true, nil},
+	}
+	for _, tt := range attacks {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что атакующий должен стоять рядом с врагом на той же арене
+			killed, err := areas.Attack(3, tt.attackerKind, tt.attackerId, tt.enemyId)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedKilled, killed)
+		})
+	}
+
+	// Проверяем, что убитый враг удален, а герой получил опыт за убийство и новый уровень
+	live, err := areas.Get(3)
+	assert.NoError(t, err)
+	live.World.Lock()
+	assert.NotContains(t, live.World.Enemies, int64(9))
+	assert.False(t, live.World.Blocked(Hex{Q: 6, R: 5}))
+	hero := *live.World.Heroes[4]
+	live.World.Unlock()
+	assert.Equal(t, []int64{9}, store.deleted)
+	// Проверяем, что здоровье раненого врага сохранено
+	assert.Equal(t, 800, store.savedEnemies[11].Charachteristics.HP)
+	assert.Equal(t, 2, hero.Level)
+	assert.True(t, decimal.NewFromInt(50).Equal(hero.Experience), "experience: %v", hero.Experience)
+	if assert.Len(t, levelUps, 1) {
+		assert.Equal(t, int64(4), levelUps[0].ObjectId)
+		assert.Equal(t, int64(3), levelUps[0].AreaId)
+	}
+
+	rewards := []struct {
+		name       string
+		action     models.Action
+		expectedXP decimal.Decimal
+	}{
+		{"Harvest with speed", models.Action{AreaId: 3, ObjectSourceId: 5, ActionType: "harvest",
+			Characteristics: []byte(`{"harvester": 5, "speed": 2}`), Duration: 10 * time.Second}, decimal.NewFromInt(2)},
+		{"Harvest with unit productivity", models.Action{AreaId: 3, ObjectSourceId: 5, ActionType: "harvest",
+			Characteristics: []byte(`{"harvester": 5}`), Duration: 10 * time.Second}, decimal.NewFromInt(3)},
+		{"Build", models.Action{AreaId: 3, ObjectSourceId: 5, ActionType: "build",
+			Characteristics: []byte(`{"builder": 5, "construction_time": 60}`), Duration: time.Minute}, decimal.NewFromInt(30)},
+		{"Move gives no experience", models.Action{AreaId: 3, ObjectSourceId: 5, ActionType: "move",
+			Characteristics: []byte(`{}`), Duration: time.Minute}, decimal.Zero},
+	}
+	for _, tt := range rewards {
+		t.Run(tt.name, func(t *testing.T) {
+			live.World.Lock()
+			before := live.World.Units[5].Experience
+			live.World.Unlock()
+
+			// Проверяем, что юнит получает опыт за завершенные добычу и строительство
+			assert.NoError(t, areas.Reward(tt.action))
+			live.World.Lock()
+			got := live.World.Units[5].Experience.Sub(before)
+			live.World.Unlock()
+			assert.True(t, tt.expectedXP.Equal(got), "experience: expected %v, got %v", tt.expectedXP, got)
+		})
+	}
+	assert.ErrorIs(t, areas.Reward(models.Action{AreaId: 3, ObjectSourceId: 4, ActionType: "build", Characteristics: []byte(`{}`)}), ErrNotOnArea)
+}
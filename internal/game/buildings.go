@@ -0,0 +1,86 @@
+/*
+Каталог зданий.
+Каталог перечисляет типы зданий, которые существуют в игре: их производимый ресурс,
+характеристики на 1 уровне и стоимость первого улучшения. По каталогу проверяется
+название здания в действии build и создается здание нового мира.
+*/
+
+package game
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+// BuildingType описывает тип здания.
+type BuildingType struct {
+	Product          string                         // Производимый зданием ресурс
+	Charachteristics models.BuildingCharacteristics // Характеристики здания 1 уровня
+	UpgradePrice     models.ResourcePrice           // Стоимость улучшения с 1 уровня
+}
+
+// BuildingCatalog - типы зданий по названию.
+type BuildingCatalog map[string]BuildingType
+
+// HARDCODE DefaultBuildingCatalog - здания из schema.sql и здание, создаваемое в новом мире
+var DefaultBuildingCatalog = BuildingCatalog{
+	"CyMan miner house": {
+		Product:          "Gold",
+		Charachteristics: models.BuildingCharacteristics{HP: 500, Armor: 10, ProductivityCoefficient: 3, Size: 2},
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(1000)}},
+	},
+	"Town Hall": {
+		Product:          "Gold",
+		Charachteristics: models.BuildingCharacteristics{HP: 1000, Armor: 50, ProductivityCoefficient: 1, Size: 10},
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(500)}},
+	},
+	"Barracks": {
+		Product:          "Units",
+		Charachteristics: models.BuildingCharacteristics{HP: 800, Armor: 30, ProductivityCoefficient: 1, Size: 8},
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(300)}},
+	},
+	"Farm": {
+		Product:          "Food",
+		Charachteristics: models.BuildingCharacteristics{HP: 600, Armor: 20, ProductivityCoefficient: 1, Size: 6},
+		UpgradePrice: models.ResourcePrice{
+			{Name: "Gold", Value: decimal.NewFromInt(150)},
+			{Name: "Wood", Value: decimal.NewFromInt(50)},
+		},
+	},
+	"Large Castle": {
+		Product:          "Gold",
+		Charachteristics: models.BuildingCharacteristics{HP: 2000, Armor: 100, ProductivityCoefficient: 1, Size: 4},
+		UpgradePrice: models.ResourcePrice{
+			{Name: "Gold", Value: decimal.NewFromInt(700)},
+			{Name: "Stone", Value: decimal.NewFromInt(300)},
+		},
+	},
+}
+
+// Names возвращает названия зданий каталога по алфавиту.
+func (c BuildingCatalog) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New создает здание 1 уровня типа name. Место на арене не задается.
+func (c BuildingCatalog) New(name string) (models.Building, bool) {
+	t, ok := c[name]
+	if !ok {
+		return models.Building{}, false
+	}
+	return models.Building{
+		Name:             name,
+		Product:          t.Product,
+		Level:            1,
+		UpgradePrice:     append(models.ResourcePrice(nil), t.UpgradePrice...),
+		Charachteristics: t.Charachteristics,
+	}, true
+}
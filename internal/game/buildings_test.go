@@ -0,0 +1,44 @@
+package game
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildingCatalogCoversSchema(t *testing.T) {
+	schema, err := os.ReadFile("../../schema.sql")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// Названия зданий из наполнения таблицы buildings
+	section := string(schema)[strings.Index(string(schema), "INSERT INTO buildings"):]
+	section = section[:strings.Index(section, ";")]
+	names := regexp.MustCompile(`(?m)^\('([^']+)'`).FindAllStringSubmatch(section, -1)
+	assert.NotEmpty(t, names)
+
+	// Проверяем, что в каталоге есть все здания из схемы
+	for _, name := range names {
+		_, ok := DefaultBuildingCatalog[name[1]]
+		assert.True(t, ok, "building %q is not in catalog", name[1])
+	}
+}
+
+func TestBuildingCatalogNew(t *testing.T) {
+	building, ok := DefaultBuildingCatalog.New("Farm")
+	assert.True(t, ok)
+	assert.Equal(t, "Farm", building.Name)
+	assert.Equal(t, 1, building.Level)
+	assert.Equal(t, 600, building.Charachteristics.HP)
+
+	// Проверяем, что цена улучшения здания не связана с каталогом
+	building.UpgradePrice[0].Value = decimal.NewFromInt(1)
+	assert.True(t, decimal.NewFromInt(150).Equal(DefaultBuildingCatalog["Farm"].UpgradePrice[0].Value))
+
+	_, ok = DefaultBuildingCatalog.New("Castle")
+	assert.False(t, ok)
+}
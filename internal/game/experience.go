@@ -0,0 +1,255 @@
+/*
+Система опыта героев и юнитов.
+Опыт начисляется за убийство врагов, добычу ресурсов и строительство.
+Когда текущий опыт достигает ExperienceToUp объект получает новый уровень,
+а его характеристики растут согласно таблице роста характеристик.
+*/
+
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+// LevelCurve описывает кривую уровней - сколько опыта нужно для перехода на следующий уровень.
+type LevelCurve struct {
+	Base     decimal.Decimal // Опыт, необходимый для перехода с 1 на 2 уровень
+	Factor   decimal.Decimal // Множитель, на который растет требование с каждым уровнем
+	MaxLevel int             // Максимальный уровень (0 - без ограничений)
+}
+
+// ExperienceToUp возвращает количество опыта, необходимое для перехода с уровня level на следующий.
+func (c LevelCurve) ExperienceToUp(level int) decimal.Decimal {
+	if level < 1 {
+		level = 1
+	}
+	return c.Base.Mul(c.Factor.Pow(decimal.NewFromInt(int64(level - 1)))).Round(2)
+}
+
+// isMax проверяет, достигнут ли максимальный уровень кривой.
+func (c LevelCurve) isMax(level int) bool {
+	return c.MaxLevel > 0 && level >= c.MaxLevel
+}
+
+// StatGrowth описывает прирост характеристик при получении уровня.
+type StatGrowth struct {
+	HP     int             // Прирост здоровья
+	Damage decimal.Decimal // Прирост урона
+	Armor  int             // Прирост брони
+}
+
+// GrowthTable - таблица прироста характеристик. Индекс 0 соответствует переходу на 2 уровень,
+// для уровней за пределами таблицы используется последняя запись.
+type GrowthTable []StatGrowth
+
+// For возвращает прирост характеристик при переходе на уровень level.
+func (t GrowthTable) For(level int) StatGrowth {
+	if len(t) == 0 {
+		return StatGrowth{}
+	}
+	i := level - 2
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(t) {
+		i = len(t) - 1
+	}
+	return t[i]
+}
+
+// ExperienceConfig содержит настройки системы опыта.
+type ExperienceConfig struct {
+	HeroCurve        LevelCurve      // Кривая уровней героев
+	UnitCurve        LevelCurve      // Кривая уровней юнитов
+	HeroGrowth       GrowthTable     // Прирост характеристик героев
+	UnitGrowth       GrowthTable     // Прирост характеристик юнитов
+	HarvestRate      decimal.Decimal // Опыт за единицу добытого ресурса
+	ConstructionRate decimal.Decimal // Опыт за секунду строительства
+}
+
+// HARDCODE DefaultExperienceConfig - настройки опыта по умолчанию
+var DefaultExperienceConfig = ExperienceConfig{
+	HeroCurve: LevelCurve{Base: decimal.NewFromInt(200), Factor: decimal.NewFromFloat(1.5), MaxLevel: 30},
+	UnitCurve: LevelCurve{Base: decimal.NewFromInt(100), Factor: decimal.NewFromFloat(1.4), MaxLevel: 20},
+	HeroGrowth: GrowthTable{
+		{HP: 20, Damage: decimal.NewFromInt(4), Armor: 2},
+		{HP: 25, Damage: decimal.NewFromInt(5), Armor: 2},
+		{HP: 30, Damage: decimal.NewFromInt(6), Armor: 3},
+	},
+	UnitGrowth: GrowthTable{
+		{HP: 10, Damage: decimal.NewFromInt(2), Armor: 1},
+		{HP: 15, Damage: decimal.NewFromInt(3), Armor: 1},
+	},
+	HarvestRate:      decimal.NewFromFloat(0.1),
+	ConstructionRate: decimal.NewFromFloat(0.5),
+}
+
+// LevelUpEvent - событие повышения уровня, отправляемое клиенту.
+type LevelUpEvent struct {
+	Type           string          `json:"type"`             // Тип события - level_up
+	AreaId         int64           `json:"area_id"`          // Идентификатор арены
+	ObjectId       int64           `json:"object_id"`        // Идентификатор героя или юнита
+	ObjectType     string          `json:"object_type"`      // hero или unit
+	Level          int             `json:"level"`            // Новый уровень
+	Experience     decimal.Decimal `json:"experience"`       // Текущий опыт
+	ExperienceToUp decimal.Decimal `json:"experience_to_up"` // Опыт до следующего уровня
+	HP             int             `json:"hp"`               // Здоровье после повышения уровня
+	Armor          int             `json:"armor"`            // Броня после повышения уровня
+	Damage         decimal.Decimal `json:"damage"`           // Урон после повышения уровня
+}
+
+// ProgressStore сохраняет прогресс героев и юнитов. Реализуется *postgress.Storage.
+type ProgressStore interface {
+	UpdateHeroProgress(h models.Hero) error
+	UpdateUnitProgress(u models.Unit) error
+}
+
+// Experience начисляет опыт, повышает уровни, сохраняет результат и оповещает клиента.
+type Experience struct {
+	config ExperienceConfig
+	store  ProgressStore
+	notify func(LevelUpEvent)
+}
+
+// Конструктор для Experience. notify может быть nil, тогда события не отправляются.
+func NewExperience(config ExperienceConfig, store ProgressStore, notify func(LevelUpEvent)) *Experience {
+	return &Experience{
+		config: config,
+		store:  store,
+		notify: notify,
+	}
+}
+
+// KillReward возвращает опыт за убийство врага.
+func (e *Experience) KillReward(enemy models.Enemy) decimal.Decimal {
+	return enemy.Charachteristics.Experience
+}
+
+// HarvestReward возвращает опыт за добычу amount единиц ресурса.
+func (e *Experience) HarvestReward(amount decimal.Decimal) decimal.Decimal {
+	return amount.Mul(e.config.HarvestRate)
+}
+
+// ConstructionReward возвращает опыт за строительство длительностью seconds секунд.
+func (e *Experience) ConstructionReward(seconds int) decimal.Decimal {
+	return decimal.NewFromInt(int64(seconds)).Mul(e.config.ConstructionRate)
+}
+
+// AwardHero начисляет герою опыт, при необходимости повышает уровень и сохраняет результат.
+func (e *Experience) AwardHero(areaId int64, h *models.Hero, xp decimal.Decimal) error {
+	if !xp.IsPositive() {
+		return nil
+	}
+	levels := applyHeroExperience(e.config, h, xp)
+	if err := e.store.UpdateHeroProgress(*h); err != nil {
+		return fmt.Errorf("cant save hero %v progress: %w", h.Id, err)
+	}
+	if levels > 0 {
+		log.Printf("Hero ID- %v reached level %v\n", h.Id, h.Level)
+		e.publish(LevelUpEvent{
+			Type:           "level_up",
+			AreaId:         areaId,
+			ObjectId:       h.Id,
+			ObjectType:     "hero",
+			Level:          h.Level,
+			Experience:     h.Experience,
+			ExperienceToUp: h.ExperienceToUp,
+			HP:             h.Charachteristics.HP,
+			Armor:          h.Charachteristics.Armor,
+			Damage:         h.Charachteristics.Damage,
+		})
+	}
+	return nil
+}
+
+// AwardUnit начисляет юниту опыт, при необходимости повышает уровень и сохраняет результат.
+func (e *Experience) AwardUnit(areaId int64, u *models.Unit, xp decimal.Decimal) error {
+	if !xp.IsPositive() {
+		return nil
+	}
+	levels := applyUnitExperience(e.config, u, xp)
+	if err := e.store.UpdateUnitProgress(*u); err != nil {
+		return fmt.Errorf("cant save unit %v progress: %w", u.Id, err)
+	}
+	if levels > 0 {
+		log.Printf("Unit ID- %v reached level %v\n", u.Id, u.Level)
+		e.publish(LevelUpEvent{
+			Type:           "level_up",
+			AreaId:         areaId,
+			ObjectId:       u.Id,
+			ObjectType:     "unit",
+			Level:          u.Level,
+			Experience:     u.Experience,
+			ExperienceToUp: u.ExperienceToUp,
+			HP:             u.Charachteristics.HP,
+			Armor:          u.Charachteristics.Armor,
+			Damage:         u.Charachteristics.Damage,
+		})
+	}
+	return nil
+}
+
+func (e *Experience) publish(event LevelUpEvent) {
+	if e.notify != nil {
+		e.notify(event)
+	}
+}
+
+// applyHeroExperience добавляет опыт герою и повышает уровень пока опыта достаточно.
+// Излишек опыта переносится на следующий уровень. Возвращает количество полученных уровней.
+func applyHeroExperience(cfg ExperienceConfig, h *models.Hero, xp decimal.Decimal) int {
+	if h.Level < 1 {
+		h.Level = 1
+	}
+	if h.ExperienceToUp.IsZero() {
+		h.ExperienceToUp = cfg.HeroCurve.ExperienceToUp(h.Level)
+	}
+	h.Experience = h.Experience.Add(xp)
+
+	levels := 0
+	for !cfg.HeroCurve.isMax(h.Level) && h.Experience.GreaterThanOrEqual(h.ExperienceToUp) {
+		h.Experience = h.Experience.Sub(h.ExperienceToUp)
+		h.Level++
+		levels++
+
+		growth := cfg.HeroGrowth.For(h.Level)
+		h.Charachteristics.HP += growth.HP
+		h.Charachteristics.HPnow += growth.HP
+		h.Charachteristics.Armor += growth.Armor
+		h.Charachteristics.Damage = h.Charachteristics.Damage.Add(growth.Damage)
+		h.ExperienceToUp = cfg.HeroCurve.ExperienceToUp(h.Level)
+	}
+	return levels
+}
+
+// applyUnitExperience добавляет опыт юниту и повышает уровень пока опыта достаточно.
+// Излишек опыта переносится на следующий уровень. Возвращает количество полученных уровней.
+func applyUnitExperience(cfg ExperienceConfig, u *models.Unit, xp decimal.Decimal) int {
+	if u.Level < 1 {
+		u.Level = 1
+	}
+	if u.ExperienceToUp.IsZero() {
+		u.ExperienceToUp = cfg.UnitCurve.ExperienceToUp(u.Level)
+	}
+	u.Experience = u.Experience.Add(xp)
+
+	levels := 0
+	for !cfg.UnitCurve.isMax(u.Level) && u.Experience.GreaterThanOrEqual(u.ExperienceToUp) {
+		u.Experience = u.Experience.Sub(u.ExperienceToUp)
+		u.Level++
+		levels++
+
+		growth := cfg.UnitGrowth.For(u.Level)
+		u.Charachteristics.HP += growth.HP
+		u.Charachteristics.HPnow += growth.HP
+		u.Charachteristics.Armor += growth.Armor
+		u.Charachteristics.Damage = u.Charachteristics.Damage.Add(growth.Damage)
+		u.ExperienceToUp = cfg.UnitCurve.ExperienceToUp(u.Level)
+	}
+	return levels
+}
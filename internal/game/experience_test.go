@@ -0,0 +1,211 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// fakeProgressStore запоминает сохраненные объекты вместо записи в БД
+type fakeProgressStore struct {
+	heroes []models.Hero
+	units  []models.Unit
+	err    error
+}
+
+func (s *fakeProgressStore) UpdateHeroProgress(h models.Hero) error {
+	s.heroes = append(s.heroes, h)
+	return s.err
+}
+
+func (s *fakeProgressStore) UpdateUnitProgress(u models.Unit) error {
+	s.units = append(s.units, u)
+	return s.err
+}
+
+var testExperienceConfig = ExperienceConfig{
+	HeroCurve:        LevelCurve{Base: decimal.NewFromInt(100), Factor: decimal.NewFromInt(2), MaxLevel: 4},
+	UnitCurve:        LevelCurve{Base: decimal.NewFromInt(50), Factor: decimal.NewFromInt(2)},
+	HeroGrowth:       GrowthTable{{HP: 10, Damage: decimal.NewFromInt(2), Armor: 1}, {HP: 20, Damage: decimal.NewFromInt(3), Armor: 2}},
+	UnitGrowth:       GrowthTable{{HP: 5, Damage: decimal.NewFromFloat(1.5), Armor: 1}},
+	HarvestRate:      decimal.NewFromFloat(0.1),
+	ConstructionRate: decimal.NewFromFloat(0.5),
+}
+
+func TestLevelCurveExperienceToUp(t *testing.T) {
+	curve := LevelCurve{Base: decimal.NewFromInt(200), Factor: decimal.NewFromFloat(1.5)}
+
+	tests := []struct {
+		level    int
+		expected decimal.Decimal
+	}{
+		{level: 0, expected: decimal.NewFromInt(200)},
+		{level: 1, expected: decimal.NewFromInt(200)},
+		{level: 2, expected: decimal.NewFromInt(300)},
+		{level: 3, expected: decimal.NewFromInt(450)},
+	}
+
+	for _, tt := range tests {
+		result := curve.ExperienceToUp(tt.level)
+		assert.True(t, tt.expected.Equal(result), "level %v: expected %v, got %v", tt.level, tt.expected, result)
+	}
+}
+
+func TestGrowthTableFor(t *testing.T) {
+	table := GrowthTable{{HP: 1}, {HP: 2}}
+
+	assert.Equal(t, 1, table.For(1).HP)
+	assert.Equal(t, 1, table.For(2).HP)
+	assert.Equal(t, 2, table.For(3).HP)
+	assert.Equal(t, 2, table.For(10).HP)
+	assert.Equal(t, StatGrowth{}, GrowthTable{}.For(2))
+}
+
+func TestApplyHeroExperience(t *testing.T) {
+	tests := []struct {
+		name           string
+		hero           models.Hero
+		xp             decimal.Decimal
+		expectedLevels int
+		expectedLevel  int
+		expectedXP     decimal.Decimal
+		expectedToUp   decimal.Decimal
+		expectedHP     int
+		expectedDamage decimal.Decimal
+		expectedArmor  int
+	}{
+		{
+			name:           "No level up",
+			hero:           models.Hero{Level: 1, ExperienceToUp: decimal.NewFromInt(100), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, Armor: 20, Damage: decimal.NewFromInt(20)}},
+			xp:             decimal.NewFromInt(40),
+			expectedLevels: 0,
+			expectedLevel:  1,
+			expectedXP:     decimal.NewFromInt(40),
+			expectedToUp:   decimal.NewFromInt(100),
+			expectedHP:     100,
+			expectedDamage: decimal.NewFromInt(20),
+			expectedArmor:  20,
+		},
+		{
+			name:           "One level with carry over",
+			hero:           models.Hero{Level: 1, Experience: decimal.NewFromInt(90), ExperienceToUp: decimal.NewFromInt(100), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 50, Armor: 20, Damage: decimal.NewFromInt(20)}},
+			xp:             decimal.NewFromInt(30),
+			expectedLevels: 1,
+			expectedLevel:  2,
+			expectedXP:     decimal.NewFromInt(20),
+			expectedToUp:   decimal.NewFromInt(200),
+			expectedHP:     110,
+			expectedDamage: decimal.NewFromInt(22),
+			expectedArmor:  21,
+		},
+		{
+			name:           "Several levels stop at max level",
+			hero:           models.Hero{Level: 1, ExperienceToUp: decimal.NewFromInt(100), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, Armor: 20, Damage: decimal.NewFromInt(20)}},
+			xp:             decimal.NewFromInt(10000),
+			expectedLevels: 3,
+			expectedLevel:  4,
+			expectedXP:     decimal.NewFromInt(9300),
+			expectedToUp:   decimal.NewFromInt(800),
+			expectedHP:     150,
+			expectedDamage: decimal.NewFromInt(28),
+			expectedArmor:  25,
+		},
+		{
+			name:           "Fractional damage is kept",
+			hero:           models.Hero{Level: 1, ExperienceToUp: decimal.NewFromInt(100), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, Armor: 20, Damage: decimal.NewFromFloat(20.5)}},
+			xp:             decimal.NewFromInt(100),
+			expectedLevels: 1,
+			expectedLevel:  2,
+			expectedXP:     decimal.Zero,
+			expectedToUp:   decimal.NewFromInt(200),
+			expectedHP:     110,
+			expectedDamage: decimal.NewFromFloat(22.5),
+			expectedArmor:  21,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hero := tt.hero
+			levels := applyHeroExperience(testExperienceConfig, &hero, tt.xp)
+
+			assert.Equal(t, tt.expectedLevels, levels)
+			assert.Equal(t, tt.expectedLevel, hero.Level)
+			assert.True(t, tt.expectedXP.Equal(hero.Experience), "experience: expected %v, got %v", tt.expectedXP, hero.Experience)
+			assert.True(t, tt.expectedToUp.Equal(hero.ExperienceToUp), "experience to up: expected %v, got %v", tt.expectedToUp, hero.ExperienceToUp)
+			assert.Equal(t, tt.expectedHP, hero.Charachteristics.HP)
+			assert.True(t, tt.expectedDamage.Equal(hero.Charachteristics.Damage), "damage: expected %v, got %v", tt.expectedDamage, hero.Charachteristics.Damage)
+			assert.Equal(t, tt.expectedArmor, hero.Charachteristics.Armor)
+		})
+	}
+}
+
+func TestApplyUnitExperience(t *testing.T) {
+	unit := models.Unit{Level: 1, Charachteristics: models.UnitCharacteristics{HP: 80, HPnow: 70, Armor: 5, Damage: decimal.NewFromInt(15)}}
+
+	// ExperienceToUp не задан - берется из кривой уровней
+	levels := applyUnitExperience(testExperienceConfig, &unit, decimal.NewFromInt(160))
+
+	assert.Equal(t, 2, levels)
+	assert.Equal(t, 3, unit.Level)
+	assert.True(t, decimal.NewFromInt(10).Equal(unit.Experience))
+	assert.True(t, decimal.NewFromInt(200).Equal(unit.ExperienceToUp))
+	assert.Equal(t, 90, unit.Charachteristics.HP)
+	assert.Equal(t, 80, unit.Charachteristics.HPnow)
+	assert.Equal(t, 7, unit.Charachteristics.Armor)
+	assert.True(t, decimal.NewFromInt(18).Equal(unit.Charachteristics.Damage))
+}
+
+func TestExperienceAwardHero(t *testing.T) {
+	store := &fakeProgressStore{}
+	var events []LevelUpEvent
+	exp := NewExperience(testExperienceConfig, store, func(e LevelUpEvent) { events = append(events, e) })
+
+	hero := models.Hero{Id: 5, Level: 1, ExperienceToUp: decimal.NewFromInt(100), Charachteristics: models.HeroCharacteristics{HP: 100, HPnow: 100, Damage: decimal.NewFromInt(20)}}
+	enemy := models.Enemy{Charachteristics: models.EnemyCharacteristics{Experience: decimal.NewFromInt(60)}}
+
+	// Первое убийство - опыт сохраняется, но уровень не растет
+	assert.NoError(t, exp.AwardHero(9, &hero, exp.KillReward(enemy)))
+	assert.Len(t, store.heroes, 1)
+	assert.Empty(t, events)
+
+	// Второе убийство - повышение уровня и событие клиенту
+	assert.NoError(t, exp.AwardHero(9, &hero, exp.KillReward(enemy)))
+	assert.Len(t, store.heroes, 2)
+	assert.Equal(t, 2, store.heroes[1].Level)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "level_up", events[0].Type)
+		assert.Equal(t, int64(9), events[0].AreaId)
+		assert.Equal(t, int64(5), events[0].ObjectId)
+		assert.Equal(t, "hero", events[0].ObjectType)
+		assert.Equal(t, 2, events[0].Level)
+		assert.Equal(t, 110, events[0].HP)
+	}
+
+	// Нулевой опыт ничего не меняет
+	assert.NoError(t, exp.AwardHero(9, &hero, decimal.Zero))
+	assert.Len(t, store.heroes, 2)
+}
+
+func TestExperienceAwardUnitStoreError(t *testing.T) {
+	store := &fakeProgressStore{err: errors.New("database error")}
+	notified := false
+	exp := NewExperience(testExperienceConfig, store, func(LevelUpEvent) { notified = true })
+
+	unit := models.Unit{Id: 1, Level: 1}
+	err := exp.AwardUnit(1, &unit, exp.ConstructionReward(200))
+
+	assert.ErrorIs(t, err, store.err)
+	assert.False(t, notified)
+}
+
+func TestExperienceRewards(t *testing.T) {
+	exp := NewExperience(testExperienceConfig, &fakeProgressStore{}, nil)
+
+	assert.True(t, decimal.NewFromInt(25).Equal(exp.HarvestReward(decimal.NewFromInt(250))))
+	assert.True(t, decimal.NewFromInt(60).Equal(exp.ConstructionReward(120)))
+}
@@ -0,0 +1,180 @@
+/*
+Групповое перемещение по полю потока.
+Вместо поиска пути для каждого юнита один раз строится поле интеграции - расстояние
+в шагах от каждой клетки арены до цели, - а по нему поле потока: для каждой клетки
+соседняя клетка, ближайшая к цели. Юниты идут по полю потока, не вставая на клетки,
+занятые другими юнитами группы.
+*/
+
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FlowField - поля интеграции и потока арены к одной цели.
+type FlowField struct {
+	width, height int
+	steps         []int // Расстояние до цели в шагах, -1 - цель недостижима
+	next          []int // Следующая клетка на пути к цели, -1 - нет
+}
+
+// Конструктор для FlowField. Поле строится поиском в ширину от цели goal
+// по свободным клеткам арены width x height.
+func NewFlowField(width, height int, goal Hex, blocked func(Hex) bool) *FlowField {
+	f := &FlowField{
+		width:  width,
+		height: height,
+		steps:  make([]int, width*height),
+		next:   make([]int, width*height),
+	}
+	for i := range f.steps {
+		f.steps[i], f.next[i] = -1, -1
+	}
+	if !f.inBounds(goal) || blocked(goal) {
+		return f
+	}
+
+	start := f.index(goal)
+	f.steps[start] = 0
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		cell := queue[head]
+		h := f.hex(cell)
+		for _, n := range h.Neighbours() {
+			if !f.inBounds(n) || blocked(n) {
+				continue
+			}
+			if i := f.index(n); f.steps[i] < 0 {
+				// Поиск в ширину: первая найденная соседняя клетка ближе всего к цели
+				f.steps[i] = f.steps[cell] + 1
+				f.next[i] = cell
+				queue = append(queue, i)
+			}
+		}
+	}
+	return f
+}
+
+// Steps возвращает расстояние от клетки до цели в шагах или -1, если цель недостижима.
+func (f *FlowField) Steps(h Hex) int {
+	if !f.inBounds(h) {
+		return -1
+	}
+	return f.steps[f.index(h)]
+}
+
+// Next возвращает клетку, в которую поле потока ведет из h. ok равен false в цели
+// и в клетках, из которых цель недостижима.
+func (f *FlowField) Next(h Hex) (next Hex, ok bool) {
+	if !f.inBounds(h) || f.next[f.index(h)] < 0 {
+		return Hex{}, false
+	}
+	return f.hex(f.next[f.index(h)]), true
+}
+
+// Move проводит группу юнитов из клеток starts по полю потока и возвращает пути юнитов
+// без стартовой клетки в порядке starts. За один ход каждый юнит делает шаг к цели,
+// если соседняя клетка ближе к цели и не занята другим юнитом группы; первыми ходят
+// юниты, ближайшие к цели. Юниты, которым некуда идти, остаются на месте, так что
+// группа собирается вокруг цели, а не на одной клетке. Путь юнита, из клетки которого
+// цель недостижима, равен nil.
+func (f *FlowField) Move(starts []Hex) [][]Hex {
+	paths := make([][]Hex, len(starts))
+	positions := make([]Hex, len(starts))
+	occupied := make(map[Hex]int, len(starts))
+	for i, start := range starts {
+		positions[i] = start
+		occupied[start]++
+		if f.Steps(start) >= 0 {
+			paths[i] = []Hex{}
+		}
+	}
+
+	order := make([]int, len(starts))
+	for i := range order {
+		order[i] = i
+	}
+	for moved := true; moved; {
+		moved = false
+		sort.SliceStable(order, func(a, b int) bool {
+			return f.Steps(positions[order[a]]) < f.Steps(positions[order[b]])
+		})
+		for _, i := range order {
+			next, ok := f.step(positions[i], occupied)
+			if !ok {
+				continue
+			}
+			occupied[positions[i]]--
+			if occupied[positions[i]] == 0 {
+				delete(occupied, positions[i])
+			}
+			occupied[next]++
+			positions[i] = next
+			paths[i] = append(paths[i], next)
+			moved = true
+		}
+	}
+	return paths
+}
+
+// step выбирает свободную от юнитов группы соседнюю клетку, которая ближе к цели, чем h.
+// Клетка по направлению поля потока выбирается первой.
+func (f *FlowField) step(h Hex, occupied map[Hex]int) (Hex, bool) {
+	steps := f.Steps(h)
+	if steps <= 0 {
+		return Hex{}, false
+	}
+	if next, ok := f.Next(h); ok && occupied[next] == 0 {
+		return next, true
+	}
+	// Обход занятой клетки: любая другая соседняя клетка, которая тоже ближе к цели
+	for _, n := range h.Neighbours() {
+		if s := f.Steps(n); s >= 0 && s < steps && occupied[n] == 0 {
+			return n, true
+		}
+	}
+	return Hex{}, false
+}
+
+// GroupRoutes находит пути юнитов арены unitIds к клетке goal по одному полю потока.
+// Клетки самих юнитов группы препятствиями не считаются, остальные объекты арены обходятся.
+// Возвращает пути в порядке unitIds, nil - для юнитов, которые не могут дойти.
+// Если не может дойти ни один юнит, возвращается ErrNoPath.
+func (w *MemoryWorld) GroupRoutes(unitIds []int64, goal Hex) ([][]Hex, error) {
+	if !w.InBounds(goal) {
+		return nil, ErrOutOfArea
+	}
+	starts := make([]Hex, len(unitIds))
+	group := make(map[Hex]bool, len(unitIds))
+	for i, id := range unitIds {
+		unit, ok := w.Units[id]
+		if !ok || len(unit.Coordinates) == 0 {
+			return nil, fmt.Errorf("%w: unit %v", ErrNotOnArea, id)
+		}
+		starts[i] = Hex(unit.Coordinates[0])
+		group[starts[i]] = true
+	}
+
+	blocked := func(h Hex) bool { return !group[h] && w.Blocked(h) }
+	paths := NewFlowField(w.Width, w.Height, goal, blocked).Move(starts)
+	for _, path := range paths {
+		if path != nil {
+			return paths, nil
+		}
+	}
+	return nil, ErrNoPath
+}
+
+func (f *FlowField) inBounds(h Hex) bool {
+	return h.Q >= 0 && h.R >= 0 && int(h.Q) < f.width && int(h.R) < f.height
+}
+
+func (f *FlowField) index(h Hex) int {
+	return int(h.R)*f.width + int(h.Q)
+}
+
+func (f *FlowField) hex(cell int) Hex {
+	return Hex{Q: float64(cell % f.width), R: float64(cell / f.width)}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+func TestFlowField(t *testing.T) {
+	world := newTestWorld()
+	// Стена по q=5 от r=0 до r=8, как в TestFindPath
+	var wall []models.Hex
+	for r := 0; r <= 8; r++ {
+		wall = append(wall, models.Hex{Q: 5, R: float64(r)})
+	}
+	world.AddBuilding(models.Building{Id: 1, Coordinates: wall})
+	goal := Hex{Q: 7, R: 3}
+	field := NewFlowField(world.Width, world.Height, goal, world.Blocked)
+
+	// Проверяем, что расстояние по полю равно длине кратчайшего пути, а поле потока ведет к цели
+	for _, start := range []Hex{{Q: 3, R: 3}, {Q: 0, R: 0}, {Q: 29, R: 29}, {Q: 8, R: 3}, goal} {
+		assert.Equal(t, len(FindPath(start, goal, world.Blocked)), field.Steps(start), "steps from %v", start)
+		h, steps := start, 0
+		for next, ok := field.Next(h); ok; next, ok = field.Next(h) {
+			assert.Equal(t, 1, h.Distance(next))
+			assert.False(t, world.Blocked(next))
+			h = next
+			steps++
+		}
+		assert.Equal(t, goal, h)
+		assert.Equal(t, field.Steps(start), steps)
+	}
+
+	// Проверяем, что из занятых клеток и из-за пределов арены цель недостижима
+	assert.Equal(t, -1, field.Steps(Hex{Q: 5, R: 4}))
+	assert.Equal(t, -1, field.Steps(Hex{Q: 30, R: 0}))
+	_, ok := field.Next(Hex{Q: 5, R: 4})
+	assert.False(t, ok)
+
+	// Проверяем, что к занятой цели поле не строится
+	field = NewFlowField(world.Width, world.Height, Hex{Q: 5, R: 4}, world.Blocked)
+	assert.Equal(t, -1, field.Steps(Hex{Q: 3, R: 3}))
+}
+
+func TestFlowFieldMove(t *testing.T) {
+	world := newTestWorld()
+	goal := Hex{Q: 15, R: 15}
+	// Цель окружена стеной с проходом в (16,15), клетка (0,29) отрезана от арены
+	world.AddBuilding(models.Building{Id: 1, Coordinates: []models.Hex{
+		{Q: 14, R: 15}, {Q: 15, R: 14}, {Q: 16, R: 14}, {Q: 14, R: 16}, {Q: 15, R: 16},
+		{Q: 1, R: 29}, {Q: 1, R: 28}, {Q: 0, R: 28},
+	}})
+	field := NewFlowField(world.Width, world.Height, goal, world.Blocked)
+	starts := []Hex{{Q: 25, R: 15}, {Q: 26, R: 15}, {Q: 25, R: 16}, {Q: 5, R: 5}, {Q: 25, R: 15}, {Q: 0, R: 29}}
+	paths := field.Move(starts)
+
+	// Проверяем, что юниты идут по свободным соседним клеткам и не заканчивают путь на одной клетке
+	assert.Len(t, paths, len(starts))
+	final := make(map[Hex]int)
+	for i, path := range paths[:5] {
+		if !assert.NotNil(t, path, "unit %v", i) {
+			continue
+		}
+		h := starts[i]
+		for _, next := range path {
+			assert.Equal(t, 1, h.Distance(next))
+			assert.False(t, world.Blocked(next), "hex %v is blocked", next)
+			assert.Less(t, field.Steps(next), field.Steps(h), "unit %v must move to the goal", i)
+			h = next
+		}
+		final[h]++
+	}
+	assert.Len(t, final, 5, "units must not stack on one hex: %v", final)
+	assert.Contains(t, final, goal)
+	// Проход к цели один: остальные юниты ждут перед ним
+	assert.Contains(t, final, Hex{Q: 16, R: 15})
+
+	// Проверяем, что отрезанный от цели юнит не двигается
+	assert.Nil(t, paths[5])
+}
+
+func TestMemoryWorldGroupRoutes(t *testing.T) {
+	area := models.Area{Id: 4, Width: 5, Height: 5}
+	// Юниты группы стоят в (0,0) и (1,0), юнит 3 вне группы - в (2,1)
+	newWorld := func(obstacles obstacleList) *MemoryWorld {
+		world := obstacles.world(area)
+		world.AddUnit(testUnit(1, Hex{Q: 0, R: 0}, 100))
+		world.AddUnit(testUnit(2, Hex{Q: 1, R: 0}, 100))
+		world.AddUnit(testUnit(3, Hex{Q: 2, R: 1}, 100))
+		return world
+	}
+
+	tests := []struct {
+		name          string
+		obstacles     obstacleList
+		units         []int64
+		goal          Hex
+		expectedSteps []int
+		expectedError error
+	}{
+		{name: "Group reaches goal", units: []int64{1, 2}, goal: Hex{Q: 4, R: 0}, expectedSteps: []int{3, 3}},
+		{name: "Goal inside group", units: []int64{1, 2}, goal: Hex{Q: 1, R: 0}, expectedSteps: []int{0, 0}},
+		{name: "Goal is occupied", obstacles: obstacleList{{Q: 4, R: 0}}, units: []int64{1, 2}, goal: Hex{Q: 4, R: 0},
+			expectedError: ErrNoPath},
+		{name: "Goal under unit outside group", units: []int64{1, 2}, goal: Hex{Q: 2, R: 1}, expectedError: ErrNoPath},
+		{name: "Goal out of area", units: []int64{1, 2}, goal: Hex{Q: 5, R: 0}, expectedError: ErrOutOfArea},
+		{name: "Unit not on area", units: []int64{1, 9}, goal: Hex{Q: 4, R: 0}, expectedError: ErrNotOnArea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что клетки юнитов группы не мешают их путям, а остальные объекты арены обходятся
+			paths, err := newWorld(tt.obstacles).GroupRoutes(tt.units, tt.goal)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+			steps := make([]int, len(paths))
+			for i, path := range paths {
+				steps[i] = len(path)
+			}
+			assert.Equal(t, tt.expectedSteps, steps)
+		})
+	}
+}
@@ -0,0 +1,554 @@
+/*
+Иерархический поиск пути (HPA*) для больших арен.
+Арена делится на квадратные кластеры. На общих границах соседних кластеров выбираются
+переходы - пары свободных соседних клеток, а между переходами одного кластера заранее
+считаются расстояния. Поиск сначала идет по небольшому графу переходов, затем найденный
+путь уточняется внутри каждого кластера. Когда клетка занимается или освобождается,
+пересчитываются только ее кластер и его границы с соседями.
+Найденный путь близок к кратчайшему, но не обязательно кратчайший.
+*/
+
+package game
+
+import (
+	"slices"
+	"sort"
+)
+
+// HARDCODE параметры HPA*
+const (
+	DefaultClusterSize = 20 // Сторона кластера в клетках
+	maxEntranceWidth   = 6  // Вход шире получает переходы на обоих краях, вход уже - один посередине
+)
+
+// hexDirections - смещения соседей гекса в том же порядке, что и Hex.Neighbours.
+var hexDirections = [6][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, -1}, {-1, 1}}
+
+// HPA - граф переходов между кластерами арены. Не безопасен для одновременного использования,
+// как и MemoryWorld, которому он принадлежит.
+type HPA struct {
+	width, height int
+	size          int // Сторона кластера
+	cols          int // Количество кластеров по q
+	blocked       []bool
+	borders       map[[2]int][][2]int // Переходы на границе пары кластеров
+	clusters      []map[int]*hpaNode  // Узлы графа переходов каждого кластера по клетке
+
+	// Буферы поиска в ширину внутри кластера
+	dist   []int
+	parent []int
+	queue  []int
+}
+
+// hpaNode - клетка, через которую проходит хотя бы один переход.
+type hpaNode struct {
+	refs  int       // Количество переходов через клетку
+	links []int     // Клетки соседних кластеров, в которые ведут переходы
+	edges []hpaEdge // Расстояния до других узлов кластера
+}
+
+// hpaEdge - расстояние в шагах до узла того же кластера.
+type hpaEdge struct {
+	to    int
+	steps int
+}
+
+// Конструктор для HPA. blocked сообщает, занята ли клетка арены width x height,
+// clusterSize - сторона кластера в клетках.
+func NewHPA(width, height, clusterSize int, blocked func(Hex) bool) *HPA {
+	if clusterSize < 1 {
+		clusterSize = DefaultClusterSize
+	}
+	p := &HPA{
+		width:   width,
+		height:  height,
+		size:    clusterSize,
+		cols:    (width + clusterSize - 1) / clusterSize,
+		blocked: make([]bool, width*height),
+		borders: make(map[[2]int][][2]int),
+		dist:    make([]int, clusterSize*clusterSize),
+		parent:  make([]int, clusterSize*clusterSize),
+	}
+	rows := (height + clusterSize - 1) / clusterSize
+	p.clusters = make([]map[int]*hpaNode, p.cols*rows)
+	for c := range p.clusters {
+		p.clusters[c] = make(map[int]*hpaNode)
+	}
+	for i := range p.blocked {
+		p.blocked[i] = blocked(p.hex(i))
+	}
+	// Границу пары кластеров достаточно построить с одной стороны
+	for c := range p.clusters {
+		p.rebuildBorders(c, func(other int) bool { return other > c })
+	}
+	for c := range p.clusters {
+		p.computeEdges(c)
+	}
+	return p
+}
+
+// SetBlocked отмечает клетку занятой или свободной и обновляет граф переходов
+// ее кластера и соседних с ним кластеров.
+func (p *HPA) SetBlocked(h Hex, blocked bool) {
+	if !p.inBounds(h) {
+		return
+	}
+	i := p.index(h)
+	if p.blocked[i] == blocked {
+		return
+	}
+	p.blocked[i] = blocked
+	c := p.clusterOf(i)
+	if !p.onBoundary(i) {
+		p.computeEdges(c)
+		return
+	}
+	changed := p.rebuildBorders(c, func(int) bool { return true })
+	p.computeEdges(c)
+	for _, other := range changed {
+		p.computeEdges(other)
+	}
+}
+
+// FindPath находит путь между двумя гексами. Как и FindPath для A*, возвращает путь
+// без стартового гекса (пустой, если start совпадает с goal) или nil, если пути нет.
+// Стартовый гекс может быть занят - на нем стоит сам юнит.
+func (p *HPA) FindPath(start, goal Hex) []Hex {
+	if !p.inBounds(start) || !p.inBounds(goal) {
+		return nil
+	}
+	s, g := p.index(start), p.index(goal)
+	if p.blocked[g] {
+		return nil
+	}
+	if s == g {
+		return []Hex{}
+	}
+	cs, cg := p.clusterOf(s), p.clusterOf(g)
+	if cs == cg {
+		if path := p.clusterPath(cs, s, g); path != nil {
+			return path
+		}
+	}
+
+	// Старт и цель временно связываются с узлами графа переходов
+	startEdges, via := p.startEdges(s, g)
+	p.bfs(cg, g)
+	goalSteps := make(map[int]int)
+	for cell := range p.clusters[cg] {
+		if d := p.dist[p.local(cg, cell)]; d >= 0 {
+			goalSteps[cell] = d
+		}
+	}
+
+	abstract := p.searchAbstract(s, g, startEdges, goalSteps)
+	if abstract == nil {
+		return nil
+	}
+	return p.refine(abstract, via)
+}
+
+// startEdges возвращает расстояния от старта s до узлов его кластера, а через соседние
+// клетки других кластеров - и до их узлов и цели g. Занятый старт не входит в переходы,
+// поэтому путь, сразу уходящий в другой кластер, иначе не нашелся бы.
+// via хранит первый шаг пути к узлам других кластеров.
+func (p *HPA) startEdges(s, g int) (edges []hpaEdge, via map[int]int) {
+	cs := p.clusterOf(s)
+	p.bfs(cs, s)
+	for cell := range p.clusters[cs] {
+		if d := p.dist[p.local(cs, cell)]; d >= 0 {
+			edges = append(edges, hpaEdge{to: cell, steps: d})
+		}
+	}
+
+	steps := make(map[int]int)
+	via = make(map[int]int)
+	q, r := s%p.width, s/p.width
+	for _, d := range hexDirections {
+		nq, nr := q+d[0], r+d[1]
+		if nq < 0 || nq >= p.width || nr < 0 || nr >= p.height {
+			continue
+		}
+		next := nr*p.width + nq
+		c := p.clusterOf(next)
+		if c == cs || p.blocked[next] {
+			continue
+		}
+		p.bfs(c, next)
+		targets := make([]int, 0, len(p.clusters[c])+1)
+		for cell := range p.clusters[c] {
+			targets = append(targets, cell)
+		}
+		if p.clusterOf(g) == c {
+			targets = append(targets, g)
+		}
+		for _, target := range targets {
+			d := p.dist[p.local(c, target)]
+			if old, ok := steps[target]; d >= 0 && (!ok || d+1 < old) {
+				steps[target] = d + 1
+				via[target] = next
+			}
+		}
+	}
+	for target, d := range steps {
+		edges = append(edges, hpaEdge{to: target, steps: d})
+	}
+	return edges, via
+}
+
+// searchAbstract ищет A* путь по графу переходов от s до g и возвращает клетки его узлов.
+func (p *HPA) searchAbstract(s, g int, startEdges []hpaEdge, goalSteps map[int]int) []int {
+	steps := map[int]int{s: 0}
+	cameFrom := make(map[int]int)
+	var open hpaQueue
+	open.push(hpaItem{cell: s, priority: p.distance(s, g)})
+
+	for len(open) > 0 {
+		item := open.pop()
+		current := item.cell
+		if current == g {
+			path := []int{g}
+			for current != s {
+				current = cameFrom[current]
+				path = append(path, current)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		base := steps[current]
+		if item.priority > base+p.distance(current, g) {
+			continue // Узел уже раскрыт с меньшей стоимостью
+		}
+		relax := func(to, cost int) {
+			if old, ok := steps[to]; !ok || base+cost < old {
+				steps[to] = base + cost
+				cameFrom[to] = current
+				open.push(hpaItem{cell: to, priority: base + cost + p.distance(to, g)})
+			}
+		}
+
+		if current == s {
+			for _, e := range startEdges {
+				relax(e.to, e.steps)
+			}
+		}
+		if node := p.clusters[p.clusterOf(current)][current]; node != nil {
+			for _, e := range node.edges {
+				relax(e.to, e.steps)
+			}
+			for _, link := range node.links {
+				relax(link, 1)
+			}
+		}
+		if d, ok := goalSteps[current]; ok {
+			relax(g, d)
+		}
+	}
+	return nil
+}
+
+// refine восстанавливает путь по клеткам между соседними узлами пути по графу переходов.
+// via - первые шаги от старта к узлам других кластеров.
+func (p *HPA) refine(abstract []int, via map[int]int) []Hex {
+	path := []Hex{}
+	for i := 1; i < len(abstract); i++ {
+		from, to := abstract[i-1], abstract[i]
+		if from == to {
+			continue
+		}
+		if next, ok := via[to]; ok && i == 1 {
+			path = append(path, p.hex(next))
+			from = next
+		}
+		c := p.clusterOf(from)
+		if c != p.clusterOf(to) {
+			// Переход между кластерами - один шаг
+			path = append(path, p.hex(to))
+			continue
+		}
+		segment := p.clusterPath(c, from, to)
+		if segment == nil {
+			return nil
+		}
+		path = append(path, segment...)
+	}
+	return path
+}
+
+// clusterPath находит кратчайший путь между клетками кластера c, не выходя из него.
+func (p *HPA) clusterPath(c, from, to int) []Hex {
+	p.bfs(c, from)
+	if p.dist[p.local(c, to)] < 0 {
+		return nil
+	}
+	path := make([]Hex, p.dist[p.local(c, to)])
+	for cell, i := to, len(path)-1; cell != from; cell, i = p.parent[p.local(c, cell)], i-1 {
+		path[i] = p.hex(cell)
+	}
+	return path
+}
+
+// bfs считает расстояния в шагах от клетки from до клеток кластера c в p.dist
+// (-1 - клетка недостижима) и запоминает предыдущие клетки путей в p.parent.
+func (p *HPA) bfs(c, from int) {
+	q0, r0, q1, r1 := p.bounds(c)
+	for i := range p.dist {
+		p.dist[i] = -1
+	}
+	p.dist[p.local(c, from)] = 0
+	p.queue = append(p.queue[:0], from)
+	for head := 0; head < len(p.queue); head++ {
+		cell := p.queue[head]
+		q, r := cell%p.width, cell/p.width
+		steps := p.dist[(r-r0)*p.size+q-q0] + 1
+		for _, d := range hexDirections {
+			nq, nr := q+d[0], r+d[1]
+			if nq < q0 || nq >= q1 || nr < r0 || nr >= r1 {
+				continue
+			}
+			next, l := nr*p.width+nq, (nr-r0)*p.size+nq-q0
+			if p.blocked[next] || p.dist[l] >= 0 {
+				continue
+			}
+			p.dist[l] = steps
+			p.parent[l] = cell
+			p.queue = append(p.queue, next)
+		}
+	}
+}
+
+// rebuildBorders заново выбирает переходы на границах кластера c с соседними кластерами,
+// для которых include возвращает true, и возвращает соседей, переходы с которыми изменились.
+func (p *HPA) rebuildBorders(c int, include func(other int) bool) []int {
+	pairs := make(map[int][][2]int)
+	q0, r0, q1, r1 := p.bounds(c)
+	for r := r0; r < r1; r++ {
+		for q := q0; q < q1; q++ {
+			cell := r*p.width + q
+			if p.blocked[cell] || !p.onBoundary(cell) {
+				continue
+			}
+			for _, d := range hexDirections {
+				nq, nr := q+d[0], r+d[1]
+				if nq < 0 || nq >= p.width || nr < 0 || nr >= p.height {
+					continue
+				}
+				next := nr*p.width + nq
+				if other := p.clusterOf(next); other != c && !p.blocked[next] {
+					pairs[other] = append(pairs[other], [2]int{cell, next})
+				}
+			}
+		}
+	}
+
+	var changed []int
+	cx, cy := c%p.cols, c/p.cols
+	for _, d := range hexDirections {
+		ox, oy := cx+d[0], cy+d[1]
+		other := oy*p.cols + ox
+		if ox < 0 || ox >= p.cols || oy < 0 || other >= len(p.clusters) || !include(other) {
+			continue
+		}
+		if p.setBorder(c, other, pairs[other]) {
+			changed = append(changed, other)
+		}
+	}
+	return changed
+}
+
+// setBorder заменяет переходы между кластерами a и b переходами, выбранными из пар
+// соседних свободных клеток pairs (первая клетка пары - в кластере a).
+// Возвращает false, если переходы не изменились.
+func (p *HPA) setBorder(a, b int, pairs [][2]int) bool {
+	key := [2]int{a, b}
+	if b < a {
+		// Первой в переходе всегда идет клетка кластера с меньшим номером
+		key = [2]int{b, a}
+		for i := range pairs {
+			pairs[i][0], pairs[i][1] = pairs[i][1], pairs[i][0]
+		}
+	}
+	var transitions [][2]int
+	if len(pairs) > 0 {
+		// Пары упорядочиваются вдоль границы: соседние по порядку пары имеют общую клетку
+		position := func(pair [2]int) int {
+			from, to := pair[0], pair[1]
+			if from%p.width/p.size != to%p.width/p.size {
+				return from/p.width + to/p.width
+			}
+			return from%p.width + to%p.width
+		}
+		sort.Slice(pairs, func(i, j int) bool { return position(pairs[i]) < position(pairs[j]) })
+
+		for start := 0; start < len(pairs); {
+			end := start + 1
+			for end < len(pairs) && position(pairs[end]) == position(pairs[end-1])+1 {
+				end++
+			}
+			if end-start > maxEntranceWidth {
+				transitions = append(transitions, pairs[start], pairs[end-1])
+			} else {
+				transitions = append(transitions, pairs[(start+end-1)/2])
+			}
+			start = end
+		}
+	}
+	if slices.Equal(transitions, p.borders[key]) {
+		return false
+	}
+
+	for _, t := range p.borders[key] {
+		p.unlink(t[0], t[1])
+		p.unlink(t[1], t[0])
+	}
+	delete(p.borders, key)
+	for _, t := range transitions {
+		p.link(t[0], t[1])
+		p.link(t[1], t[0])
+	}
+	if len(transitions) > 0 {
+		p.borders[key] = transitions
+	}
+	return true
+}
+
+// link добавляет переход из клетки from в соседнюю клетку другого кластера to.
+func (p *HPA) link(from, to int) {
+	nodes := p.clusters[p.clusterOf(from)]
+	node := nodes[from]
+	if node == nil {
+		node = &hpaNode{}
+		nodes[from] = node
+	}
+	node.refs++
+	node.links = append(node.links, to)
+}
+
+// unlink удаляет переход из from в to. Клетка без переходов перестает быть узлом.
+func (p *HPA) unlink(from, to int) {
+	nodes := p.clusters[p.clusterOf(from)]
+	node := nodes[from]
+	if node == nil {
+		return
+	}
+	for i, link := range node.links {
+		if link == to {
+			node.links = append(node.links[:i], node.links[i+1:]...)
+			break
+		}
+	}
+	node.refs--
+	if node.refs <= 0 {
+		delete(nodes, from)
+	}
+}
+
+// computeEdges пересчитывает расстояния между узлами кластера c.
+func (p *HPA) computeEdges(c int) {
+	for cell, node := range p.clusters[c] {
+		node.edges = node.edges[:0]
+		p.bfs(c, cell)
+		for other := range p.clusters[c] {
+			if d := p.dist[p.local(c, other)]; other != cell && d >= 0 {
+				node.edges = append(node.edges, hpaEdge{to: other, steps: d})
+			}
+		}
+	}
+}
+
+// bounds возвращает границы кластера c: q0 <= q < q1, r0 <= r < r1.
+func (p *HPA) bounds(c int) (q0, r0, q1, r1 int) {
+	q0, r0 = c%p.cols*p.size, c/p.cols*p.size
+	return q0, r0, min(q0+p.size, p.width), min(r0+p.size, p.height)
+}
+
+// onBoundary проверяет, лежит ли клетка на краю своего кластера.
+func (p *HPA) onBoundary(cell int) bool {
+	q, r := cell%p.width%p.size, cell/p.width%p.size
+	return q == 0 || r == 0 || q == p.size-1 || r == p.size-1 ||
+		cell%p.width == p.width-1 || cell/p.width == p.height-1
+}
+
+func (p *HPA) clusterOf(cell int) int {
+	return cell/p.width/p.size*p.cols + cell%p.width/p.size
+}
+
+// local возвращает индекс клетки в буферах поиска внутри кластера c.
+func (p *HPA) local(c, cell int) int {
+	q0, r0, _, _ := p.bounds(c)
+	return (cell/p.width-r0)*p.size + cell%p.width - q0
+}
+
+// distance - расстояние между клетками в шагах без учета препятствий.
+func (p *HPA) distance(a, b int) int {
+	dq, dr := a%p.width-b%p.width, a/p.width-b/p.width
+	return (abs(dq) + abs(dr) + abs(dq+dr)) / 2
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (p *HPA) inBounds(h Hex) bool {
+	return h.Q >= 0 && h.R >= 0 && int(h.Q) < p.width && int(h.R) < p.height
+}
+
+func (p *HPA) index(h Hex) int {
+	return int(h.R)*p.width + int(h.Q)
+}
+
+func (p *HPA) hex(cell int) Hex {
+	return Hex{Q: float64(cell % p.width), R: float64(cell / p.width)}
+}
+
+// hpaItem - узел в очереди поиска по графу переходов.
+type hpaItem struct {
+	cell     int
+	priority int
+}
+
+// hpaQueue - двоичная куча узлов по возрастанию приоритета. В отличие от PriorityQueue
+// хранит значения, а не указатели, и не требует container/heap.
+type hpaQueue []hpaItem
+
+func (q *hpaQueue) push(item hpaItem) {
+	*q = append(*q, item)
+	h := *q
+	for i := len(h) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if h[parent].priority <= h[i].priority {
+			break
+		}
+		h[parent], h[i] = h[i], h[parent]
+		i = parent
+	}
+}
+
+func (q *hpaQueue) pop() hpaItem {
+	h := *q
+	top := h[0]
+	last := len(h) - 1
+	h[0] = h[last]
+	h = h[:last]
+	for i := 0; ; {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < len(h) && h[left].priority < h[smallest].priority {
+			smallest = left
+		}
+		if right < len(h) && h[right].priority < h[smallest].priority {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+	*q = h
+	return top
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// hpaMaxDetour - во сколько раз путь HPA* может быть длиннее кратчайшего
+const hpaMaxDetour = 1.5
+
+// randomObstacles возвращает занятые клетки арены width x height с долей density
+func randomObstacles(width, height int, density float64, seed int64) map[Hex]bool {
+	rng := rand.New(rand.NewSource(seed))
+	obstacles := make(map[Hex]bool)
+	for q := 0; q < width; q++ {
+		for r := 0; r < height; r++ {
+			if rng.Float64() < density {
+				obstacles[Hex{Q: float64(q), R: float64(r)}] = true
+			}
+		}
+	}
+	return obstacles
+}
+
+// boundedBlocked - занятость клеток для A* с учетом границ арены
+func boundedBlocked(width, height int, obstacles map[Hex]bool) func(Hex) bool {
+	return func(h Hex) bool {
+		return h.Q < 0 || h.R < 0 || h.Q >= float64(width) || h.R >= float64(height) || obstacles[h]
+	}
+}
+
+// assertPath проверяет, что путь ведет по соседним свободным клеткам от start к goal
+// и не сильно длиннее кратчайшего пути expected
+func assertPath(t *testing.T, path, expected []Hex, start, goal Hex, blocked func(Hex) bool) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, path, "%v -> %v: path must not exist", start, goal)
+		return
+	}
+	if !assert.NotNil(t, path, "%v -> %v: path must exist", start, goal) {
+		return
+	}
+	prev := start
+	for _, h := range path {
+		assert.False(t, blocked(h), "hex %v is blocked", h)
+		assert.Equal(t, 1, prev.Distance(h), "path must move to neighbour hexes")
+		prev = h
+	}
+	assert.Equal(t, goal, prev)
+	assert.LessOrEqual(t, float64(len(path)), float64(len(expected))*hpaMaxDetour+2)
+}
+
+func TestHPAFindPath(t *testing.T) {
+	// Размеры арены не кратны размеру кластера - крайние кластеры неполные
+	const width, height = 57, 43
+	tests := []struct {
+		name        string
+		density     float64
+		clusterSize int
+	}{
+		{"Empty area", 0, 8},
+		{"Sparse obstacles", 0.15, 8},
+		{"Dense obstacles", 0.35, 8},
+		{"Small clusters", 0.25, 3},
+		{"Single cluster", 0.25, 64},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что HPA* находит путь тогда же, когда A*, и путь близок к кратчайшему
+			obstacles := randomObstacles(width, height, tt.density, int64(i))
+			blocked := boundedBlocked(width, height, obstacles)
+			hpa := NewHPA(width, height, tt.clusterSize, blocked)
+			rng := rand.New(rand.NewSource(int64(i)))
+			for j := 0; j < 200; j++ {
+				start := Hex{Q: float64(rng.Intn(width)), R: float64(rng.Intn(height))}
+				goal := Hex{Q: float64(rng.Intn(width)), R: float64(rng.Intn(height))}
+				assertPath(t, hpa.FindPath(start, goal), FindPath(start, goal, blocked), start, goal, blocked)
+			}
+		})
+	}
+
+	// Проверяем граничные случаи, как у FindPath
+	hpa := NewHPA(10, 10, 4, func(h Hex) bool { return h == Hex{Q: 5, R: 5} })
+	assert.Empty(t, hpa.FindPath(Hex{Q: 3, R: 3}, Hex{Q: 3, R: 3}))
+	assert.NotNil(t, hpa.FindPath(Hex{Q: 3, R: 3}, Hex{Q: 3, R: 3}))
+	assert.Nil(t, hpa.FindPath(Hex{Q: 3, R: 3}, Hex{Q: 5, R: 5}))
+	assert.Nil(t, hpa.FindPath(Hex{Q: 3, R: 3}, Hex{Q: 10, R: 0}))
+	assert.Equal(t, []Hex{{Q: 4, R: 5}}, hpa.FindPath(Hex{Q: 5, R: 5}, Hex{Q: 4, R: 5}))
+}
+
+func TestHPASetBlocked(t *testing.T) {
+	const width, height = 40, 40
+	obstacles := randomObstacles(width, height, 0.2, 7)
+	blocked := boundedBlocked(width, height, obstacles)
+	hpa := NewHPA(width, height, 6, blocked)
+	rng := rand.New(rand.NewSource(7))
+
+	// Проверяем, что после каждого изменения путь совпадает по наличию и близок
+	// по длине к пути A* на текущей арене
+	for i := 0; i < 300; i++ {
+		h := Hex{Q: float64(rng.Intn(width)), R: float64(rng.Intn(height))}
+		obstacles[h] = !obstacles[h]
+		hpa.SetBlocked(h, obstacles[h])
+
+		start := Hex{Q: float64(rng.Intn(width)), R: float64(rng.Intn(height))}
+		goal := Hex{Q: float64(rng.Intn(width)), R: float64(rng.Intn(height))}
+		assertPath(t, hpa.FindPath(start, goal), FindPath(start, goal, blocked), start, goal, blocked)
+	}
+
+	// Проверяем, что обновленный граф совпадает с построенным заново
+	rebuilt := NewHPA(width, height, 6, blocked)
+	assert.Equal(t, rebuilt.borders, hpa.borders)
+	for c := range rebuilt.clusters {
+		assert.Equal(t, len(rebuilt.clusters[c]), len(hpa.clusters[c]), "nodes of cluster %v", c)
+		for cell, node := range rebuilt.clusters[c] {
+			if assert.Contains(t, hpa.clusters[c], cell) {
+				assert.ElementsMatch(t, node.links, hpa.clusters[c][cell].links)
+				assert.ElementsMatch(t, node.edges, hpa.clusters[c][cell].edges)
+			}
+		}
+	}
+}
+
+func TestMemoryWorldHPA(t *testing.T) {
+	world := NewMemoryWorld(models.Area{Id: 1, Width: 60, Height: 60})
+	world.EnableHPA(10)
+	start, goal := Hex{Q: 5, R: 30}, Hex{Q: 50, R: 30}
+
+	// Проверяем, что путь по пустой арене кратчайший
+	assert.Len(t, world.FindPath(start, goal), start.Distance(goal))
+
+	// Проверяем, что добавленная стена учитывается без перестроения графа
+	var wall []models.Hex
+	for r := 10; r < 50; r++ {
+		wall = append(wall, models.Hex{Q: 30, R: float64(r)})
+	}
+	world.AddBuilding(models.Building{Id: 1, Charachteristics: models.BuildingCharacteristics{HP: 10}, Coordinates: wall})
+	path := world.FindPath(start, goal)
+	assertPath(t, path, FindPath(start, goal, world.Blocked), start, goal, world.Blocked)
+	assert.Greater(t, len(path), start.Distance(goal))
+
+	// Проверяем, что разрушенная стена освобождает клетки
+	assert.True(t, world.DamageTarget(Target{Id: 1, Kind: KindBuilding}, decimal.NewFromInt(10)))
+	assert.Len(t, world.FindPath(start, goal), start.Distance(goal))
+}
+
+// Сравнение A* и HPA* на арене 400x400: путь от (0,0) до (n/2,n/2) имеет длину n
+var benchmarkLengths = []int{10, 100, 400}
+
+func benchmarkArena(b *testing.B, density float64) func(Hex) bool {
+	b.Helper()
+	obstacles := randomObstacles(400, 400, density, 1)
+	for _, n := range benchmarkLengths {
+		delete(obstacles, Hex{Q: float64(n / 2), R: float64(n / 2)})
+	}
+	delete(obstacles, Hex{})
+	return boundedBlocked(400, 400, obstacles)
+}
+
+func BenchmarkFindPath(b *testing.B) {
+	for _, density := range []float64{0, 0.2} {
+		blocked := benchmarkArena(b, density)
+		for _, n := range benchmarkLengths {
+			goal := Hex{Q: float64(n / 2), R: float64(n / 2)}
+			b.Run(fmt.Sprintf("AStar/obstacles=%v/length=%v", density, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					FindPath(Hex{}, goal, blocked)
+				}
+			})
+			hpa := NewHPA(400, 400, DefaultClusterSize, blocked)
+			b.Run(fmt.Sprintf("HPA/obstacles=%v/length=%v", density, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					hpa.FindPath(Hex{}, goal)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkHPABuild(b *testing.B) {
+	blocked := benchmarkArena(b, 0.2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewHPA(400, 400, DefaultClusterSize, blocked)
+	}
+}
+
+func BenchmarkHPASetBlocked(b *testing.B) {
+	hpa := NewHPA(400, 400, DefaultClusterSize, benchmarkArena(b, 0.2))
+	// Клетка на углу кластера - обновляются границы с соседними кластерами
+	h := Hex{Q: DefaultClusterSize, R: DefaultClusterSize}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hpa.SetBlocked(h, i%2 == 0)
+	}
+}
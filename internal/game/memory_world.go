@@ -0,0 +1,275 @@
+package game
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+// Виды объектов арены
+const (
+	KindNeutral  = "neutral"
+	KindBuilding = "building"
+	KindHero     = "hero"
+	KindUnit     = "unit"
+	KindEnemy    = "enemy"
+)
+
+// MemoryWorld - состояние одной арены в памяти сервера. Позволяет игровой логике
+// (ИИ врагов, спавнеру) быстро проверять занятость клеток без обращения к БД,
+// а в тестах используется вместо БД. Арену, загруженную в Areas, одновременно меняют
+// тики ИИ и обработчики действий, поэтому обращаться к ней нужно под Lock.
+type MemoryWorld struct {
+	sync.Mutex
+
+	AreaId    int64
+	Width     int
+	Height    int
+	Neutrals  map[int64]*models.Neutral
+	Buildings map[int64]*models.Building
+	Heroes    map[int64]*models.Hero
+	Units     map[int64]*models.Unit
+	Enemies   map[int64]*models.Enemy
+
+	occupied map[Hex]int // количество объектов на клетке
+	paths    *HPA        // иерархический поиск пути, nil - поиск A*
+}
+
+// EnableHPA включает иерархический поиск пути для больших арен. Граф переходов строится
+// по текущим объектам и дальше обновляется, когда клетки занимаются и освобождаются.
+func (w *MemoryWorld) EnableHPA(clusterSize int) {
+	w.paths = NewHPA(w.Width, w.Height, clusterSize, w.Blocked)
+}
+
+// FindPath находит путь по арене в обход занятых клеток: иерархическим поиском,
+// если он включен, иначе A*.
+func (w *MemoryWorld) FindPath(start, goal Hex) []Hex {
+	if w.paths != nil {
+		return w.paths.FindPath(start, goal)
+	}
+	return FindPath(start, goal, w.Blocked)
+}
+
+// FindPathTo находит путь до клетки goal, которую может занимать цель, например герой.
+// Если goal занята, путь ведет к ближайшей к start свободной соседней с ней клетке
+// и заканчивается в goal.
+func (w *MemoryWorld) FindPathTo(start, goal Hex) []Hex {
+	if !w.Blocked(goal) {
+		return w.FindPath(start, goal)
+	}
+	neighbours := goal.Neighbours()
+	sort.SliceStable(neighbours, func(i, j int) bool {
+		return start.Distance(neighbours[i]) < start.Distance(neighbours[j])
+	})
+	for _, n := range neighbours {
+		if n != start && w.Blocked(n) {
+			continue
+		}
+		if path := w.FindPath(start, n); path != nil {
+			return append(path, goal)
+		}
+	}
+	return nil
+}
+
+// Target - объект игрока, который может быть целью врага.
+type Target struct {
+	Id       int64  // Идентификатор объекта
+	Kind     string // hero, unit или building
+	Position Hex    // Текущая позиция
+}
+
+// Конструктор для MemoryWorld
+func NewMemoryWorld(area models.Area) *MemoryWorld {
+	return &MemoryWorld{
+		AreaId:    area.Id,
+		Width:     area.Width,
+		Height:    area.Height,
+		Neutrals:  make(map[int64]*models.Neutral),
+		Buildings: make(map[int64]*models.Building),
+		Heroes:    make(map[int64]*models.Hero),
+		Units:     make(map[int64]*models.Unit),
+		Enemies:   make(map[int64]*models.Enemy),
+		occupied:  make(map[Hex]int),
+	}
+}
+
+func (w *MemoryWorld) AddNeutral(n models.Neutral) {
+	w.Neutrals[n.Id] = &n
+	w.occupy(n.Coordinates, 1)
+}
+
+func (w *MemoryWorld) AddBuilding(b models.Building) {
+	w.Buildings[b.Id] = &b
+	w.occupy(b.Coordinates, 1)
+}
+
+func (w *MemoryWorld) AddHero(h models.Hero) {
+	w.Heroes[h.Id] = &h
+	w.occupy(h.Coordinates, 1)
+}
+
+func (w *MemoryWorld) AddUnit(u models.Unit) {
+	w.Units[u.Id] = &u
+	w.occupy(u.Coordinates, 1)
+}
+
+func (w *MemoryWorld) AddEnemy(e models.Enemy) {
+	w.Enemies[e.Id] = &e
+	w.occupy(e.Coordinates, 1)
+}
+
+// RemoveEnemy удаляет врага с арены.
+func (w *MemoryWorld) RemoveEnemy(id int64) {
+	if e, ok := w.Enemies[id]; ok {
+		w.occupy(e.Coordinates, -1)
+		delete(w.Enemies, id)
+	}
+}
+
+// InBounds проверяет, находится ли гекс в пределах арены.
+func (w *MemoryWorld) InBounds(h Hex) bool {
+	return h.Q >= 0 && h.R >= 0 && int(h.Q) < w.Width && int(h.R) < w.Height
+}
+
+// Blocked проверяет, нельзя ли встать на гекс: он за пределами арены или занят объектом.
+func (w *MemoryWorld) Blocked(h Hex) bool {
+	return !w.InBounds(h) || w.occupied[h] > 0
+}
+
+// Targets возвращает живых героев и юнитов игрока, упорядоченных по виду и ID.
+func (w *MemoryWorld) Targets() []Target {
+	targets := make([]Target, 0, len(w.Heroes)+len(w.Units))
+	for _, h := range w.Heroes {
+		if len(h.Coordinates) > 0 && h.Charachteristics.HPnow > 0 {
+			targets = append(targets, Target{Id: h.Id, Kind: KindHero, Position: Hex(h.Coordinates[0])})
+		}
+	}
+	for _, u := range w.Units {
+		if len(u.Coordinates) > 0 && u.Charachteristics.HPnow > 0 {
+			targets = append(targets, Target{Id: u.Id, Kind: KindUnit, Position: Hex(u.Coordinates[0])})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Kind != targets[j].Kind {
+			return targets[i].Kind < targets[j].Kind
+		}
+		return targets[i].Id < targets[j].Id
+	})
+	return targets
+}
+
+// Target возвращает цель по виду и ID, если она еще жива.
+func (w *MemoryWorld) Target(kind string, id int64) (Target, bool) {
+	switch kind {
+	case KindBuilding:
+		if b, ok := w.Buildings[id]; ok && len(b.Coordinates) > 0 && b.Charachteristics.HP > 0 {
+			return Target{Id: id, Kind: kind, Position: Hex(b.Coordinates[0])}, true
+		}
+	case KindHero:
+		if h, ok := w.Heroes[id]; ok && len(h.Coordinates) > 0 && h.Charachteristics.HPnow > 0 {
+			return Target{Id: id, Kind: kind, Position: Hex(h.Coordinates[0])}, true
+		}
+	case KindUnit:
+		if u, ok := w.Units[id]; ok && len(u.Coordinates) > 0 && u.Charachteristics.HPnow > 0 {
+			return Target{Id: id, Kind: kind, Position: Hex(u.Coordinates[0])}, true
+		}
+	}
+	return Target{}, false
+}
+
+// MoveEnemy перемещает врага на гекс to.
+func (w *MemoryWorld) MoveEnemy(id int64, to Hex) {
+	e, ok := w.Enemies[id]
+	if !ok {
+		return
+	}
+	w.occupy(e.Coordinates, -1)
+	e.Coordinates = []models.Hex{models.Hex(to)}
+	w.occupy(e.Coordinates, 1)
+}
+
+// DamageTarget наносит цели урон с учетом брони (не меньше 1) и возвращает true, если цель погибла.
+// Погибшая цель освобождает клетку.
+func (w *MemoryWorld) DamageTarget(target Target, damage decimal.Decimal) bool {
+	switch target.Kind {
+	case KindBuilding:
+		b, ok := w.Buildings[target.Id]
+		if !ok {
+			return false
+		}
+		b.Charachteristics.HP -= effectiveDamage(damage, b.Charachteristics.Armor)
+		if b.Charachteristics.HP <= 0 {
+			b.Charachteristics.HP = 0
+			w.occupy(b.Coordinates, -1)
+			delete(w.Buildings, b.Id)
+			return true
+		}
+	case KindHero:
+		h, ok := w.Heroes[target.Id]
+		if !ok {
+			return false
+		}
+		h.Charachteristics.HPnow -= effectiveDamage(damage, h.Charachteristics.Armor)
+		if h.Charachteristics.HPnow <= 0 {
+			h.Charachteristics.HPnow = 0
+			w.occupy(h.Coordinates, -1)
+			delete(w.Heroes, h.Id)
+			return true
+		}
+	case KindUnit:
+		u, ok := w.Units[target.Id]
+		if !ok {
+			return false
+		}
+		u.Charachteristics.HPnow -= effectiveDamage(damage, u.Charachteristics.Armor)
+		if u.Charachteristics.HPnow <= 0 {
+			u.Charachteristics.HPnow = 0
+			w.occupy(u.Coordinates, -1)
+			delete(w.Units, u.Id)
+			return true
+		}
+	}
+	return false
+}
+
+// DamageEnemy наносит врагу урон с учетом брони (не меньше 1) и возвращает true, если враг погиб.
+// Погибший враг удаляется с арены.
+func (w *MemoryWorld) DamageEnemy(id int64, damage decimal.Decimal) bool {
+	e, ok := w.Enemies[id]
+	if !ok {
+		return false
+	}
+	e.Charachteristics.HP -= effectiveDamage(damage, e.Charachteristics.Armor)
+	if e.Charachteristics.HP > 0 {
+		return false
+	}
+	e.Charachteristics.HP = 0
+	w.RemoveEnemy(id)
+	return true
+}
+
+// effectiveDamage возвращает урон за вычетом брони, но не меньше 1.
+func effectiveDamage(damage decimal.Decimal, armor int) int {
+	d := int(damage.IntPart()) - armor
+	if d < 1 {
+		d = 1
+	}
+	return d
+}
+
+func (w *MemoryWorld) occupy(coordinates []models.Hex, delta int) {
+	for _, c := range coordinates {
+		wasBlocked := w.occupied[Hex(c)] > 0
+		w.occupied[Hex(c)] += delta
+		if w.occupied[Hex(c)] <= 0 {
+			delete(w.occupied, Hex(c))
+		}
+		if blocked := w.occupied[Hex(c)] > 0; w.paths != nil && blocked != wasBlocked {
+			w.paths.SetBlocked(Hex(c), blocked)
+		}
+	}
+}
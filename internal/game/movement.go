@@ -2,10 +2,17 @@ package game
 
 import (
 	"container/heap"
-	"cyber/internal/models"
-	storage "cyber/internal/storage"
-	"fmt"
+	"errors"
 	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoPath          = errors.New("path not found")
+	ErrOutOfArea       = errors.New("hex is out of area")
+	ErrUnknownUnitType = errors.New("unknown unit type")
+	ErrNotValidSpeed   = errors.New("speed must be positive")
 )
 
 type PathNode struct {
@@ -21,7 +28,7 @@ type Hex struct {
 }
 
 // Функция расчета стоимости перехода между 2 гексами
-func (h *Hex) Cost(toPosition models.Hex) float64 {
+func (h *Hex) Cost(toPosition Hex) float64 {
 	return math.Abs(h.Q-toPosition.Q) + math.Abs(h.R-toPosition.R) + math.Abs(h.Q+h.R-toPosition.Q-toPosition.R)
 }
 
@@ -64,34 +71,13 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return node
 }
 
-// Функция получает из БД координаты всех объектов и маркирует их как препятсвия
-// TODO: лучше разделить на 2 функции. 1 получает данные 2 - маркирует
-func obstaclesMaper(areaID int64) (map[Hex]bool, error) {
-	db, err := storage.New()
-	if err != nil {
-		return nil, fmt.Errorf("error db connection: %v\n", err)
-	}
-	obstacles, err := db.GetObstacles(areaID)
-	if err != nil {
-		return nil, err
-	}
-	obstaclesMap := make(map[Hex]bool, len(obstacles))
-	for _, v := range obstacles {
-		obstaclesMap[v] = true
-	}
-	return obstaclesMap, nil
-}
-
-// AStar находит кратчайший путь между двумя гексами.
-func AStar(start, goal Hex, areaID int64) bool {
-	obstacles, err := obstaclesMaper(areaID)
-	if err != nil {
-		return false
-	}
-
+// FindPath находит кратчайший путь между двумя гексами алгоритмом A*.
+// blocked сообщает, занят ли гекс. Возвращает путь без стартового гекса
+// (пустой, если start совпадает с goal) или nil, если путь не найден.
+func FindPath(start, goal Hex, blocked func(Hex) bool) []Hex {
 	// Проверяем, не является ли цель занятой клеткой
-	if obstacles[goal] {
-		return false
+	if blocked(goal) {
+		return nil
 	}
 
 	frontier := make(PriorityQueue, 0)
@@ -112,14 +98,14 @@ func AStar(start, goal Hex, areaID int64) bool {
 	for frontier.Len() > 0 {
 		current := heap.Pop(&frontier).(*PathNode)
 
-		// Если достигли цели, возвращаем true
+		// Если достигли цели, восстанавливаем путь
 		if current.Coordinate == goal {
-			return true
+			return reconstructPath(cameFrom, start, goal)
 		}
 
 		// Анализируем соседей
 		for _, neighbor := range current.Coordinate.Neighbours() {
-			if obstacles[neighbor] {
+			if blocked(neighbor) {
 				continue
 			}
 
@@ -137,8 +123,84 @@ func AStar(start, goal Hex, areaID int64) bool {
 		}
 	}
 
-	// Если путь не найден, возвращаем false
-	return false
+	// Путь не найден
+	return nil
+}
+
+// Route - путь юнита по арене.
+type Route struct {
+	Path       []Hex         // Путь без стартового гекса
+	Cost       float64       // Стоимость пути, сумма Cost переходов
+	TravelTime time.Duration // Время прохождения пути
+}
+
+// UnitSpeed возвращает скорость перемещения юнита типа unitType в клетках в секунду:
+// для hero и unit - скорость стартовых героя и юнита арены, для остальных типов -
+// скорость врага с таким названием (goblin, orc, dragon).
+func UnitSpeed(unitType string) (float64, error) {
+	switch unitType {
+	case KindHero:
+		return generateHero().Charachteristics.Speed.InexactFloat64(), nil
+	case KindUnit:
+		return generateUnit().Charachteristics.Speed.InexactFloat64(), nil
+	}
+	for _, t := range DefaultSpawnConfig.Templates {
+		if strings.EqualFold(t.Name, unitType) {
+			return t.Characteristics.Speed.InexactFloat64(), nil
+		}
+	}
+	return 0, ErrUnknownUnitType
+}
+
+// Path находит путь по арене от start до goal в обход занятых клеток.
+// Путь не выходит за пределы арены, поэтому поиск всегда завершается.
+func (w *MemoryWorld) Path(start, goal Hex) ([]Hex, error) {
+	if !w.InBounds(start) || !w.InBounds(goal) {
+		return nil, ErrOutOfArea
+	}
+	path := w.FindPath(start, goal)
+	if path == nil {
+		return nil, ErrNoPath
+	}
+	return path, nil
+}
+
+// Route находит путь по арене от start до goal и оценивает время его прохождения
+// со скоростью speed клеток в секунду.
+func (w *MemoryWorld) Route(start, goal Hex, speed float64) (Route, error) {
+	if speed <= 0 {
+		return Route{}, ErrNotValidSpeed
+	}
+	path, err := w.Path(start, goal)
+	if err != nil {
+		return Route{}, err
+	}
+
+	route := Route{Path: path}
+	from := start
+	for _, h := range path {
+		route.Cost += from.Cost(h)
+		from = h
+	}
+	route.TravelTime = time.Duration(float64(len(path)) / speed * float64(time.Second))
+	return route, nil
+}
+
+// reconstructPath восстанавливает путь от start до goal по карте переходов cameFrom.
+func reconstructPath(cameFrom map[Hex]Hex, start, goal Hex) []Hex {
+	path := []Hex{}
+	for current := goal; current != start; current = cameFrom[current] {
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Distance возвращает расстояние между двумя гексами в клетках.
+func (h Hex) Distance(to Hex) int {
+	return int(h.Heuristic(to))
 }
 
 // Neighbours возвращает соседей гекса.
@@ -4,8 +4,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 	//"math"
-	//"cyber/internal/models"
+	"cyber/internal/models"
 )
 
 //func (h Hex) Cost(toPosition Hex) float64 {
@@ -163,3 +164,108 @@ func Test_Neighbours(t *testing.T) {
 		})
 	}
 }
+
+// obstacleList - препятствия арены в памяти
+type obstacleList []models.Hex
+
+// world создает арену area, на которой заняты клетки препятствий.
+func (o obstacleList) world(area models.Area) *MemoryWorld {
+	world := NewMemoryWorld(area)
+	world.occupy(o, 1)
+	return world
+}
+
+func TestMemoryWorldRoute(t *testing.T) {
+	area := models.Area{Id: 4, Width: 5, Height: 5}
+	// Стена по q=2 с проходом только в r=4
+	wall := obstacleList{{Q: 2, R: 0}, {Q: 2, R: 1}, {Q: 2, R: 2}, {Q: 2, R: 3}}
+
+	tests := []struct {
+		name          string
+		store         obstacleList
+		start, goal   Hex
+		speed         float64
+		expectedSteps int
+		expectedError error
+	}{
+		{name: "Straight path", start: Hex{Q: 0, R: 0}, goal: Hex{Q: 3, R: 0}, speed: 2, expectedSteps: 3},
+		{name: "Path around wall", store: wall, start: Hex{Q: 0, R: 0}, goal: Hex{Q: 4, R: 0}, speed: 2, expectedSteps: 10},
+		{name: "Goal is occupied", store: wall, start: Hex{Q: 0, R: 0}, goal: Hex{Q: 2, R: 1}, speed: 2, expectedError: ErrNoPath},
+		{name: "Wall without gap", store: append(wall, models.Hex{Q: 2, R: 4}), start: Hex{Q: 0, R: 0}, goal: Hex{Q: 4, R: 0}, speed: 2,
+			expectedError: ErrNoPath},
+		{name: "Goal out of area", start: Hex{Q: 0, R: 0}, goal: Hex{Q: 5, R: 0}, speed: 2, expectedError: ErrOutOfArea},
+		{name: "Zero speed", start: Hex{Q: 0, R: 0}, goal: Hex{Q: 1, R: 0}, expectedError: ErrNotValidSpeed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что путь обходит объекты, не выходит за арену, а время зависит от скорости
+			route, err := tt.store.world(area).Route(tt.start, tt.goal, tt.speed)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+			if assert.Len(t, route.Path, tt.expectedSteps) {
+				assert.Equal(t, tt.goal, route.Path[len(route.Path)-1])
+			}
+			for _, h := range route.Path {
+				assert.NotContains(t, tt.store, models.Hex(h))
+				assert.True(t, h.Q >= 0 && h.R >= 0 && h.Q < 5 && h.R < 5, "hex %v out of area", h)
+			}
+			assert.Equal(t, float64(2*tt.expectedSteps), route.Cost)
+			assert.Equal(t, time.Duration(tt.expectedSteps)*time.Second/2, route.TravelTime)
+		})
+	}
+}
+
+func TestUnitSpeed(t *testing.T) {
+	tests := []struct {
+		unitType      string
+		expected      float64
+		expectedError error
+	}{
+		{unitType: "hero", expected: 5},
+		{unitType: "unit", expected: 4},
+		{unitType: "dragon", expected: 2},
+		{unitType: "Goblin", expected: 1},
+		{unitType: "tank", expectedError: ErrUnknownUnitType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unitType, func(t *testing.T) {
+			// Проверяем, что скорость берется из стартовых объектов и шаблонов врагов
+			speed, err := UnitSpeed(tt.unitType)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, speed)
+		})
+	}
+}
+
+func TestMemoryWorldPath(t *testing.T) {
+	area := models.Area{Id: 4, Width: 30, Height: 30}
+	// Клетка (15,15) со всех сторон окружена препятствиями
+	goal := Hex{Q: 15, R: 15}
+	var ring obstacleList
+	for _, n := range goal.Neighbours() {
+		ring = append(ring, models.Hex(n))
+	}
+	world := ring.world(area)
+
+	// Проверяем, что поиск к окруженной цели не выходит за арену и завершается
+	done := make(chan error)
+	go func() {
+		_, err := world.Path(Hex{Q: 0, R: 0}, goal)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrNoPath)
+	case <-time.After(5 * time.Second):
+		t.Fatal("path search to enclosed goal does not finish")
+	}
+
+	_, err := world.Path(Hex{Q: 0, R: 0}, Hex{Q: 29, R: 29})
+	assert.NoError(t, err)
+	_, err = world.Path(Hex{Q: 0, R: 0}, Hex{Q: 30, R: 0})
+	assert.ErrorIs(t, err, ErrOutOfArea)
+}
@@ -0,0 +1,164 @@
+/*
+Планировщик действий.
+Действия, добавленные через GameLogicService, выполняются заданное время с момента начала.
+О каждой смене статуса (PROCESS, затем DONE или NOT_DONE) публикуется событие,
+по окончании статус действия в БД меняется на DONE, после чего вызывается обработчик
+завершенных действий, например начисляющий опыт.
+*/
+
+package game
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"cyber/internal/models"
+)
+
+var ErrSchedulerStopped = errors.New("action scheduler is stopped")
+
+// ActionEvent - событие о смене статуса действия, отправляемое клиенту.
+type ActionEvent struct {
+	Type     string `json:"type"`      // Тип действия - move, harvest, ...
+	UserId   int64  `json:"user_id"`   // Идентификатор пользователя
+	AreaId   int64  `json:"area_id"`   // Идентификатор арены
+	ActionId int64  `json:"action_id"` // Идентификатор действия
+	Status   string `json:"status"`    // PROCESS, DONE или NOT_DONE
+}
+
+// ActionStore - хранилище действий и их статусов. Реализуется *postgress.Storage.
+type ActionStore interface {
+	AddAction(a models.Action) (int64, error)
+	UpdateActionStatus(actionId int64, status string) error
+}
+
+// Scheduler завершает действия по истечении их длительности.
+type Scheduler struct {
+	store  ActionStore
+	notify func(ActionEvent)
+	done   func(models.Action)
+	now    func() time.Time
+	after  func(d time.Duration, f func()) (stop func() bool) // таймер, подменяется в тестах
+
+	mu      sync.Mutex
+	pending map[int64]*pendingAction // действия, ожидающие таймера
+	stopped bool
+	running sync.WaitGroup // запланированные и еще не сохраненные действия
+}
+
+// pendingAction - действие, таймер которого еще не сработал.
+type pendingAction struct {
+	action models.Action
+	stop   func() bool
+}
+
+// Конструктор для Scheduler. notify вызывается при каждой смене статуса действия,
+// done - после успешного завершения действия со статусом DONE. Оба могут быть nil.
+func NewScheduler(store ActionStore, notify func(ActionEvent), done func(models.Action)) *Scheduler {
+	return &Scheduler{
+		store:  store,
+		notify: notify,
+		done:   done,
+		now:    time.Now,
+		after: func(d time.Duration, f func()) func() bool {
+			return time.AfterFunc(d, f).Stop
+		},
+		pending: make(map[int64]*pendingAction),
+	}
+}
+
+// Schedule планирует завершение сохраненного действия a в момент a.StartTime + a.Duration.
+// Действие, время которого уже прошло, завершается сразу.
+func (s *Scheduler) Schedule(a models.Action) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrSchedulerStopped
+	}
+	// Событие публикуется под блокировкой, чтобы оно не обогнало завершение действия
+	s.publish(a, models.ActionInProgress)
+
+	s.running.Add(1)
+	p := &pendingAction{action: a}
+	s.pending[a.Id] = p
+	p.stop = s.after(a.StartTime.Add(a.Duration).Sub(s.now()), func() {
+		s.mu.Lock()
+		delete(s.pending, a.Id)
+		s.mu.Unlock()
+		defer s.running.Done()
+		s.finish(a, models.ActionDone)
+	})
+	return nil
+}
+
+// Add сохраняет новое действие a и планирует его завершение. Возвращает ID действия.
+// Если планировщик уже остановлен, сохраненное действие получает статус NOT_DONE.
+func (s *Scheduler) Add(a models.Action) (int64, error) {
+	a.Status = models.ActionInProgress
+	id, err := s.store.AddAction(a)
+	if err != nil {
+		return 0, err
+	}
+	a.Id = id
+	if err := s.Schedule(a); err != nil {
+		if updateErr := s.store.UpdateActionStatus(id, models.ActionNotDone); updateErr != nil {
+			log.Printf("Cant mark action ID- %v as not done: %v\n", id, updateErr)
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// Shutdown останавливает прием новых действий. Действия, таймер которых еще не сработал,
+// прерываются со статусом NOT_DONE. Затем Shutdown ждет, пока уже завершающиеся
+// действия сохранят статус, или пока не будет отменен ctx.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	var interrupted []models.Action
+	for id, p := range s.pending {
+		if p.stop() {
+			interrupted = append(interrupted, p.action)
+			delete(s.pending, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, a := range interrupted {
+		s.finish(a, models.ActionNotDone)
+		s.running.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// finish сохраняет итоговый статус действия и сообщает о нем клиентам.
+func (s *Scheduler) finish(a models.Action, status string) {
+	if err := s.store.UpdateActionStatus(a.Id, status); err != nil {
+		log.Printf("Cant finish action ID- %v: %v\n", a.Id, err)
+		status = models.ActionNotDone
+	}
+	s.publish(a, status)
+	if status == models.ActionDone && s.done != nil {
+		s.done(a)
+	}
+}
+
+func (s *Scheduler) publish(a models.Action, status string) {
+	if s.notify != nil {
+		s.notify(ActionEvent{Type: a.ActionType, UserId: a.UserId, AreaId: a.AreaId, ActionId: a.Id, Status: status})
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// fakeActionStore хранит действия и их статусы в памяти
+type fakeActionStore struct {
+	statuses map[int64]string
+	added    []models.Action
+}
+
+func (s *fakeActionStore) AddAction(a models.Action) (int64, error) {
+	a.Id = int64(len(s.added) + 1)
+	s.added = append(s.added, a)
+	s.statuses[a.Id] = a.Status
+	return a.Id, nil
+}
+
+func (s *fakeActionStore) UpdateActionStatus(actionId int64, status string) error {
+	s.statuses[actionId] = status
+	return nil
+}
+
+// testTimer - таймер, запущенный планировщиком в тесте
+type testTimer struct {
+	d time.Duration
+	f func()
+}
+
+func newTestScheduler(store *fakeActionStore) (*Scheduler, *[]ActionEvent, *[]testTimer, *[]int64) {
+	var events []ActionEvent
+	var timers []testTimer
+	var done []int64
+	s := NewScheduler(store, func(e ActionEvent) { events = append(events, e) }, func(a models.Action) { done = append(done, a.Id) })
+	s.now = func() time.Time { return time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) }
+	s.after = func(d time.Duration, f func()) func() bool {
+		timers = append(timers, testTimer{d, f})
+		return func() bool { return true }
+	}
+	return s, &events, &timers, &done
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+	store := &fakeActionStore{statuses: map[int64]string{}}
+	s, events, timers, done := newTestScheduler(store)
+	start := s.now().Add(-10 * time.Second)
+
+	assert.NoError(t, s.Schedule(models.Action{Id: 7, UserId: 1, AreaId: 4, ActionType: "move", StartTime: start, Duration: time.Minute}))
+
+	// Проверяем, что действие завершается через оставшуюся часть длительности
+	if assert.Len(t, *timers, 1) {
+		assert.Equal(t, 50*time.Second, (*timers)[0].d)
+		(*timers)[0].f()
+	}
+	assert.Equal(t, models.ActionDone, store.statuses[7])
+	assert.Equal(t, []ActionEvent{
+		{Type: "move", UserId: 1, AreaId: 4, ActionId: 7, Status: models.ActionInProgress},
+		{Type: "move", UserId: 1, AreaId: 4, ActionId: 7, Status: models.ActionDone},
+	}, *events)
+	assert.Empty(t, s.pending)
+	// Проверяем, что завершенное действие передается обработчику
+	assert.Equal(t, []int64{7}, *done)
+}
+
+func TestSchedulerShutdownInterruptsPending(t *testing.T) {
+	store := &fakeActionStore{statuses: map[int64]string{}}
+	s, events, _, done := newTestScheduler(store)
+
+	assert.NoError(t, s.Schedule(models.Action{Id: 7, AreaId: 4, ActionType: "build", StartTime: s.now(), Duration: time.Hour}))
+
+	// Проверяем, что незавершенное действие прерывается, а новые действия не принимаются
+	assert.NoError(t, s.Shutdown(context.Background()))
+	assert.Equal(t, models.ActionNotDone, store.statuses[7])
+	if assert.Len(t, *events, 2) {
+		assert.Equal(t, ActionEvent{Type: "build", AreaId: 4, ActionId: 7, Status: models.ActionNotDone}, (*events)[1])
+	}
+
+	assert.ErrorIs(t, s.Schedule(models.Action{Id: 8}), ErrSchedulerStopped)
+	// Проверяем, что прерванное действие не считается выполненным
+	assert.Empty(t, *done)
+}
+
+func TestSchedulerAdd(t *testing.T) {
+	store := &fakeActionStore{statuses: map[int64]string{}}
+	s, events, timers, done := newTestScheduler(store)
+
+	id, err := s.Add(models.Action{UserId: 1, AreaId: 4, ObjectSourceId: 5, ActionType: "harvest", StartTime: s.now(), Duration: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+
+	// Проверяем, что новое действие сохраняется со статусом PROCESS и завершается по таймеру
+	if assert.Len(t, store.added, 1) {
+		assert.Equal(t, models.ActionInProgress, store.added[0].Status)
+	}
+	if assert.Len(t, *timers, 1) {
+		assert.Equal(t, time.Minute, (*timers)[0].d)
+		(*timers)[0].f()
+	}
+	assert.Equal(t, models.ActionDone, store.statuses[1])
+	assert.Len(t, *events, 2)
+	assert.Equal(t, []int64{1}, *done)
+
+	// Проверяем, что действие, сохраненное после остановки, не остается в статусе PROCESS
+	assert.NoError(t, s.Shutdown(context.Background()))
+	_, err = s.Add(models.Action{UserId: 1, AreaId: 4, ActionType: "build", StartTime: s.now()})
+	assert.ErrorIs(t, err, ErrSchedulerStopped)
+	assert.Equal(t, models.ActionNotDone, store.statuses[2])
+}
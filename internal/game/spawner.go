@@ -0,0 +1,512 @@
+/*
+Появление врагов на арене.
+При создании мира на арене размещаются лагеря нейтральных врагов, а во время игры
+спавнер периодически отправляет волны врагов на здания игрока. Сила и количество врагов
+растут с уровнем пользователя (User.Level) и авторитетом его лиги (League.Authority).
+Зачищенный лагерь появляется снова после перезарядки.
+Все созданные враги сохраняются в БД и связываются с ареной через areas_enemies.
+*/
+
+package game
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+var (
+	ErrNoFreePlace = errors.New("no free place for enemies")
+	ErrNoTemplate  = errors.New("no enemy template for level")
+)
+
+// EnemyTemplate - базовые характеристики врага на 1 уровне сложности.
+type EnemyTemplate struct {
+	Name            string                      // Название врага
+	MinLevel        int                         // Минимальный уровень пользователя, с которого появляется враг
+	Characteristics models.EnemyCharacteristics // Характеристики врага
+}
+
+// Difficulty - сложность арены.
+type Difficulty struct {
+	Level     int // Уровень пользователя
+	Authority int // Авторитет лиги пользователя
+}
+
+// Конструктор для Difficulty
+func NewDifficulty(user models.User, league models.League) Difficulty {
+	return Difficulty{Level: user.Level, Authority: league.Authority}
+}
+
+// SpawnConfig - настройки появления врагов.
+type SpawnConfig struct {
+	Templates       []EnemyTemplate // Доступные враги
+	Camps           int             // Количество лагерей на арене
+	CampSize        int             // Количество врагов в лагере на 1 уровне сложности
+	CampRadius      int             // Радиус лагеря вокруг его центра
+	SafeRadius      int             // Минимальное расстояние от лагеря до объектов игрока
+	WaveSize        int             // Количество врагов в волне на 1 уровне сложности
+	WaveInterval    time.Duration   // Интервал между волнами (0 - волн нет)
+	RespawnCooldown time.Duration   // Время до повторного появления зачищенного лагеря
+	LevelStep       float64         // Прирост силы врагов за каждый уровень пользователя
+	AuthorityStep   float64         // Прирост силы врагов за каждую единицу авторитета лиги
+}
+
+// Factor возвращает множитель силы и количества врагов для сложности d.
+func (c SpawnConfig) Factor(d Difficulty) float64 {
+	level := d.Level
+	if level < 1 {
+		level = 1
+	}
+	authority := d.Authority
+	if authority < 0 {
+		authority = 0
+	}
+	return 1 + float64(level-1)*c.LevelStep + float64(authority)*c.AuthorityStep
+}
+
+// HARDCODE DefaultSpawnConfig - настройки появления врагов по умолчанию
+var DefaultSpawnConfig = SpawnConfig{
+	Templates: []EnemyTemplate{
+		{
+			Name:     "Goblin",
+			MinLevel: 1,
+			Characteristics: models.EnemyCharacteristics{
+				HP: 100, Armor: 2, Speed: decimal.NewFromFloat(1), Vision: 6,
+				AtackRange: decimal.NewFromFloat(1), Damage: decimal.NewFromFloat(10), Experience: decimal.NewFromFloat(20),
+			},
+		},
+		{
+			Name:     "Orc",
+			MinLevel: 3,
+			Characteristics: models.EnemyCharacteristics{
+				HP: 200, Armor: 6, Speed: decimal.NewFromFloat(1), Vision: 7,
+				AtackRange: decimal.NewFromFloat(1), Damage: decimal.NewFromFloat(20), Experience: decimal.NewFromFloat(50),
+			},
+		},
+		{
+			Name:     "Dragon",
+			MinLevel: 10,
+			Characteristics: models.EnemyCharacteristics{
+				HP: 500, Armor: 15, Speed: decimal.NewFromFloat(2), Vision: 10, IsRange: true,
+				AtackRange: decimal.NewFromFloat(3), Damage: decimal.NewFromFloat(50), Experience: decimal.NewFromFloat(200),
+			},
+		},
+	},
+	Camps:           3,
+	CampSize:        3,
+	CampRadius:      2,
+	SafeRadius:      15,
+	WaveSize:        4,
+	WaveInterval:    15 * time.Minute,
+	RespawnCooldown: 10 * time.Minute,
+	LevelStep:       0.1,
+	AuthorityStep:   0.01,
+}
+
+// EnemyStore - хранилище врагов арены. Реализуется *postgress.Storage.
+type EnemyStore interface {
+	AddEnemy(e models.Enemy) (int64, error)
+	AddEnemyAtArea(enemyId, areaId int64, camp int) error
+	DeleteEnemy(enemyId int64) error
+}
+
+// Camp - лагерь врагов.
+type Camp struct {
+	Id        int       // Номер лагеря на арене
+	Center    Hex       // Центр лагеря
+	Enemies   []int64   // Идентификаторы врагов лагеря
+	ClearedAt time.Time // Время зачистки лагеря, нулевое - лагерь не зачищен
+}
+
+// Spawner размещает врагов на одной арене.
+type Spawner struct {
+	world      *MemoryWorld
+	config     SpawnConfig
+	difficulty Difficulty
+	store      EnemyStore
+	rng        *rand.Rand
+	ai         *AI
+
+	camps    []*Camp
+	lastWave time.Time
+}
+
+// Конструктор для Spawner. ai может быть nil, тогда враги волны не получают цель штурма.
+func NewSpawner(world *MemoryWorld, config SpawnConfig, difficulty Difficulty, store EnemyStore, rng *rand.Rand, ai *AI) *Spawner {
+	return &Spawner{
+		world:      world,
+		config:     config,
+		difficulty: difficulty,
+		store:      store,
+		rng:        rng,
+		ai:         ai,
+	}
+}
+
+// Camps возвращает копию лагерей арены.
+func (s *Spawner) Camps() []Camp {
+	camps := make([]Camp, 0, len(s.camps))
+	for _, c := range s.camps {
+		camp := *c
+		camp.Enemies = append([]int64(nil), c.Enemies...)
+		camps = append(camps, camp)
+	}
+	return camps
+}
+
+// SpawnCamps размещает лагеря врагов вдали от объектов игрока.
+func (s *Spawner) SpawnCamps() error {
+	for i := 0; i < s.config.Camps; i++ {
+		center, ok := s.findCampCenter()
+		if !ok {
+			return ErrNoFreePlace
+		}
+		camp := &Camp{Id: len(s.camps) + 1, Center: center}
+		s.camps = append(s.camps, camp)
+		if err := s.fillCamp(camp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Wave отправляет волну врагов с края арены на случайное здание игрока.
+// Если зданий на арене нет, волна не появляется.
+func (s *Spawner) Wave() ([]int64, error) {
+	target, ok := s.waveTarget()
+	if !ok {
+		return nil, nil
+	}
+	start := s.edgeHex(target.Position)
+
+	var ids []int64
+	for _, at := range s.freeAround(start, s.count(s.config.WaveSize)) {
+		id, err := s.spawn(at, 0)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+		if s.ai != nil {
+			s.ai.Assault(id, target)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoFreePlace
+	}
+	return ids, nil
+}
+
+// Tick отмечает зачищенные лагеря, возрождает лагеря после перезарядки
+// и отправляет волну, если с прошлой волны прошло WaveInterval.
+func (s *Spawner) Tick(now time.Time) error {
+	for _, camp := range s.camps {
+		if camp.ClearedAt.IsZero() {
+			if s.cleared(camp) {
+				camp.ClearedAt = now
+			}
+			continue
+		}
+		if now.Sub(camp.ClearedAt) >= s.config.RespawnCooldown {
+			if err := s.fillCamp(camp); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.config.WaveInterval <= 0 {
+		return nil
+	}
+	if s.lastWave.IsZero() {
+		s.lastWave = now
+		return nil
+	}
+	if now.Sub(s.lastWave) >= s.config.WaveInterval {
+		s.lastWave = now
+		if _, err := s.Wave(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreCamps восстанавливает лагеря арены, загруженной из БД, по номерам лагерей ее врагов.
+// Центром лагеря считается позиция его первого врага. Оставшиеся враги волн лагерями не становятся:
+// они снова идут на штурм здания игрока, а если зданий не осталось - удаляются с арены и из БД.
+func (s *Spawner) RestoreCamps() error {
+	ids := make([]int64, 0, len(s.world.Enemies))
+	for id := range s.world.Enemies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	s.camps = nil
+	camps := make(map[int]*Camp)
+	for _, id := range ids {
+		e := s.world.Enemies[id]
+		if len(e.Coordinates) == 0 {
+			continue
+		}
+		if e.Camp == 0 {
+			if err := s.restoreWave(e); err != nil {
+				return err
+			}
+			continue
+		}
+		camp, ok := camps[e.Camp]
+		if !ok {
+			camp = &Camp{Id: e.Camp, Center: Hex(e.Coordinates[0])}
+			camps[e.Camp] = camp
+			s.camps = append(s.camps, camp)
+		}
+		camp.Enemies = append(camp.Enemies, id)
+	}
+	sort.Slice(s.camps, func(i, j int) bool { return s.camps[i].Id < s.camps[j].Id })
+	return nil
+}
+
+// restoreWave снова отправляет оставшегося врага волны на штурм здания игрока.
+// Если зданий на арене нет, волна закончена и враг удаляется.
+func (s *Spawner) restoreWave(e *models.Enemy) error {
+	if target, ok := s.waveTarget(); ok {
+		if s.ai != nil {
+			s.ai.Assault(e.Id, target)
+		}
+		return nil
+	}
+	if err := s.store.DeleteEnemy(e.Id); err != nil {
+		return err
+	}
+	s.world.RemoveEnemy(e.Id)
+	return nil
+}
+
+// Run выполняет Tick с интервалом interval, пока не будет отменен ctx.
+// Каждый тик выполняется под блокировкой арены.
+func (s *Spawner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.world.Lock()
+			err := s.Tick(now)
+			s.world.Unlock()
+			if err != nil {
+				log.Printf("Spawner error at area ID- %v: %v\n", s.world.AreaId, err)
+			}
+		}
+	}
+}
+
+// fillCamp создает врагов лагеря вокруг его центра.
+func (s *Spawner) fillCamp(camp *Camp) error {
+	camp.Enemies = nil
+	camp.ClearedAt = time.Time{}
+	for _, at := range s.freeAround(camp.Center, s.count(s.config.CampSize)) {
+		id, err := s.spawn(at, camp.Id)
+		if err != nil {
+			return err
+		}
+		camp.Enemies = append(camp.Enemies, id)
+	}
+	if len(camp.Enemies) == 0 {
+		return ErrNoFreePlace
+	}
+	return nil
+}
+
+// cleared проверяет, что на арене не осталось врагов лагеря.
+func (s *Spawner) cleared(camp *Camp) bool {
+	for _, id := range camp.Enemies {
+		if _, ok := s.world.Enemies[id]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// spawn создает врага лагеря camp (0 - врага волны) на гексе at, сохраняет его и связывает с ареной.
+func (s *Spawner) spawn(at Hex, camp int) (int64, error) {
+	enemy, err := s.newEnemy(at)
+	if err != nil {
+		return 0, err
+	}
+	enemy.Camp = camp
+	id, err := s.store.AddEnemy(enemy)
+	if err != nil {
+		log.Printf("Failed when creating enemy: %v\n", err)
+		return 0, err
+	}
+	if err := s.store.AddEnemyAtArea(id, s.world.AreaId, camp); err != nil {
+		return 0, err
+	}
+	enemy.Id = id
+	s.world.AddEnemy(enemy)
+	return id, nil
+}
+
+// newEnemy выбирает случайный шаблон, доступный на уровне сложности, и усиливает его.
+func (s *Spawner) newEnemy(at Hex) (models.Enemy, error) {
+	var available []EnemyTemplate
+	for _, t := range s.config.Templates {
+		if t.MinLevel <= s.difficulty.Level || t.MinLevel <= 1 {
+			available = append(available, t)
+		}
+	}
+	if len(available) == 0 {
+		return models.Enemy{}, ErrNoTemplate
+	}
+	template := available[s.rng.Intn(len(available))]
+
+	level := s.difficulty.Level
+	if level < 1 {
+		level = 1
+	}
+	factor := s.config.Factor(s.difficulty)
+	c := template.Characteristics
+	c.HP = int(math.Round(float64(c.HP) * factor))
+	c.Armor = int(math.Round(float64(c.Armor) * factor))
+	c.Damage = c.Damage.Mul(decimal.NewFromFloat(factor)).Round(2)
+	c.Experience = c.Experience.Mul(decimal.NewFromFloat(factor)).Round(2)
+	c.Level = level
+
+	return models.Enemy{
+		Name:             template.Name,
+		Charachteristics: c,
+		Level:            level,
+		Coordinates:      []models.Hex{models.Hex(at)},
+	}, nil
+}
+
+// count возвращает количество врагов с учетом сложности.
+func (s *Spawner) count(base int) int {
+	n := int(math.Round(float64(base) * s.config.Factor(s.difficulty)))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// findCampCenter ищет случайный свободный гекс не ближе SafeRadius к объектам игрока и другим лагерям.
+func (s *Spawner) findCampCenter() (Hex, bool) {
+	const attempts = 200
+	margin := s.config.CampRadius
+	if s.world.Width <= 2*margin || s.world.Height <= 2*margin {
+		return Hex{}, false
+	}
+	for i := 0; i < attempts; i++ {
+		h := Hex{
+			Q: float64(margin + s.rng.Intn(s.world.Width-2*margin)),
+			R: float64(margin + s.rng.Intn(s.world.Height-2*margin)),
+		}
+		if s.world.Blocked(h) || !s.safe(h) {
+			continue
+		}
+		return h, true
+	}
+	return Hex{}, false
+}
+
+// safe проверяет, что гекс достаточно далеко от объектов игрока и других лагерей.
+func (s *Spawner) safe(h Hex) bool {
+	for _, p := range s.playerHexes() {
+		if h.Distance(p) < s.config.SafeRadius {
+			return false
+		}
+	}
+	for _, c := range s.camps {
+		if h.Distance(c.Center) <= 2*s.config.CampRadius {
+			return false
+		}
+	}
+	return true
+}
+
+// playerHexes возвращает все гексы, занятые объектами игрока.
+func (s *Spawner) playerHexes() []Hex {
+	var hexes []Hex
+	for _, b := range s.world.Buildings {
+		for _, c := range b.Coordinates {
+			hexes = append(hexes, Hex(c))
+		}
+	}
+	for _, t := range s.world.Targets() {
+		hexes = append(hexes, t.Position)
+	}
+	return hexes
+}
+
+// freeAround возвращает до n свободных гексов, ближайших к center (включая сам center).
+func (s *Spawner) freeAround(center Hex, n int) []Hex {
+	radius := s.config.CampRadius
+	if radius < 1 {
+		radius = 1
+	}
+	var free []Hex
+	for dq := -radius; dq <= radius; dq++ {
+		for dr := -radius; dr <= radius; dr++ {
+			h := Hex{Q: center.Q + float64(dq), R: center.R + float64(dr)}
+			if center.Distance(h) <= radius && !s.world.Blocked(h) {
+				free = append(free, h)
+			}
+		}
+	}
+	sort.Slice(free, func(i, j int) bool {
+		di, dj := center.Distance(free[i]), center.Distance(free[j])
+		if di != dj {
+			return di < dj
+		}
+		if free[i].Q != free[j].Q {
+			return free[i].Q < free[j].Q
+		}
+		return free[i].R < free[j].R
+	})
+	if len(free) > n {
+		free = free[:n]
+	}
+	return free
+}
+
+// waveTarget выбирает случайное здание игрока.
+func (s *Spawner) waveTarget() (Target, bool) {
+	ids := make([]int64, 0, len(s.world.Buildings))
+	for id, b := range s.world.Buildings {
+		if len(b.Coordinates) > 0 && b.Charachteristics.HP > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return Target{}, false
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return s.world.Target(KindBuilding, ids[s.rng.Intn(len(ids))])
+}
+
+// edgeHex возвращает гекс на краю арены, наиболее удаленном от target.
+func (s *Spawner) edgeHex(target Hex) Hex {
+	maxQ, maxR := float64(s.world.Width-1), float64(s.world.Height-1)
+	edges := []Hex{
+		{Q: 0, R: target.R},
+		{Q: maxQ, R: target.R},
+		{Q: target.Q, R: 0},
+		{Q: target.Q, R: maxR},
+	}
+	best := edges[0]
+	for _, e := range edges[1:] {
+		if target.Distance(e) > target.Distance(best) {
+			best = e
+		}
+	}
+	return best
+}
@@ -0,0 +1,282 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// fakeEnemyStore выдает врагам последовательные ID и запоминает связи с аренами и лагерями
+type fakeEnemyStore struct {
+	nextId  int64
+	enemies []models.Enemy
+	links   map[int64]int64
+	camps   map[int64]int
+	deleted []int64
+	err     error
+}
+
+func newFakeEnemyStore() *fakeEnemyStore {
+	return &fakeEnemyStore{nextId: 100, links: make(map[int64]int64), camps: make(map[int64]int)}
+}
+
+func (s *fakeEnemyStore) AddEnemy(e models.Enemy) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.nextId++
+	s.enemies = append(s.enemies, e)
+	return s.nextId, nil
+}
+
+func (s *fakeEnemyStore) AddEnemyAtArea(enemyId, areaId int64, camp int) error {
+	s.links[enemyId] = areaId
+	s.camps[enemyId] = camp
+	return nil
+}
+
+func (s *fakeEnemyStore) DeleteEnemy(enemyId int64) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.deleted = append(s.deleted, enemyId)
+	return nil
+}
+
+func testSpawnConfig() SpawnConfig {
+	config := DefaultSpawnConfig
+	config.Camps = 2
+	config.CampSize = 3
+	config.CampRadius = 2
+	config.SafeRadius = 5
+	config.WaveSize = 2
+	config.WaveInterval = time.Minute
+	config.RespawnCooldown = 5 * time.Minute
+	return config
+}
+
+func newSpawnWorld() *MemoryWorld {
+	world := NewMemoryWorld(models.Area{Id: 3, Width: 40, Height: 40})
+	world.AddBuilding(models.Building{
+		Id:               1,
+		Name:             "CyMan miner house",
+		Charachteristics: models.BuildingCharacteristics{HP: 100, Armor: 0},
+		Coordinates:      []models.Hex{{Q: 20, R: 20}, {Q: 21, R: 20}, {Q: 20, R: 21}, {Q: 21, R: 21}},
+	})
+	world.AddUnit(testUnit(5, Hex{Q: 18, R: 20}, 80))
+	return world
+}
+
+func TestSpawnerFactor(t *testing.T) {
+	config := testSpawnConfig()
+
+	assert.Equal(t, 1.0, config.Factor(Difficulty{Level: 1}))
+	assert.Equal(t, 1.0, config.Factor(Difficulty{}))
+	assert.InDelta(t, 1.9+0.5, config.Factor(Difficulty{Level: 10, Authority: 50}), 1e-9)
+	assert.Greater(t, config.Factor(Difficulty{Level: 5, Authority: 10}), config.Factor(Difficulty{Level: 5}))
+}
+
+func TestSpawnCamps(t *testing.T) {
+	world := newSpawnWorld()
+	store := newFakeEnemyStore()
+	spawner := NewSpawner(world, testSpawnConfig(), Difficulty{Level: 1}, store, rand.New(rand.NewSource(1)), nil)
+
+	assert.NoError(t, spawner.SpawnCamps())
+
+	camps := spawner.Camps()
+	assert.Len(t, camps, 2)
+	assert.Len(t, world.Enemies, 6)
+	assert.Len(t, store.links, 6)
+	for _, camp := range camps {
+		assert.Len(t, camp.Enemies, 3)
+		// Проверяем, что лагерь не появился рядом с объектами игрока
+		assert.GreaterOrEqual(t, camp.Center.Distance(Hex{Q: 20, R: 20}), 5)
+		assert.GreaterOrEqual(t, camp.Center.Distance(Hex{Q: 18, R: 20}), 5)
+		for _, id := range camp.Enemies {
+			e, ok := world.Enemies[id]
+			if assert.True(t, ok) {
+				assert.True(t, world.InBounds(Hex(e.Coordinates[0])))
+				assert.LessOrEqual(t, camp.Center.Distance(Hex(e.Coordinates[0])), 2)
+			}
+			assert.Equal(t, int64(3), store.links[id])
+			assert.Equal(t, camp.Id, store.camps[id])
+		}
+	}
+
+	// Проверяем, что враги не стоят на одной клетке
+	seen := make(map[models.Hex]bool)
+	for _, e := range world.Enemies {
+		assert.False(t, seen[e.Coordinates[0]], "two enemies on %v", e.Coordinates[0])
+		seen[e.Coordinates[0]] = true
+	}
+}
+
+func TestSpawnerDifficultyScaling(t *testing.T) {
+	spawn := func(d Difficulty) (*MemoryWorld, *fakeEnemyStore) {
+		world := newSpawnWorld()
+		store := newFakeEnemyStore()
+		config := testSpawnConfig()
+		config.Templates = config.Templates[:1]
+		spawner := NewSpawner(world, config, d, store, rand.New(rand.NewSource(1)), nil)
+		assert.NoError(t, spawner.SpawnCamps())
+		return world, store
+	}
+
+	_, easy := spawn(Difficulty{Level: 1})
+	_, hard := spawn(Difficulty{Level: 11, Authority: 100})
+
+	// Сложность 1 + 10*0.1 + 100*0.01 = 3: втрое больше врагов и втрое сильнее
+	assert.Len(t, easy.enemies, 6)
+	assert.Len(t, hard.enemies, 18)
+	assert.Equal(t, 100, easy.enemies[0].Charachteristics.HP)
+	assert.Equal(t, 300, hard.enemies[0].Charachteristics.HP)
+	assert.True(t, hard.enemies[0].Charachteristics.Damage.Equal(easy.enemies[0].Charachteristics.Damage.Mul(decimal.NewFromInt(3))))
+	assert.Equal(t, 11, hard.enemies[0].Level)
+}
+
+func TestSpawnerTemplatesByLevel(t *testing.T) {
+	world := newSpawnWorld()
+	store := newFakeEnemyStore()
+	spawner := NewSpawner(world, testSpawnConfig(), Difficulty{Level: 1}, store, rand.New(rand.NewSource(3)), nil)
+	assert.NoError(t, spawner.SpawnCamps())
+	for _, e := range store.enemies {
+		assert.Equal(t, "Goblin", e.Name, "only level 1 enemies expected")
+	}
+
+	config := testSpawnConfig()
+	config.Templates = []EnemyTemplate{{Name: "Dragon", MinLevel: 10}}
+	spawner = NewSpawner(newSpawnWorld(), config, Difficulty{Level: 2}, newFakeEnemyStore(), rand.New(rand.NewSource(3)), nil)
+	assert.ErrorIs(t, spawner.SpawnCamps(), ErrNoTemplate)
+}
+
+func TestSpawnerRespawnAfterCooldown(t *testing.T) {
+	world := newSpawnWorld()
+	store := newFakeEnemyStore()
+	config := testSpawnConfig()
+	config.WaveInterval = 0
+	spawner := NewSpawner(world, config, Difficulty{Level: 1}, store, rand.New(rand.NewSource(1)), nil)
+	assert.NoError(t, spawner.SpawnCamps())
+
+	start := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+	camp := spawner.Camps()[0]
+	for _, id := range camp.Enemies {
+		world.RemoveEnemy(id)
+	}
+
+	// Лагерь зачищен, но перезарядка еще не прошла
+	assert.NoError(t, spawner.Tick(start))
+	assert.Equal(t, start, spawner.Camps()[0].ClearedAt)
+	assert.NoError(t, spawner.Tick(start.Add(4*time.Minute)))
+	assert.Len(t, world.Enemies, 3)
+
+	assert.NoError(t, spawner.Tick(start.Add(5*time.Minute)))
+	respawned := spawner.Camps()[0]
+	assert.True(t, respawned.ClearedAt.IsZero())
+	assert.Len(t, respawned.Enemies, 3)
+	assert.NotEqual(t, camp.Enemies, respawned.Enemies)
+	assert.Len(t, world.Enemies, 6)
+	assert.Len(t, store.links, 9)
+}
+
+func TestSpawnerRestoreCamps(t *testing.T) {
+	world := newSpawnWorld()
+	config := testSpawnConfig()
+	config.WaveInterval = 0
+	spawner := NewSpawner(world, config, Difficulty{Level: 1}, newFakeEnemyStore(), rand.New(rand.NewSource(1)), nil)
+	assert.NoError(t, spawner.SpawnCamps())
+
+	// Проверяем, что после загрузки арены лагеря восстанавливаются с теми же врагами
+	restored := NewSpawner(world, config, Difficulty{Level: 1}, newFakeEnemyStore(), rand.New(rand.NewSource(2)), nil)
+	assert.NoError(t, restored.RestoreCamps())
+	camps := restored.Camps()
+	if assert.Len(t, camps, 2) {
+		var expected, got [][]int64
+		for i, camp := range spawner.Camps() {
+			expected = append(expected, camp.Enemies)
+			got = append(got, camps[i].Enemies)
+		}
+		assert.ElementsMatch(t, expected, got)
+	}
+
+	// Проверяем, что восстановленный лагерь возрождается после зачистки
+	for _, id := range camps[0].Enemies {
+		world.RemoveEnemy(id)
+	}
+	start := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, restored.Tick(start))
+	assert.NoError(t, restored.Tick(start.Add(config.RespawnCooldown)))
+	assert.Len(t, restored.Camps()[0].Enemies, 3)
+	assert.Len(t, world.Enemies, 6)
+}
+
+func TestSpawnerRestoreWave(t *testing.T) {
+	world := newSpawnWorld()
+	store := newFakeEnemyStore()
+	config := testSpawnConfig()
+	config.Camps = 0
+	spawner := NewSpawner(world, config, Difficulty{Level: 1}, store, rand.New(rand.NewSource(1)), nil)
+	start := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, spawner.Tick(start))
+	assert.NoError(t, spawner.Tick(start.Add(time.Minute)))
+	assert.Len(t, world.Enemies, 2)
+	for id := range world.Enemies {
+		assert.Equal(t, 0, store.camps[id])
+	}
+
+	// Проверяем, что враги волны не становятся лагерем и снова идут на штурм здания
+	ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(1)), nil)
+	restored := NewSpawner(world, config, Difficulty{Level: 1}, newFakeEnemyStore(), rand.New(rand.NewSource(2)), ai)
+	assert.NoError(t, restored.RestoreCamps())
+	assert.Empty(t, restored.Camps())
+	assert.Len(t, world.Enemies, 2)
+	for id := range world.Enemies {
+		if assert.Contains(t, ai.brains, id) {
+			assert.True(t, ai.brains[id].assault)
+			assert.Equal(t, int64(1), ai.brains[id].objective.Id)
+		}
+	}
+
+	// Проверяем, что без зданий оставшиеся враги волны удаляются с арены и из БД
+	delete(world.Buildings, 1)
+	cleanup := newFakeEnemyStore()
+	restored = NewSpawner(world, config, Difficulty{Level: 1}, cleanup, rand.New(rand.NewSource(2)), nil)
+	assert.NoError(t, restored.RestoreCamps())
+	assert.Empty(t, world.Enemies)
+	assert.Len(t, cleanup.deleted, 2)
+}
+
+func TestSpawnerWaveAssaultsBuilding(t *testing.T) {
+	world := newSpawnWorld()
+	delete(world.Units, 5)
+	store := newFakeEnemyStore()
+	ai := NewAI(world, testBehaviours, nil, rand.New(rand.NewSource(1)), nil)
+	config := testSpawnConfig()
+	config.Camps = 0
+	spawner := NewSpawner(world, config, Difficulty{Level: 1}, store, rand.New(rand.NewSource(1)), ai)
+
+	start := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, spawner.Tick(start))
+	assert.Empty(t, world.Enemies)
+	assert.NoError(t, spawner.Tick(start.Add(time.Minute)))
+	assert.Len(t, world.Enemies, 2)
+
+	// Враги волны идут к зданию через всю арену и разрушают его
+	for i := 0; i < 100 && len(world.Buildings) > 0; i++ {
+		ai.Tick()
+	}
+	assert.Empty(t, world.Buildings)
+}
+
+func TestSpawnerStoreError(t *testing.T) {
+	store := newFakeEnemyStore()
+	store.err = errors.New("database error")
+	spawner := NewSpawner(newSpawnWorld(), testSpawnConfig(), Difficulty{Level: 1}, store, rand.New(rand.NewSource(1)), nil)
+
+	assert.Error(t, spawner.SpawnCamps())
+}
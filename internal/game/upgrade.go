@@ -0,0 +1,321 @@
+/*
+Улучшение зданий.
+Стоимость улучшения (Building.UpgradePrice) списывается с ресурсов пользователя
+через ledger в момент начала улучшения, после чего улучшение идет заданное время. По окончании
+уровень и характеристики здания растут согласно таблице улучшений,
+а стоимость следующего улучшения пересчитывается.
+*/
+
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/ledger"
+	"cyber/internal/models"
+)
+
+var (
+	ErrUpgradeInProgress = errors.New("building upgrade already in progress")
+	ErrUpgradeMaxLevel   = errors.New("building has max level")
+	ErrUnknownBuilding   = errors.New("no upgrade rules for building")
+	ErrUpgraderStopped   = errors.New("upgrades are stopped")
+	ErrNoUpgradePrice    = errors.New("building has no upgrade price")
+)
+
+// BuildingUpgrade описывает правила улучшения одного типа зданий.
+type BuildingUpgrade struct {
+	Duration                time.Duration   // Длительность улучшения на 1 уровне
+	DurationPerLevel        time.Duration   // Прирост длительности с каждым уровнем
+	HP                      int             // Прирост здоровья
+	Armor                   int             // Прирост брони
+	ProductivityCoefficient int             // Прирост коэффициента производительности
+	PriceFactor             decimal.Decimal // Множитель стоимости следующего улучшения
+	MaxLevel                int             // Максимальный уровень здания
+}
+
+// DurationFor возвращает длительность улучшения здания с уровня level.
+func (u BuildingUpgrade) DurationFor(level int) time.Duration {
+	if level < 1 {
+		level = 1
+	}
+	return u.Duration + time.Duration(level-1)*u.DurationPerLevel
+}
+
+// UpgradeTable - правила улучшения по названию здания.
+type UpgradeTable map[string]BuildingUpgrade
+
+// HARDCODE DefaultUpgradeTable - таблица улучшений зданий по умолчанию.
+// Покрывает все здания DefaultBuildingCatalog.
+var DefaultUpgradeTable = UpgradeTable{
+	"CyMan miner house": {
+		Duration:                time.Minute,
+		DurationPerLevel:        30 * time.Second,
+		HP:                      100,
+		Armor:                   2,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromFloat(1.5),
+		MaxLevel:                10,
+	},
+	"Town Hall": {
+		Duration:                5 * time.Minute,
+		DurationPerLevel:        2 * time.Minute,
+		HP:                      200,
+		Armor:                   10,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromFloat(2),
+		MaxLevel:                10,
+	},
+	"Barracks": {
+		Duration:                3 * time.Minute,
+		DurationPerLevel:        time.Minute,
+		HP:                      150,
+		Armor:                   5,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromFloat(1.5),
+		MaxLevel:                10,
+	},
+	"Farm": {
+		Duration:                time.Minute,
+		DurationPerLevel:        30 * time.Second,
+		HP:                      100,
+		Armor:                   2,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromFloat(1.5),
+		MaxLevel:                10,
+	},
+	"Large Castle": {
+		Duration:                10 * time.Minute,
+		DurationPerLevel:        5 * time.Minute,
+		HP:                      400,
+		Armor:                   20,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromFloat(2),
+		MaxLevel:                5,
+	},
+}
+
+// UpgradeEvent - событие о ходе улучшения здания, отправляемое клиенту.
+type UpgradeEvent struct {
+	Type       string `json:"type"`        // Тип события - upgrade
+	AreaId     int64  `json:"area_id"`     // Идентификатор арены
+	BuildingId int64  `json:"building_id"` // Идентификатор здания
+	Level      int    `json:"level"`       // Уровень здания
+	Message    string `json:"message"`     // processing, successfuly complete или failed
+}
+
+// UpgradeStore - хранилище, используемое для улучшения зданий. Реализуется *postgress.Storage.
+type UpgradeStore interface {
+	GetBuilding(buildingId int64) (models.Building, error)
+	UpdateBuilding(b models.Building) error
+}
+
+// LiveBuildings обновляет здание на загруженной в память арене. Реализуется *Areas.
+type LiveBuildings interface {
+	UpdateBuilding(areaId int64, b models.Building)
+}
+
+// ResourceSpender списывает стоимость улучшения с пользователя и возвращает ее,
+// если улучшение прервано. Реализуется *ledger.Ledger.
+type ResourceSpender interface {
+	Debit(userId int64, reason ledger.Reason, resources []models.Resource) error
+	Credit(userId int64, reason ledger.Reason, resources []models.Resource) error
+}
+
+// pendingUpgrade - улучшение, таймер которого еще не сработал.
+type pendingUpgrade struct {
+	userId int64
+	areaId int64
+	price  []models.Resource
+	stop   func() bool
+}
+
+// Upgrader запускает и завершает улучшения зданий.
+type Upgrader struct {
+	table   UpgradeTable
+	store   UpgradeStore
+	spender ResourceSpender
+	live    LiveBuildings
+	notify  func(UpgradeEvent)
+	after   func(d time.Duration, f func()) (stop func() bool) // таймер, подменяется в тестах
+
+	mu         sync.Mutex
+	inProgress map[int64]bool            // здания, улучшение которых еще идет
+	pending    map[int64]*pendingUpgrade // улучшения, ожидающие таймера
+	stopped    bool
+	running    sync.WaitGroup // запущенные и еще не сохраненные улучшения
+}
+
+// Конструктор для Upgrader. live получает улучшенные здания, чтобы арены в памяти
+// видели новые уровень и характеристики, может быть nil.
+func NewUpgrader(table UpgradeTable, store UpgradeStore, spender ResourceSpender, live LiveBuildings, notify func(UpgradeEvent)) *Upgrader {
+	return &Upgrader{
+		table:   table,
+		store:   store,
+		spender: spender,
+		live:    live,
+		notify:  notify,
+		after: func(d time.Duration, f func()) func() bool {
+			return time.AfterFunc(d, f).Stop
+		},
+		inProgress: make(map[int64]bool),
+		pending:    make(map[int64]*pendingUpgrade),
+	}
+}
+
+// Start списывает стоимость улучшения с пользователя и запускает улучшение здания.
+// Возвращает длительность улучшения.
+func (u *Upgrader) Start(userId, areaId, buildingId int64) (time.Duration, error) {
+	u.mu.Lock()
+	if u.stopped {
+		u.mu.Unlock()
+		return 0, ErrUpgraderStopped
+	}
+	if u.inProgress[buildingId] {
+		u.mu.Unlock()
+		return 0, ErrUpgradeInProgress
+	}
+	u.inProgress[buildingId] = true
+	u.mu.Unlock()
+
+	building, err := u.store.GetBuilding(buildingId)
+	if err != nil {
+		u.release(buildingId)
+		return 0, err
+	}
+	rules, ok := u.table[building.Name]
+	if !ok {
+		u.release(buildingId)
+		return 0, fmt.Errorf("%w: %v", ErrUnknownBuilding, building.Name)
+	}
+	if rules.MaxLevel > 0 && building.Level >= rules.MaxLevel {
+		u.release(buildingId)
+		return 0, ErrUpgradeMaxLevel
+	}
+	// Без стоимости улучшение было бы бесплатным
+	if len(building.UpgradePrice) == 0 {
+		u.release(buildingId)
+		return 0, ErrNoUpgradePrice
+	}
+	if err := u.spender.Debit(userId, ledger.UpgradeCost, building.UpgradePrice); err != nil {
+		u.release(buildingId)
+		return 0, err
+	}
+
+	duration := rules.DurationFor(building.Level)
+	u.publish(UpgradeEvent{Type: "upgrade", AreaId: areaId, BuildingId: buildingId, Level: building.Level, Message: "processing"})
+
+	u.mu.Lock()
+	u.running.Add(1)
+	p := &pendingUpgrade{userId: userId, areaId: areaId, price: building.UpgradePrice}
+	u.pending[buildingId] = p
+	p.stop = u.after(duration, func() {
+		u.mu.Lock()
+		delete(u.pending, buildingId)
+		u.mu.Unlock()
+		defer u.running.Done()
+		u.finish(buildingId, p, rules)
+	})
+	u.mu.Unlock()
+	return duration, nil
+}
+
+// Shutdown останавливает прием новых улучшений. Улучшения, таймер которых еще не сработал,
+// прерываются, а их стоимость возвращается пользователю. Затем Shutdown ждет, пока
+// уже завершающиеся улучшения сохранят результат, или пока не будет отменен ctx.
+func (u *Upgrader) Shutdown(ctx context.Context) error {
+	u.mu.Lock()
+	u.stopped = true
+	var interrupted []int64
+	for buildingId, p := range u.pending {
+		if p.stop() {
+			interrupted = append(interrupted, buildingId)
+		}
+	}
+	pending := make(map[int64]*pendingUpgrade, len(interrupted))
+	for _, buildingId := range interrupted {
+		pending[buildingId] = u.pending[buildingId]
+		delete(u.pending, buildingId)
+	}
+	u.mu.Unlock()
+
+	for buildingId, p := range pending {
+		if err := u.spender.Credit(p.userId, ledger.UpgradeCost, p.price); err != nil {
+			log.Printf("Cant refund interrupted upgrade of building ID- %v: %v\n", buildingId, err)
+		}
+		u.publish(UpgradeEvent{Type: "upgrade", AreaId: p.areaId, BuildingId: buildingId, Message: "failed"})
+		u.release(buildingId)
+		u.running.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		u.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// finish применяет улучшение к зданию после окончания таймера и сохраняет результат.
+// Если сохранить улучшение не удалось, списанная стоимость возвращается пользователю.
+func (u *Upgrader) finish(buildingId int64, p *pendingUpgrade, rules BuildingUpgrade) {
+	defer u.release(buildingId)
+	areaId := p.areaId
+
+	building, err := u.store.GetBuilding(buildingId)
+	if err == nil {
+		applyUpgrade(&building, rules)
+		err = u.store.UpdateBuilding(building)
+	}
+	if err != nil {
+		log.Printf("Cant finish upgrade of building ID- %v: %v\n", buildingId, err)
+		if err := u.spender.Credit(p.userId, ledger.UpgradeCost, p.price); err != nil {
+			log.Printf("Cant refund failed upgrade of building ID- %v: %v\n", buildingId, err)
+		}
+		u.publish(UpgradeEvent{Type: "upgrade", AreaId: areaId, BuildingId: buildingId, Message: "failed"})
+		return
+	}
+	if u.live != nil {
+		u.live.UpdateBuilding(areaId, building)
+	}
+	u.publish(UpgradeEvent{Type: "upgrade", AreaId: areaId, BuildingId: buildingId, Level: building.Level, Message: "successfuly complete"})
+}
+
+func (u *Upgrader) release(buildingId int64) {
+	u.mu.Lock()
+	delete(u.inProgress, buildingId)
+	u.mu.Unlock()
+}
+
+func (u *Upgrader) publish(event UpgradeEvent) {
+	if u.notify != nil {
+		u.notify(event)
+	}
+}
+
+// applyUpgrade повышает уровень здания, его характеристики и стоимость следующего улучшения.
+func applyUpgrade(b *models.Building, rules BuildingUpgrade) {
+	b.Level++
+	b.Charachteristics.HP += rules.HP
+	b.Charachteristics.Armor += rules.Armor
+	b.Charachteristics.ProductivityCoefficient += rules.ProductivityCoefficient
+
+	next := make(models.ResourcePrice, 0, len(b.UpgradePrice))
+	for _, r := range b.UpgradePrice {
+		r.Value = r.Value.Mul(rules.PriceFactor).Round(0)
+		next = append(next, r)
+	}
+	b.UpgradePrice = next
+}
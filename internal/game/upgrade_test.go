@@ -0,0 +1,251 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/ledger"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+)
+
+// fakeUpgradeStore хранит здания и ресурсы пользователя в памяти и списывает ресурсы как ledger
+type fakeUpgradeStore struct {
+	buildings map[int64]models.Building
+	resources map[string]decimal.Decimal
+	updated   []models.Building
+	reasons   []ledger.Reason
+	refunds   []models.Resource
+	updateErr error // ошибка, возвращаемая UpdateBuilding
+}
+
+func (s *fakeUpgradeStore) GetBuilding(buildingId int64) (models.Building, error) {
+	b, ok := s.buildings[buildingId]
+	if !ok {
+		return models.Building{}, storage.ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *fakeUpgradeStore) UpdateBuilding(b models.Building) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	s.buildings[b.Id] = b
+	s.updated = append(s.updated, b)
+	return nil
+}
+
+func (s *fakeUpgradeStore) Debit(userId int64, reason ledger.Reason, price []models.Resource) error {
+	s.reasons = append(s.reasons, reason)
+	for _, r := range price {
+		if s.resources[r.Name].LessThan(r.Value) {
+			return storage.ErrNotEnoughRes
+		}
+	}
+	for _, r := range price {
+		s.resources[r.Name] = s.resources[r.Name].Sub(r.Value)
+	}
+	return nil
+}
+
+func (s *fakeUpgradeStore) Credit(userId int64, reason ledger.Reason, resources []models.Resource) error {
+	for _, r := range resources {
+		s.resources[r.Name] = s.resources[r.Name].Add(r.Value)
+	}
+	s.refunds = append(s.refunds, resources...)
+	return nil
+}
+
+// fakeLiveBuildings запоминает здания, переданные аренам в памяти
+type fakeLiveBuildings struct {
+	updated map[int64][]models.Building
+}
+
+func (l *fakeLiveBuildings) UpdateBuilding(areaId int64, b models.Building) {
+	l.updated[areaId] = append(l.updated[areaId], b)
+}
+
+var testUpgradeTable = UpgradeTable{
+	"House": {
+		Duration:                time.Minute,
+		DurationPerLevel:        30 * time.Second,
+		HP:                      100,
+		Armor:                   2,
+		ProductivityCoefficient: 1,
+		PriceFactor:             decimal.NewFromInt(2),
+		MaxLevel:                3,
+	},
+}
+
+func newTestUpgrader(store *fakeUpgradeStore) (*Upgrader, *[]UpgradeEvent, *[]func()) {
+	var events []UpgradeEvent
+	var timers []func()
+	u := NewUpgrader(testUpgradeTable, store, store, &fakeLiveBuildings{updated: make(map[int64][]models.Building)}, func(e UpgradeEvent) { events = append(events, e) })
+	u.after = func(d time.Duration, f func()) func() bool {
+		timers = append(timers, f)
+		return func() bool { return true }
+	}
+	return u, &events, &timers
+}
+
+func TestUpgraderStart(t *testing.T) {
+	store := &fakeUpgradeStore{
+		buildings: map[int64]models.Building{
+			1: {
+				Id:               1,
+				Name:             "House",
+				Level:            1,
+				Charachteristics: models.BuildingCharacteristics{HP: 500, Armor: 10, ProductivityCoefficient: 3},
+				UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(100)}, {Name: "Wood", Value: decimal.NewFromInt(50)}},
+			},
+		},
+		resources: map[string]decimal.Decimal{"Gold": decimal.NewFromInt(250), "Wood": decimal.NewFromInt(50)},
+	}
+	u, events, timers := newTestUpgrader(store)
+
+	duration, err := u.Start(1, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, duration)
+	assert.True(t, decimal.NewFromInt(150).Equal(store.resources["Gold"]))
+	assert.True(t, store.resources["Wood"].IsZero())
+	assert.Equal(t, []ledger.Reason{ledger.UpgradeCost}, store.reasons)
+
+	// Пока улучшение идет, повторный запуск запрещен
+	_, err = u.Start(1, 2, 1)
+	assert.ErrorIs(t, err, ErrUpgradeInProgress)
+
+	// Срабатывание таймера завершает улучшение
+	if assert.Len(t, *timers, 1) {
+		(*timers)[0]()
+	}
+	building := store.buildings[1]
+	assert.Equal(t, 2, building.Level)
+	assert.Equal(t, models.BuildingCharacteristics{HP: 600, Armor: 12, ProductivityCoefficient: 4}, building.Charachteristics)
+	assert.True(t, decimal.NewFromInt(200).Equal(building.UpgradePrice[0].Value))
+	assert.True(t, decimal.NewFromInt(100).Equal(building.UpgradePrice[1].Value))
+	// Арена в памяти получает улучшенное здание
+	assert.Equal(t, []models.Building{building}, u.live.(*fakeLiveBuildings).updated[2])
+
+	if assert.Len(t, *events, 2) {
+		assert.Equal(t, "processing", (*events)[0].Message)
+		assert.Equal(t, "successfuly complete", (*events)[1].Message)
+		assert.Equal(t, 2, (*events)[1].Level)
+		assert.Equal(t, int64(2), (*events)[1].AreaId)
+	}
+
+	// На следующее улучшение ресурсов уже не хватает, здание снова свободно
+	_, err = u.Start(1, 2, 1)
+	assert.ErrorIs(t, err, storage.ErrNotEnoughRes)
+	assert.Equal(t, 2, store.buildings[1].Level)
+}
+
+func TestUpgraderStartErrors(t *testing.T) {
+	store := &fakeUpgradeStore{
+		buildings: map[int64]models.Building{
+			1: {Id: 1, Name: "House", Level: 3},
+			2: {Id: 2, Name: "Castle", Level: 1},
+			4: {Id: 4, Name: "House", Level: 1},
+		},
+		resources: map[string]decimal.Decimal{},
+	}
+	u, _, timers := newTestUpgrader(store)
+
+	tests := []struct {
+		name          string
+		buildingId    int64
+		expectedError error
+	}{
+		{name: "Max level", buildingId: 1, expectedError: ErrUpgradeMaxLevel},
+		{name: "Unknown building type", buildingId: 2, expectedError: ErrUnknownBuilding},
+		{name: "Building not found", buildingId: 3, expectedError: storage.ErrNotFound},
+		{name: "No upgrade price", buildingId: 4, expectedError: ErrNoUpgradePrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.Start(1, 1, tt.buildingId)
+			assert.True(t, errors.Is(err, tt.expectedError), "expected %v, got %v", tt.expectedError, err)
+		})
+	}
+	assert.Empty(t, *timers)
+	assert.Empty(t, store.updated)
+	assert.Empty(t, store.reasons)
+}
+
+func TestUpgraderShutdownRefundsPending(t *testing.T) {
+	store := &fakeUpgradeStore{
+		buildings: map[int64]models.Building{
+			1: {Id: 1, Name: "House", Level: 1, UpgradePrice: models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(100)}}},
+		},
+		resources: map[string]decimal.Decimal{"Gold": decimal.NewFromInt(100)},
+	}
+	u, events, _ := newTestUpgrader(store)
+
+	_, err := u.Start(1, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, store.resources["Gold"].IsZero())
+
+	// Прерванное улучшение возвращает ресурсы, а новые улучшения не принимаются
+	assert.NoError(t, u.Shutdown(context.Background()))
+	assert.True(t, decimal.NewFromInt(100).Equal(store.resources["Gold"]))
+	assert.Equal(t, 1, store.buildings[1].Level)
+	if assert.Len(t, *events, 2) {
+		assert.Equal(t, "failed", (*events)[1].Message)
+	}
+
+	_, err = u.Start(1, 2, 1)
+	assert.ErrorIs(t, err, ErrUpgraderStopped)
+}
+
+func TestUpgraderFinishFailureRefunds(t *testing.T) {
+	store := &fakeUpgradeStore{
+		buildings: map[int64]models.Building{
+			1: {Id: 1, Name: "House", Level: 1, UpgradePrice: models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromInt(100)}}},
+		},
+		resources: map[string]decimal.Decimal{"Gold": decimal.NewFromInt(100)},
+		updateErr: errors.New("connection lost"),
+	}
+	u, events, timers := newTestUpgrader(store)
+
+	_, err := u.Start(1, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, store.resources["Gold"].IsZero())
+
+	// Улучшение не сохранилось - ресурсы возвращаются, уровень здания не меняется
+	if assert.Len(t, *timers, 1) {
+		(*timers)[0]()
+	}
+	assert.Empty(t, u.live.(*fakeLiveBuildings).updated)
+	assert.True(t, decimal.NewFromInt(100).Equal(store.resources["Gold"]))
+	assert.Equal(t, 1, store.buildings[1].Level)
+	if assert.Len(t, *events, 2) {
+		assert.Equal(t, "failed", (*events)[1].Message)
+	}
+
+	// Здание освобождено для следующего улучшения
+	store.updateErr = nil
+	_, err = u.Start(1, 2, 1)
+	assert.NoError(t, err)
+}
+
+func TestDefaultUpgradeTableCoversBuildings(t *testing.T) {
+	// Проверяем, что улучшать можно все здания каталога
+	for _, name := range DefaultBuildingCatalog.Names() {
+		_, ok := DefaultUpgradeTable[name]
+		assert.True(t, ok, "no upgrade rules for %q", name)
+	}
+}
+
+func TestBuildingUpgradeDurationFor(t *testing.T) {
+	rules := testUpgradeTable["House"]
+
+	assert.Equal(t, time.Minute, rules.DurationFor(0))
+	assert.Equal(t, time.Minute, rules.DurationFor(1))
+	assert.Equal(t, 2*time.Minute, rules.DurationFor(3))
+}
@@ -2,7 +2,8 @@
 Пакет полностью тестовый и на данном этапе необходим для тестирования
 других функций приложения.
 Пакет предназначен для создание арен и на их базе миров пользователей.
-В дальнейшем это будет отдельный микросервис.
+В дальнейшем это будет отдельный микросервис: другие сервисы уже создают арены
+и управляют ими только через gRPC WorldService (пакет world).
 Задача пакета - создать арену с случайно расположеными на ней объектами.
 */
 
@@ -10,6 +11,7 @@ package game
 
 import (
 	storage "cyber/internal/storage"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -24,15 +26,79 @@ import (
 const (
 	width  = 400
 	height = 400
+
+	placementAttempts = 100 // HARDCODE попыток найти свободное место для объекта
 )
 
-// Функция вовзращающая случайный слайс координат в зависимости от размера объекта
-func generateCoordinates(size int) []models.Hex {
-	rand.Seed(time.Now().UnixNano()) // Инициализация генератора случайных чисел
+var ErrUnknownTemplate = errors.New("unknown world template")
+
+// WorldTemplate - стартовый набор объектов арены.
+type WorldTemplate struct {
+	Neutrals  int         // Количество нейтральных объектов
+	Buildings int         // Количество зданий
+	Heroes    int         // Количество героев
+	Units     int         // Количество юнитов
+	Spawn     SpawnConfig // Настройки лагерей врагов
+}
+
+// DefaultTemplate - шаблон арены, создаваемой при первом входе пользователя.
+const DefaultTemplate = "default"
+
+// HARDCODE WorldTemplates - доступные шаблоны арен
+var WorldTemplates = map[string]WorldTemplate{
+	DefaultTemplate: {Neutrals: 1, Buildings: 1, Heroes: 1, Units: 1, Spawn: DefaultSpawnConfig},
+	"rich":          {Neutrals: 4, Buildings: 2, Heroes: 1, Units: 3, Spawn: DefaultSpawnConfig},
+	"peaceful":      {Neutrals: 1, Buildings: 1, Heroes: 1, Units: 1, Spawn: peacefulSpawnConfig()},
+}
+
+// peacefulSpawnConfig - настройки появления врагов без лагерей при создании арены.
+func peacefulSpawnConfig() SpawnConfig {
+	config := DefaultSpawnConfig
+	config.Camps = 0
+	return config
+}
+
+// Template возвращает шаблон арены по названию. Пустое название - шаблон по умолчанию.
+func Template(name string) (WorldTemplate, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	t, ok := WorldTemplates[name]
+	if !ok {
+		return WorldTemplate{}, fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+	}
+	return t, nil
+}
+
+// DifficultyStore - хранилище пользователей и лиг, по которым считается сложность арены.
+type DifficultyStore interface {
+	GetUser(userId int64) (models.User, error)
+	GetLeague(leagueId int64) (models.League, error)
+}
+
+// WorldStore - хранилище арен и их объектов. Реализуется *postgress.Storage.
+type WorldStore interface {
+	EnemyStore
+	AddEmptyArea(a models.Area) (int64, error)
+	AddNeutral(n models.Neutral) (int64, error)
+	AddBuilding(b models.Building) (int64, error)
+	AddHero(h models.Hero) (int64, error)
+	AddAbilityAtHero(abilityId, heroId int64) error
+	AddUnit(u models.Unit) (int64, error)
+	AddNeutralAtArea(neutralId, areaId int64) error
+	AddBuildingAtArea(buildingId, areaId int64) error
+	AddHeroAtArea(heroId, areaId int64) error
+	AddUnitAtArea(unitId, areaId int64) error
+	ClearArea(areaId int64) error
+	DeleteArea(areaId int64) error
+	DifficultyStore
+}
 
+// Функция вовзращающая случайный слайс координат в зависимости от размера объекта
+func generateCoordinates(rng *rand.Rand, size int) []models.Hex {
 	// Генерация начальной точки / размещение объекта в рамках игрового поля
-	startQ := rand.Intn(width - size)
-	startR := rand.Intn(height - size)
+	startQ := rng.Intn(width - size)
+	startR := rng.Intn(height - size)
 
 	// Генерация координат для объекта
 	coordinates := make([]models.Hex, 0, width*height)
@@ -45,38 +111,44 @@ func generateCoordinates(size int) []models.Hex {
 	return coordinates
 }
 
-// HARDCODE generateNeutral создает нейтральный объект расположенный в случайном месте арены.
-func generateNeutral() models.Neutral {
+// freeCoordinates подбирает объекту размера size случайное место, не занятое объектами world.
+func freeCoordinates(world *MemoryWorld, rng *rand.Rand, size int) ([]models.Hex, error) {
+	for i := 0; i < placementAttempts; i++ {
+		coordinates := generateCoordinates(rng, size)
+		free := true
+		for _, c := range coordinates {
+			if world.Blocked(Hex(c)) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return coordinates, nil
+		}
+	}
+	return nil, ErrNoFreePlace
+}
+
+// HARDCODE generateNeutral создает нейтральный объект. Место на арене подбирает PopulateWorld.
+func generateNeutral(rng *rand.Rand) models.Neutral {
 	return models.Neutral{
 		Name:                    "Gold mine",
 		Product:                 "Gold",
 		ProductivityCoefficient: 4,
-		Capacity:                decimal.NewFromFloat(rand.Float64() * 10000),
-		ThresholdLevel1:         decimal.NewFromFloat(rand.Float64() * 5000),
-		ThresholdLevel2:         decimal.NewFromFloat(rand.Float64() * 2000),
+		Capacity:                decimal.NewFromFloat(rng.Float64() * 10000),
+		ThresholdLevel1:         decimal.NewFromFloat(rng.Float64() * 5000),
+		ThresholdLevel2:         decimal.NewFromFloat(rng.Float64() * 2000),
 		Size:                    4,
-		Coordinates:             generateCoordinates(4),
 	}
 }
 
-// HARDCODE generateBuilding создает здание расположенное в случайном месте арены.
+// HARDCODE generateBuilding создает здание из каталога. Место на арене подбирает PopulateWorld.
 func generateBuilding() models.Building {
-	return models.Building{
-		Name:         "CyMan miner house",
-		Product:      "Gold",
-		Level:        1,
-		UpgradePrice: []models.Resource{{Name: "Gold", Value: decimal.NewFromFloat(1000)}},
-		Coordinates:  generateCoordinates(2),
-		Charachteristics: models.BuildingCharacteristics{
-			HP:                      500,
-			Armor:                   10,
-			ProductivityCoefficient: 3,
-			Size:                    2,
-		},
-	}
+	building, _ := DefaultBuildingCatalog.New("CyMan miner house")
+	return building
 }
 
-// HARDCODE generateHero создает героя расположенныое в случайном месте арены.
+// HARDCODE generateHero создает героя. Место на арене подбирает PopulateWorld.
 func generateHero() models.Hero {
 	return models.Hero{
 		Name:           "Ion Mash",
@@ -91,7 +163,7 @@ func generateHero() models.Hero {
 			Vision:     10,
 			IsRange:    false,
 			AtackRange: decimal.NewFromFloat(1.0),
-			Damage:     20,
+			Damage:     decimal.NewFromFloat(20.0),
 		},
 		Abilities: []models.Ability{
 			{
@@ -108,11 +180,10 @@ func generateHero() models.Hero {
 				},
 			},
 		},
-		Coordinates: generateCoordinates(1),
 	}
 }
 
-// HARDCODE generateUnit создает юнита расположенного в случайном месте арены.
+// HARDCODE generateUnit создает юнита. Место на арене подбирает PopulateWorld.
 func generateUnit() models.Unit {
 	return models.Unit{
 		Name:           "Miner",
@@ -131,7 +202,6 @@ func generateUnit() models.Unit {
 			Damage:                  decimal.NewFromFloat(15.0),
 			ProductivityCoefficient: 4,
 		},
-		Coordinates: generateCoordinates(1),
 	}
 }
 
@@ -144,51 +214,158 @@ func NewArea(userId int) models.Area {
 	}
 }
 
-// Фактически функция create world - устанавливает связи объектов и арен
+// Фактически функция create world - устанавливает связи объектов и арен.
+// Создает новое подключение к БД, в сервере следует использовать CreateUserWorld.
 func CreateWorld(userId int) error {
 	db, err := storage.New()
 	if err != nil {
 		return fmt.Errorf("error db connection: %v\n", err)
 	}
-	area := NewArea(userId)
-	if err != nil {
-		log.Fatalf("Cant create world! %v\n", err)
-		return err
+	_, err = CreateUserWorld(db, int64(userId))
+	return err
+}
+
+// CreateUserWorld создает арену пользователя по шаблону по умолчанию
+// и возвращает ID созданной арены.
+func CreateUserWorld(db WorldStore, userId int64) (int64, error) {
+	area, err := CreateTemplateWorld(db, userId, DefaultTemplate, 0)
+	return area.Id, err
+}
+
+// CreateTemplateWorld создает арену пользователя со стартовыми объектами шаблона template
+// и лагерями врагов. Одинаковые seed и template дают одинаковое расположение объектов,
+// seed 0 - случайное. Если арену не удалось заполнить, она удаляется.
+func CreateTemplateWorld(db WorldStore, userId int64, template string, seed int64) (models.Area, error) {
+	if _, err := Template(template); err != nil {
+		return models.Area{}, err
 	}
+	area := NewArea(int(userId))
 	areaId, err := db.AddEmptyArea(area)
 	if err != nil {
-		return err
+		return models.Area{}, err
 	}
+	area.Id = areaId
+	if err := PopulateWorld(db, area, template, seed); err != nil {
+		if err := db.DeleteArea(areaId); err != nil {
+			log.Printf("Cant delete unpopulated area ID- %v: %v\n", areaId, err)
+		}
+		return models.Area{}, err
+	}
+	return area, nil
+}
 
-	neutral := generateNeutral()
-	building := generateBuilding()
-	hero := generateHero()
-	unit := generateUnit()
-
-	neutralId, err := db.AddNeutral(neutral)
-	if err != nil {
-		log.Printf("Failed when creating neutral: %v\n", err)
+// PopulateWorld размещает на пустой арене area стартовые объекты шаблона template
+// и лагеря врагов, сложность которых зависит от владельца арены. seed 0 - случайное зерно.
+// Если заполнить арену не удалось, уже созданные объекты удаляются и арена остается пустой.
+func PopulateWorld(db WorldStore, area models.Area, template string, seed int64) error {
+	if err := populateWorld(db, area, template, seed); err != nil {
+		if err := db.ClearArea(area.Id); err != nil {
+			log.Printf("Cant clear unpopulated area ID- %v: %v\n", area.Id, err)
+		}
 		return err
 	}
-	buildingId, err := db.AddBuilding(building)
+	return nil
+}
+
+func populateWorld(db WorldStore, area models.Area, template string, seed int64) error {
+	t, err := Template(template)
 	if err != nil {
-		log.Printf("Failed when creating building: %v\n", err)
 		return err
 	}
-	heroId, err := db.AddHero(hero)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	world := NewMemoryWorld(area)
+
+	for i := 0; i < t.Neutrals; i++ {
+		neutral := generateNeutral(rng)
+		if neutral.Coordinates, err = freeCoordinates(world, rng, neutral.Size); err != nil {
+			return err
+		}
+		if neutral.Id, err = db.AddNeutral(neutral); err != nil {
+			log.Printf("Failed when creating neutral: %v\n", err)
+			return err
+		}
+		if err := db.AddNeutralAtArea(neutral.Id, area.Id); err != nil {
+			return err
+		}
+		world.AddNeutral(neutral)
+	}
+	for i := 0; i < t.Buildings; i++ {
+		building := generateBuilding()
+		if building.Coordinates, err = freeCoordinates(world, rng, building.Charachteristics.Size); err != nil {
+			return err
+		}
+		if building.Id, err = db.AddBuilding(building); err != nil {
+			log.Printf("Failed when creating building: %v\n", err)
+			return err
+		}
+		if err := db.AddBuildingAtArea(building.Id, area.Id); err != nil {
+			return err
+		}
+		world.AddBuilding(building)
+	}
+	for i := 0; i < t.Heroes; i++ {
+		hero := generateHero()
+		if hero.Coordinates, err = freeCoordinates(world, rng, 1); err != nil {
+			return err
+		}
+		if hero.Id, err = db.AddHero(hero); err != nil {
+			log.Printf("Failed when creating hero: %v\n", err)
+			return err
+		}
+		for _, ability := range hero.Abilities {
+			if err := db.AddAbilityAtHero(ability.Id, hero.Id); err != nil {
+				return err
+			}
+		}
+		if err := db.AddHeroAtArea(hero.Id, area.Id); err != nil {
+			return err
+		}
+		world.AddHero(hero)
+	}
+	for i := 0; i < t.Units; i++ {
+		unit := generateUnit()
+		if unit.Coordinates, err = freeCoordinates(world, rng, 1); err != nil {
+			return err
+		}
+		if unit.Id, err = db.AddUnit(unit); err != nil {
+			log.Printf("Failed when creating unit: %v\n", err)
+			return err
+		}
+		if err := db.AddUnitAtArea(unit.Id, area.Id); err != nil {
+			return err
+		}
+		world.AddUnit(unit)
+	}
+
+	// Лагеря врагов размещаются с учетом уже созданных объектов арены
+	difficulty, err := difficultyFor(db, area.UserId)
 	if err != nil {
-		log.Printf("Failed when creating hero: %v\n", err)
+		log.Printf("Failed when reading user difficulty: %v\n", err)
 		return err
 	}
-	unitId, err := db.AddUnit(unit)
-	if err != nil {
-		log.Printf("Failed when creating unit: %v\n", err)
+	spawner := NewSpawner(world, t.Spawn, difficulty, db, rng, nil)
+	if err := spawner.SpawnCamps(); err != nil {
+		log.Printf("Failed when creating enemy camps: %v\n", err)
 		return err
 	}
-	db.AddNeutralAtArea(neutralId, areaId)
-	db.AddBuildingAtArea(buildingId, areaId)
-	db.AddHeroAtArea(heroId, areaId)
-	db.AddUnitAtArea(unitId, areaId)
 	return nil
+}
 
+// difficultyFor возвращает сложность арены по уровню пользователя и авторитету его лиги.
+func difficultyFor(db DifficultyStore, userId int64) (Difficulty, error) {
+	user, err := db.GetUser(userId)
+	if err != nil {
+		return Difficulty{}, err
+	}
+	var league models.League
+	if user.LeagueId != 0 {
+		league, err = db.GetLeague(user.LeagueId)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return Difficulty{}, err
+		}
+	}
+	return NewDifficulty(user, league), nil
 }
@@ -0,0 +1,101 @@
+/*
+Перехватчики gRPC сервера: проверка сервиса-клиента, журнал вызовов, метрики
+и восстановление после паники обработчика.
+*/
+
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuthHeader - ключ метаданных вызова с токеном сервиса в виде "Bearer <token>".
+const AuthHeader = "authorization"
+
+// ServiceAuth пропускает вызовы только от доверенных сервисов: с общим токеном в метаданных
+// или с клиентским сертификатом mTLS. Сам сертификат проверяет TLS сервера (см. ServerTLS),
+// ServiceAuth только убеждается, что он предъявлен, и сверяет имя сервиса (CN сертификата).
+type ServiceAuth struct {
+	token []byte
+	names map[string]bool // CN разрешенных сертификатов, пустой - любой подписанный доверенным УЦ
+}
+
+// Конструктор для ServiceAuth. Пустой token отключает проверку по токену,
+// names ограничивает сервисы, которым разрешен вход по сертификату.
+func NewServiceAuth(token string, names ...string) *ServiceAuth {
+	a := &ServiceAuth{names: make(map[string]bool)}
+	if token != "" {
+		a.token = []byte(token)
+	}
+	for _, name := range names {
+		a.names[name] = true
+	}
+	return a
+}
+
+// Unary возвращает перехватчик одиночных вызовов.
+func (a *ServiceAuth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает перехватчик потоковых вызовов.
+func (a *ServiceAuth) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *ServiceAuth) authorize(ctx context.Context) error {
+	if a.token != nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get(AuthHeader) {
+			scheme, token, ok := strings.Cut(value, " ")
+			if ok && strings.EqualFold(scheme, "Bearer") && subtle.ConstantTimeCompare([]byte(token), a.token) == 1 {
+				return nil
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			name := info.State.VerifiedChains[0][0].Subject.CommonName
+			if len(a.names) > 0 && !a.names[name] {
+				return status.Errorf(codes.PermissionDenied, "service %q is not allowed", name)
+			}
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "service token or client certificate required")
+}
+
+// TokenCredentials передает общий токен сервиса в каждом вызове клиента:
+// grpc.WithPerRPCCredentials(TokenCredentials{Token: token}).
+// Токен передается только по соединению с TLS, без TLS - только если задан Insecure.
+type TokenCredentials struct {
+	Token    string
+	Insecure bool // Передавать токен без TLS. Только для разработки
+}
+
+func (t TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AuthHeader: "Bearer " + t.Token}, nil
+}
+
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return !t.Insecure
+}
@@ -0,0 +1,18 @@
+package interceptors
+
+import (
+	"google.golang.org/grpc"
+)
+
+// ServerOptions собирает перехватчики сервера в порядке, при котором журнал и метрики
+// видят итоговый код каждого вызова, включая отказ в доступе и восстановленную панику.
+// Без auth вызовы не проверяются.
+func ServerOptions(auth *ServiceAuth, metrics *Metrics) []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{UnaryLogging(), metrics.Unary(), UnaryRecovery()}
+	stream := []grpc.StreamServerInterceptor{StreamLogging(), metrics.Stream(), StreamRecovery()}
+	if auth != nil {
+		unary = append(unary, auth.Unary())
+		stream = append(stream, auth.Stream())
+	}
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...)}
+}
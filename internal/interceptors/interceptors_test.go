@@ -0,0 +1,182 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cyber/pkg/pb"
+)
+
+const testToken = "service-token"
+
+// testServer отвечает на GetAction и WatchActions, а на действие 13 паникует
+type testServer struct {
+	pb.UnimplementedGameLogicServiceServer
+}
+
+func (testServer) GetAction(ctx context.Context, req *pb.ActionRequest) (*pb.ActionResponse, error) {
+	if req.Id == 13 {
+		panic("unlucky action")
+	}
+	return &pb.ActionResponse{Id: req.Id}, nil
+}
+
+func (testServer) WatchActions(req *pb.WatchRequest, stream grpc.ServerStreamingServer[pb.ActionStatus]) error {
+	if req.ActionId == 13 {
+		panic("unlucky action")
+	}
+	return stream.Send(&pb.ActionStatus{ActionId: req.ActionId})
+}
+
+// newTestClient запускает сервер с перехватчиками в памяти процесса и возвращает клиента к нему
+func newTestClient(t *testing.T, serverOptions []grpc.ServerOption, dialOptions ...grpc.DialOption) pb.GameLogicServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(serverOptions...)
+	pb.RegisterGameLogicServiceServer(grpcServer, testServer{})
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+
+	dialOptions = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+	}, dialOptions...)
+	conn, err := grpc.NewClient("passthrough:///localhost", dialOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewGameLogicServiceClient(conn)
+}
+
+// watch открывает поток и возвращает код статуса первого сообщения
+func watch(client pb.GameLogicServiceClient, actionId int64) codes.Code {
+	stream, err := client.WatchActions(context.Background(), &pb.WatchRequest{ActionId: actionId})
+	if err != nil {
+		return status.Code(err)
+	}
+	_, err = stream.Recv()
+	return status.Code(err)
+}
+
+func TestServiceAuthToken(t *testing.T) {
+	options := ServerOptions(NewServiceAuth(testToken), nil)
+	plain := grpc.WithTransportCredentials(insecure.NewCredentials())
+	anonymous := newTestClient(t, options, plain)
+	wrong := newTestClient(t, options, plain, grpc.WithPerRPCCredentials(TokenCredentials{Token: "other", Insecure: true}))
+	trusted := newTestClient(t, options, plain, grpc.WithPerRPCCredentials(TokenCredentials{Token: testToken, Insecure: true}))
+
+	tests := []struct {
+		name         string
+		client       pb.GameLogicServiceClient
+		expectedCode codes.Code
+	}{
+		{"Without token", anonymous, codes.Unauthenticated},
+		{"Wrong token", wrong, codes.Unauthenticated},
+		{"Shared token", trusted, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что одиночные вызовы и потоки пропускаются только с общим токеном
+			_, err := tt.client.GetAction(context.Background(), &pb.ActionRequest{Id: 1})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedCode, watch(tt.client, 1))
+		})
+	}
+}
+
+func TestTokenCredentialsRequireTLS(t *testing.T) {
+	plain := grpc.WithTransportCredentials(insecure.NewCredentials())
+
+	// Проверяем, что клиент не передает токен по соединению без TLS без явного разрешения
+	assert.True(t, TokenCredentials{Token: testToken}.RequireTransportSecurity())
+	_, err := grpc.NewClient("passthrough:///localhost", plain, grpc.WithPerRPCCredentials(TokenCredentials{Token: testToken}))
+	assert.Error(t, err)
+
+	assert.False(t, TokenCredentials{Token: testToken, Insecure: true}.RequireTransportSecurity())
+	conn, err := grpc.NewClient("passthrough:///localhost", plain, grpc.WithPerRPCCredentials(TokenCredentials{Token: testToken, Insecure: true}))
+	assert.NoError(t, err)
+	conn.Close()
+}
+
+func TestServiceAuthMTLS(t *testing.T) {
+	serverTLS, err := ServerTLS("testdata/server.pem", "testdata/server-key.pem", "testdata/ca.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withCert, err := ClientTLS("testdata/client.pem", "testdata/client-key.pem", "testdata/ca.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withoutCert, err := ClientTLS("", "", "testdata/ca.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		auth         *ServiceAuth
+		dialOptions  []grpc.DialOption
+		expectedCode codes.Code
+	}{
+		{"Client certificate", NewServiceAuth(""), []grpc.DialOption{grpc.WithTransportCredentials(withCert)}, codes.OK},
+		{"Allowed service name", NewServiceAuth("", "game-actions"), []grpc.DialOption{grpc.WithTransportCredentials(withCert)}, codes.OK},
+		{"Other service name", NewServiceAuth("", "world"), []grpc.DialOption{grpc.WithTransportCredentials(withCert)}, codes.PermissionDenied},
+		{"TLS without certificate", NewServiceAuth(testToken), []grpc.DialOption{grpc.WithTransportCredentials(withoutCert)}, codes.Unauthenticated},
+		{"TLS with token", NewServiceAuth(testToken), []grpc.DialOption{grpc.WithTransportCredentials(withoutCert),
+			grpc.WithPerRPCCredentials(TokenCredentials{Token: testToken})}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append(ServerOptions(tt.auth, nil), grpc.Creds(serverTLS))
+			client := newTestClient(t, options, tt.dialOptions...)
+
+			// Проверяем, что сервис с сертификатом доверенного УЦ входит без токена
+			_, err := client.GetAction(context.Background(), &pb.ActionRequest{Id: 1})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestServerOptionsObserveCalls(t *testing.T) {
+	var logs bytes.Buffer
+	output := log.Writer()
+	log.SetOutput(&logs)
+	defer log.SetOutput(output)
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+	client := newTestClient(t, ServerOptions(nil, metrics), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	method := "/game_logic.GameLogicService/GetAction"
+
+	// Проверяем, что паника обработчика возвращается клиенту как Internal, а сервер продолжает работу
+	_, err := client.GetAction(context.Background(), &pb.ActionRequest{Id: 13})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "unlucky")
+	assert.Equal(t, codes.Internal, watch(client, 13))
+	_, err = client.GetAction(context.Background(), &pb.ActionRequest{Id: 1})
+	assert.NoError(t, err)
+
+	// Проверяем, что вызовы учитываются в метриках по методу и коду статуса
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "Internal")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("/game_logic.GameLogicService/WatchActions", "Internal")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.duration))
+
+	// Проверяем, что каждый вызов записывается в лог с методом и кодом статуса
+	assert.Equal(t, 1, strings.Count(logs.String(), "gRPC "+method+" Internal"))
+	assert.Contains(t, logs.String(), "gRPC "+method+" OK")
+	assert.Contains(t, logs.String(), "unlucky action")
+}
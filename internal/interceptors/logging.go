@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLogging записывает в лог метод, длительность и код статуса каждого одиночного вызова.
+func UnaryLogging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// StreamLogging записывает в лог метод, длительность и код статуса каждого потока.
+func StreamLogging() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+func logCall(method string, latency time.Duration, err error) {
+	log.Printf("gRPC %s %v %v\n", method, status.Code(err), latency)
+}
+
+// UnaryRecovery перехватывает панику обработчика одиночного вызова и возвращает клиенту Internal.
+func UnaryRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery перехватывает панику обработчика потока и закрывает поток с Internal.
+func StreamRecovery() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered записывает панику в лог со стеком. Клиенту причина паники не передается.
+func recovered(method string, p any) error {
+	log.Printf("gRPC %s panic: %v\n%s", method, p, debug.Stack())
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics - метрики вызовов gRPC сервера. Методы nil метрик ничего не делают.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// Конструктор для Metrics. Метрики регистрируются в reg, если он не nil.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cyber_grpc_requests_total",
+			Help: "gRPC calls handled, by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cyber_grpc_request_duration_seconds",
+			Help:    "gRPC call latency, by method. Streams are measured until they are closed.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	if reg != nil {
+		reg.MustRegister(m.requests, m.duration)
+	}
+	return m
+}
+
+// Unary возвращает перехватчик одиночных вызовов.
+func (m *Metrics) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// Stream возвращает перехватчик потоковых вызовов.
+func (m *Metrics) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+func (m *Metrics) observe(method string, latency time.Duration, err error) {
+	if m != nil {
+		m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+		m.duration.WithLabelValues(method).Observe(latency.Seconds())
+	}
+}
@@ -0,0 +1,20 @@
+#!/bin/sh
+# Создает локальные тестовые сертификаты для mTLS между сервисами.
+# Только для тестов и локального запуска: ключи лежат в репозитории.
+set -e
+cd "$(dirname "$0")"
+
+openssl req -x509 -newkey rsa:2048 -nodes -days 36500 -subj "/CN=cyber test CA" \
+	-keyout ca-key.pem -out ca.pem
+
+openssl req -newkey rsa:2048 -nodes -subj "/CN=localhost" -keyout server-key.pem -out server.csr
+printf "subjectAltName=DNS:localhost,IP:127.0.0.1\nextendedKeyUsage=serverAuth\n" > server.ext
+openssl x509 -req -in server.csr -CA ca.pem -CAkey ca-key.pem -CAcreateserial -days 36500 \
+	-extfile server.ext -out server.pem
+
+openssl req -newkey rsa:2048 -nodes -subj "/CN=game-actions" -keyout client-key.pem -out client.csr
+printf "extendedKeyUsage=clientAuth\n" > client.ext
+openssl x509 -req -in client.csr -CA ca.pem -CAkey ca-key.pem -CAcreateserial -days 36500 \
+	-extfile client.ext -out client.pem
+
+rm -f server.csr server.ext client.csr client.ext ca.srl
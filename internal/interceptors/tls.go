@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ServerTLS загружает сертификат сервера. Если задан caFile, сервер проверяет клиентские
+// сертификаты, подписанные этим УЦ. Клиенты без сертификата допускаются, чтобы они
+// могли войти по токену, - их отклоняет ServiceAuth.
+func ServerTLS(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cant load server certificate: %w", err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		if config.ClientCAs, err = certPool(caFile); err != nil {
+			return nil, err
+		}
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// ClientTLS загружает УЦ, которым подписан сертификат сервера, и, если заданы certFile
+// и keyFile, клиентский сертификат для mTLS.
+func ClientTLS(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	pool, err := certPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cant load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func certPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("cant read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in %s", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,124 @@
+/*
+Пакет ledger ведет учет ресурсов пользователей.
+Все изменения количества ресурсов проходят через Ledger: начисление (Credit),
+списание (Debit) и обмен между пользователями (Trade) выполняются атомарно, количество ресурса никогда не становится
+отрицательным, а каждое изменение дописывается в историю с указанием причины.
+Текущие ресурсы и история отдаются клиенту запросами get_user_data и get_resource_history.
+*/
+
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"cyber/internal/models"
+)
+
+// Reason - причина изменения ресурсов пользователя.
+type Reason string
+
+const (
+	HarvestIncome Reason = "harvest" // Доход от добычи ресурсов
+	BuildCost     Reason = "build"   // Оплата строительства
+	UpgradeCost   Reason = "upgrade" // Оплата улучшения здания
+	TradeExchange Reason = "trade"   // Обмен ресурсов между пользователями
+)
+
+const (
+	DefaultHistoryLimit = 50  // Количество записей истории, возвращаемое по умолчанию
+	MaxHistoryLimit     = 500 // Максимальное количество записей истории в одном ответе
+)
+
+var (
+	ErrNotPositive   = errors.New("resource amount must be positive")
+	ErrUnknownReason = errors.New("unknown ledger reason")
+	ErrSelfTrade     = errors.New("user cant trade with himself")
+)
+
+// Store - хранилище ресурсов пользователей. Реализуется *postgress.Storage.
+type Store interface {
+	ApplyResourceTransaction(userId int64, reason string, deltas []models.Resource) error
+	TransferResources(fromUserId, toUserId int64, reason string, resources []models.Resource) error
+	GetUserResources(userId int64) ([]models.Resource, error)
+	GetResourceTransactions(userId int64, limit int) ([]models.ResourceTransaction, error)
+}
+
+// Ledger - учет ресурсов пользователей.
+type Ledger struct {
+	store Store
+}
+
+// Конструктор для Ledger
+func New(store Store) *Ledger {
+	return &Ledger{store: store}
+}
+
+// Credit начисляет пользователю ресурсы.
+func (l *Ledger) Credit(userId int64, reason Reason, resources []models.Resource) error {
+	if err := validate(reason, resources); err != nil {
+		return err
+	}
+	return l.store.ApplyResourceTransaction(userId, string(reason), resources)
+}
+
+// Debit списывает с пользователя ресурсы. Если хотя бы одного ресурса недостаточно - ничего не списывается.
+func (l *Ledger) Debit(userId int64, reason Reason, resources []models.Resource) error {
+	if err := validate(reason, resources); err != nil {
+		return err
+	}
+	return l.store.ApplyResourceTransaction(userId, string(reason), negate(resources))
+}
+
+// Trade передает resources от пользователя fromUserId пользователю toUserId. Списание и начисление
+// выполняются в одной транзакции: если у fromUserId не хватает хотя бы одного ресурса - ничего не меняется.
+func (l *Ledger) Trade(fromUserId, toUserId int64, resources []models.Resource) error {
+	if fromUserId == toUserId {
+		return ErrSelfTrade
+	}
+	if err := validate(TradeExchange, resources); err != nil {
+		return err
+	}
+	return l.store.TransferResources(fromUserId, toUserId, string(TradeExchange), resources)
+}
+
+// Resources возвращает текущее количество ресурсов пользователя. Используется контрактом get_user_data.
+func (l *Ledger) Resources(userId int64) ([]models.Resource, error) {
+	return l.store.GetUserResources(userId)
+}
+
+// History возвращает последние limit изменений ресурсов пользователя, новые первыми.
+// Используется запросом get_resource_history.
+func (l *Ledger) History(userId int64, limit int) ([]models.ResourceTransaction, error) {
+	if limit < 1 {
+		limit = DefaultHistoryLimit
+	}
+	if limit > MaxHistoryLimit {
+		limit = MaxHistoryLimit
+	}
+	return l.store.GetResourceTransactions(userId, limit)
+}
+
+// validate проверяет причину и положительность количества каждого ресурса.
+func validate(reason Reason, resources []models.Resource) error {
+	switch reason {
+	case HarvestIncome, BuildCost, UpgradeCost, TradeExchange:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownReason, reason)
+	}
+	for _, r := range resources {
+		if !r.Value.IsPositive() {
+			return fmt.Errorf("%w: %v %v", ErrNotPositive, r.Name, r.Value)
+		}
+	}
+	return nil
+}
+
+func negate(resources []models.Resource) []models.Resource {
+	deltas := make([]models.Resource, 0, len(resources))
+	for _, r := range resources {
+		r.Value = r.Value.Neg()
+		deltas = append(deltas, r)
+	}
+	return deltas
+}
@@ -0,0 +1,204 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+// fakeStore хранит ресурсы пользователей и историю в памяти и повторяет атомарность хранилища
+type fakeStore struct {
+	amounts map[int64]map[string]decimal.Decimal
+	history []models.ResourceTransaction
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{amounts: make(map[int64]map[string]decimal.Decimal)}
+}
+
+func (s *fakeStore) ApplyResourceTransaction(userId int64, reason string, deltas []models.Resource) error {
+	if !s.enough(userId, deltas) {
+		return errors.New("not enough resources")
+	}
+	s.apply(userId, reason, deltas)
+	return nil
+}
+
+func (s *fakeStore) TransferResources(fromUserId, toUserId int64, reason string, resources []models.Resource) error {
+	debit := negate(resources)
+	if !s.enough(fromUserId, debit) {
+		return errors.New("not enough resources")
+	}
+	s.apply(fromUserId, reason, debit)
+	s.apply(toUserId, reason, resources)
+	return nil
+}
+
+func (s *fakeStore) enough(userId int64, deltas []models.Resource) bool {
+	for _, d := range deltas {
+		if s.amounts[userId][d.Name].Add(d.Value).IsNegative() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *fakeStore) apply(userId int64, reason string, deltas []models.Resource) {
+	if s.amounts[userId] == nil {
+		s.amounts[userId] = make(map[string]decimal.Decimal)
+	}
+	for _, d := range deltas {
+		s.amounts[userId][d.Name] = s.amounts[userId][d.Name].Add(d.Value)
+		s.history = append(s.history, models.ResourceTransaction{UserId: userId, ResourceName: d.Name, Amount: d.Value, Reason: reason})
+	}
+}
+
+func (s *fakeStore) GetUserResources(userId int64) ([]models.Resource, error) {
+	var resources []models.Resource
+	for _, name := range []string{"Gold", "Wood"} {
+		if v, ok := s.amounts[userId][name]; ok {
+			resources = append(resources, models.Resource{Name: name, Value: v})
+		}
+	}
+	return resources, nil
+}
+
+func (s *fakeStore) GetResourceTransactions(userId int64, limit int) ([]models.ResourceTransaction, error) {
+	if limit > len(s.history) {
+		limit = len(s.history)
+	}
+	return s.history[:limit], nil
+}
+
+func gold(v int64) models.Resource {
+	return models.Resource{Name: "Gold", Value: decimal.NewFromInt(v)}
+}
+
+func wood(v int64) models.Resource {
+	return models.Resource{Name: "Wood", Value: decimal.NewFromInt(v)}
+}
+
+func TestLedgerCreditDebit(t *testing.T) {
+	store := newFakeStore()
+	l := New(store)
+
+	assert.NoError(t, l.Credit(1, HarvestIncome, []models.Resource{gold(100), wood(30)}))
+	assert.NoError(t, l.Debit(1, BuildCost, []models.Resource{gold(40)}))
+
+	// Списание больше остатка не проходит и ничего не меняет
+	assert.Error(t, l.Debit(1, UpgradeCost, []models.Resource{gold(10), wood(31)}))
+
+	resources, err := l.Resources(1)
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 2) {
+		assert.True(t, decimal.NewFromInt(60).Equal(resources[0].Value))
+		assert.True(t, decimal.NewFromInt(30).Equal(resources[1].Value))
+	}
+
+	history, err := l.History(1, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, "harvest", history[0].Reason)
+		assert.Equal(t, "build", history[2].Reason)
+		assert.True(t, decimal.NewFromInt(-40).Equal(history[2].Amount))
+	}
+}
+
+func TestLedgerTrade(t *testing.T) {
+	store := newFakeStore()
+	l := New(store)
+	assert.NoError(t, l.Credit(1, HarvestIncome, []models.Resource{gold(100)}))
+
+	// Проверяем, что ресурсы переходят от одного пользователя к другому с причиной trade
+	assert.NoError(t, l.Trade(1, 2, []models.Resource{gold(30)}))
+	seller, err := l.Resources(1)
+	assert.NoError(t, err)
+	buyer, err := l.Resources(2)
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(70).Equal(seller[0].Value))
+	assert.True(t, decimal.NewFromInt(30).Equal(buyer[0].Value))
+	if assert.Len(t, store.history, 3) {
+		assert.Equal(t, models.ResourceTransaction{UserId: 1, ResourceName: "Gold", Amount: decimal.NewFromInt(-30), Reason: "trade"}, store.history[1])
+		assert.Equal(t, models.ResourceTransaction{UserId: 2, ResourceName: "Gold", Amount: decimal.NewFromInt(30), Reason: "trade"}, store.history[2])
+	}
+
+	// Проверяем, что при нехватке ресурсов не меняются ресурсы ни одного из пользователей
+	assert.Error(t, l.Trade(1, 2, []models.Resource{gold(10), wood(1)}))
+	assert.True(t, decimal.NewFromInt(70).Equal(store.amounts[1]["Gold"]))
+	assert.True(t, decimal.NewFromInt(30).Equal(store.amounts[2]["Gold"]))
+	assert.Len(t, store.history, 3)
+}
+
+func TestLedgerHistoryLimit(t *testing.T) {
+	store := newFakeStore()
+	l := New(store)
+	for i := 0; i < MaxHistoryLimit+1; i++ {
+		assert.NoError(t, l.Credit(1, HarvestIncome, []models.Resource{gold(1)}))
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "Default limit", limit: 0, expected: DefaultHistoryLimit},
+		{name: "Requested limit", limit: 3, expected: 3},
+		{name: "Limit is capped", limit: MaxHistoryLimit + 1, expected: MaxHistoryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := l.History(1, tt.limit)
+			assert.NoError(t, err)
+			assert.Len(t, history, tt.expected)
+		})
+	}
+}
+
+func TestLedgerValidation(t *testing.T) {
+	store := newFakeStore()
+	l := New(store)
+
+	tests := []struct {
+		name          string
+		call          func() error
+		expectedError error
+	}{
+		{
+			name:          "Negative credit",
+			call:          func() error { return l.Credit(1, HarvestIncome, []models.Resource{gold(-5)}) },
+			expectedError: ErrNotPositive,
+		},
+		{
+			name:          "Zero debit",
+			call:          func() error { return l.Debit(1, BuildCost, []models.Resource{gold(0)}) },
+			expectedError: ErrNotPositive,
+		},
+		{
+			name:          "Negative trade",
+			call:          func() error { return l.Trade(1, 2, []models.Resource{gold(5), wood(-1)}) },
+			expectedError: ErrNotPositive,
+		},
+		{
+			name:          "Trade with himself",
+			call:          func() error { return l.Trade(1, 1, []models.Resource{gold(5)}) },
+			expectedError: ErrSelfTrade,
+		},
+		{
+			name:          "Unknown reason",
+			call:          func() error { return l.Credit(1, Reason("gift"), []models.Resource{gold(5)}) },
+			expectedError: ErrUnknownReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.call(), tt.expectedError)
+		})
+	}
+	assert.Empty(t, store.history)
+}
@@ -0,0 +1,251 @@
+/*
+gRPC сервер GameLogicService.
+Через него другие микросервисы получают и добавляют действия пользователей.
+*/
+
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cyber/internal/events"
+	"cyber/internal/game"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/pkg/pb"
+)
+
+// ActionIdHeader - заголовок ответа AddAction с ID сохраненного действия.
+const ActionIdHeader = "action-id"
+
+// ActionStore - хранилище действий. Реализуется *postgress.Storage.
+type ActionStore interface {
+	AddAction(a models.Action) (int64, error)
+	GetAction(actionId int64) (models.Action, error)
+	UpdateActionStatus(actionId int64, status string) error
+}
+
+// ActionScheduler запускает сохраненные действия. Реализуется *game.Scheduler.
+type ActionScheduler interface {
+	Schedule(a models.Action) error
+}
+
+// GameLogicServer реализует pb.GameLogicServiceServer.
+type GameLogicServer struct {
+	pb.UnimplementedGameLogicServiceServer
+	store     ActionStore
+	scheduler ActionScheduler
+	bus       *events.Bus
+	now       func() time.Time
+
+	stopping chan struct{} // закрывается при остановке сервера, завершает потоки WatchActions
+	stopOnce sync.Once
+}
+
+// Конструктор для GameLogicServer. Добавленные действия сохраняются в store
+// и передаются scheduler. Смены статусов действий WatchActions получает из bus,
+// куда их публикует scheduler.
+func NewGameLogicServer(store ActionStore, scheduler ActionScheduler, bus *events.Bus) *GameLogicServer {
+	return &GameLogicServer{store: store, scheduler: scheduler, bus: bus, now: time.Now, stopping: make(chan struct{})}
+}
+
+// Shutdown завершает открытые потоки WatchActions, чтобы grpc.Server.GracefulStop
+// не ждал их бесконечно.
+func (s *GameLogicServer) Shutdown() {
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+// GetAction возвращает сохраненное действие по ID.
+func (s *GameLogicServer) GetAction(ctx context.Context, req *pb.ActionRequest) (*pb.ActionResponse, error) {
+	a, err := s.store.GetAction(req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return actionResponse(a), nil
+}
+
+// AddAction сохраняет действие и передает его планировщику. ID действия возвращается
+// в заголовке ответа ActionIdHeader. Без start_time действие начинается сразу,
+// без status - получает статус PROCESS.
+func (s *GameLogicServer) AddAction(ctx context.Context, req *pb.LogicRequest) (*emptypb.Empty, error) {
+	a, err := s.action(req)
+	if err != nil {
+		return nil, err
+	}
+	a.Id, err = s.store.AddAction(a)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := s.scheduler.Schedule(a); err != nil {
+		// Действие уже сохранено, но выполняться не будет
+		if updateErr := s.store.UpdateActionStatus(a.Id, models.ActionNotDone); updateErr != nil {
+			log.Printf("Cant mark action ID- %v as not done: %v\n", a.Id, updateErr)
+		}
+		return nil, statusError(err)
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ActionIdHeader, strconv.FormatInt(a.Id, 10))); err != nil {
+		log.Printf("Cant send ID of action %v: %v\n", a.Id, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchActions отправляет смены статусов действий, подходящих под фильтр запроса.
+// С action_id сначала отправляется текущий статус действия, а поток закрывается, когда
+// действие завершено. Заголовок ответа отправляется сразу после подписки на смены статусов.
+// Если клиент не успевает читать, поток закрывается с ResourceExhausted.
+func (s *GameLogicServer) WatchActions(req *pb.WatchRequest, stream grpc.ServerStreamingServer[pb.ActionStatus]) error {
+	filter := events.Filter{UserId: req.GetUserId(), AreaId: req.GetAreaId(), ActionId: req.GetActionId()}
+	sub := s.bus.Subscribe(filter, 0)
+	defer sub.Close()
+	// Заголовок ответа сообщает клиенту, что подписка уже действует
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	// Текущий статус читается после подписки, чтобы не пропустить смену статуса между ними
+	if filter.ActionId != 0 {
+		a, err := s.store.GetAction(filter.ActionId)
+		if err != nil {
+			return statusError(err)
+		}
+		if !filter.Match(events.Event{UserId: a.UserId, AreaId: a.AreaId, ActionId: a.Id}) {
+			return status.Error(codes.NotFound, storage.ErrNotFound.Error())
+		}
+		current := game.ActionEvent{Type: a.ActionType, UserId: a.UserId, AreaId: a.AreaId, ActionId: a.Id, Status: a.Status}
+		if err := stream.Send(actionStatus(current, s.now())); err != nil {
+			return err
+		}
+		if finished(a.Status) {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case e, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			if sub.Dropped() > 0 {
+				return status.Error(codes.ResourceExhausted, "action statuses are read too slowly")
+			}
+			event, ok := e.Payload.(game.ActionEvent)
+			if !ok {
+				continue
+			}
+			if err := stream.Send(actionStatus(event, e.Time)); err != nil {
+				return err
+			}
+			if req.GetActionId() != 0 && finished(event.Status) {
+				return nil
+			}
+		}
+	}
+}
+
+// finished проверяет, что статус действия итоговый.
+func finished(status string) bool {
+	return status == models.ActionDone || status == models.ActionNotDone
+}
+
+// actionStatus переводит событие планировщика в сообщение WatchActions.
+func actionStatus(e game.ActionEvent, at time.Time) *pb.ActionStatus {
+	return &pb.ActionStatus{
+		ActionId:   e.ActionId,
+		UserId:     e.UserId,
+		AreaId:     e.AreaId,
+		ActionType: e.Type,
+		Status:     e.Status,
+		Time:       timestamppb.New(at),
+	}
+}
+
+// action проверяет запрос и переводит его в действие.
+func (s *GameLogicServer) action(req *pb.LogicRequest) (models.Action, error) {
+	a := models.Action{
+		UserId:         req.GetUserId(),
+		AreaId:         req.GetAreaId(),
+		ObjectSourceId: req.GetObjectSourceId(),
+		ObjectDestId:   req.GetObjectDestId(),
+		ActionType:     req.GetActionType(),
+		StartTime:      s.now(),
+		Status:         models.ActionInProgress,
+	}
+	// Новое действие всегда выполняется: завершает его только планировщик
+	if st := req.GetStatus(); st != "" && st != models.ActionInProgress {
+		return models.Action{}, status.Errorf(codes.InvalidArgument, "status of new action must be %q, got %q", models.ActionInProgress, st)
+	}
+	if _, ok := models.ParseActionType(a.ActionType); !ok {
+		return models.Action{}, status.Errorf(codes.InvalidArgument, "unknown action type %q", a.ActionType)
+	}
+	if c := req.GetCharacteristics(); c != "" {
+		if !json.Valid([]byte(c)) {
+			return models.Action{}, status.Error(codes.InvalidArgument, "characteristics must be JSON")
+		}
+		a.Characteristics = []byte(c)
+	}
+	if req.StartTime != nil {
+		if err := req.StartTime.CheckValid(); err != nil {
+			return models.Action{}, status.Errorf(codes.InvalidArgument, "start_time: %v", err)
+		}
+		a.StartTime = req.StartTime.AsTime()
+	}
+	if req.Duration != nil {
+		if err := req.Duration.CheckValid(); err != nil || req.Duration.AsDuration() < 0 {
+			return models.Action{}, status.Error(codes.InvalidArgument, "duration must be non-negative")
+		}
+		a.Duration = req.Duration.AsDuration()
+	}
+	return a, nil
+}
+
+// actionResponse переводит действие в ответ GetAction.
+func actionResponse(a models.Action) *pb.ActionResponse {
+	return &pb.ActionResponse{
+		Id:              a.Id,
+		UserId:          a.UserId,
+		AreaId:          a.AreaId,
+		ObjectSourceId:  a.ObjectSourceId,
+		ObjectDestId:    a.ObjectDestId,
+		ActionType:      a.ActionType,
+		Characteristics: string(a.Characteristics),
+		StartTime:       timestamppb.New(a.StartTime),
+		Duration:        durationpb.New(a.Duration),
+		Status:          a.Status,
+	}
+}
+
+// statusError переводит ошибку хранилища или планировщика в ошибку gRPC с кодом статуса.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrNotValidActionID),
+		errors.Is(err, storage.ErrNotValidUserID),
+		errors.Is(err, storage.ErrNotValidAreaID),
+		errors.Is(err, storage.ErrUnknownAction):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, game.ErrSchedulerStopped):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	log.Printf("GameLogicService error: %v\n", err)
+	return status.Error(codes.Internal, "internal error")
+}
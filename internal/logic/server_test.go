@@ -0,0 +1,314 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cyber/internal/events"
+	"cyber/internal/game"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/pkg/pb"
+)
+
+// fakeActions хранит действия в памяти и проверяет их как *postgress.Storage
+type fakeActions struct {
+	mu      sync.Mutex
+	actions map[int64]models.Action
+}
+
+func (f *fakeActions) AddAction(a models.Action) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if a.UserId < 1 {
+		return 0, storage.ErrNotValidUserID
+	}
+	if a.AreaId != 4 {
+		return 0, storage.ErrNotFound
+	}
+	a.Id = int64(len(f.actions) + 1)
+	f.actions[a.Id] = a
+	return a.Id, nil
+}
+
+func (f *fakeActions) GetAction(actionId int64) (models.Action, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if actionId < 1 {
+		return models.Action{}, storage.ErrNotValidActionID
+	}
+	a, ok := f.actions[actionId]
+	if !ok {
+		return models.Action{}, storage.ErrNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeActions) UpdateActionStatus(actionId int64, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a := f.actions[actionId]
+	a.Status = status
+	f.actions[actionId] = a
+	return nil
+}
+
+// fakeScheduler запоминает запланированные действия
+type fakeScheduler struct {
+	mu        sync.Mutex
+	scheduled []models.Action
+	err       error
+}
+
+func (f *fakeScheduler) Schedule(a models.Action) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.scheduled = append(f.scheduled, a)
+	return nil
+}
+
+// newTestClient запускает сервер в памяти процесса и возвращает клиента к нему
+func newTestClient(t *testing.T, srv *GameLogicServer) pb.GameLogicServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterGameLogicServiceServer(grpcServer, srv)
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewGameLogicServiceClient(conn)
+}
+
+func TestGameLogicServer(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeActions{actions: map[int64]models.Action{}}
+	scheduler := &fakeScheduler{}
+	srv := NewGameLogicServer(store, scheduler, events.NewBus())
+	srv.now = func() time.Time { return now }
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	// Проверяем, что действие сохраняется, передается планировщику, а его ID приходит в заголовке
+	var header metadata.MD
+	_, err := client.AddAction(ctx, &pb.LogicRequest{
+		UserId:          1,
+		AreaId:          4,
+		ObjectSourceId:  5,
+		ActionType:      "move",
+		Characteristics: `{"to":{"q":2,"r":2}}`,
+		Duration:        durationpb.New(time.Minute),
+	}, grpc.Header(&header))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, header.Get(ActionIdHeader))
+	if assert.Len(t, scheduler.scheduled, 1) {
+		assert.Equal(t, int64(1), scheduler.scheduled[0].Id)
+		assert.Equal(t, now, scheduler.scheduled[0].StartTime)
+	}
+
+	// Проверяем, что сохраненное действие читается обратно
+	action, err := client.GetAction(ctx, &pb.ActionRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "move", action.ActionType)
+	assert.Equal(t, `{"to":{"q":2,"r":2}}`, action.Characteristics)
+	assert.Equal(t, models.ActionInProgress, action.Status)
+	assert.Equal(t, now, action.StartTime.AsTime())
+	assert.Equal(t, time.Minute, action.Duration.AsDuration())
+}
+
+func TestGameLogicServerErrors(t *testing.T) {
+	store := &fakeActions{actions: map[int64]models.Action{}}
+	scheduler := &fakeScheduler{}
+	client := newTestClient(t, NewGameLogicServer(store, scheduler, events.NewBus()))
+	ctx := context.Background()
+	valid := func() *pb.LogicRequest {
+		return &pb.LogicRequest{UserId: 1, AreaId: 4, ActionType: "attack", StartTime: timestamppb.Now()}
+	}
+
+	tests := []struct {
+		name         string
+		call         func() error
+		expectedCode codes.Code
+	}{
+		{"Action not found", func() error {
+			_, err := client.GetAction(ctx, &pb.ActionRequest{Id: 9})
+			return err
+		}, codes.NotFound},
+		{"Invalid action ID", func() error {
+			_, err := client.GetAction(ctx, &pb.ActionRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"Unknown action type", func() error {
+			req := valid()
+			req.ActionType = "fly"
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.InvalidArgument},
+		{"Action already done", func() error {
+			req := valid()
+			req.Status = models.ActionDone
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.InvalidArgument},
+		{"Characteristics are not JSON", func() error {
+			req := valid()
+			req.Characteristics = "{"
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.InvalidArgument},
+		{"Negative duration", func() error {
+			req := valid()
+			req.Duration = durationpb.New(-time.Second)
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.InvalidArgument},
+		{"Invalid user", func() error {
+			req := valid()
+			req.UserId = 0
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.InvalidArgument},
+		{"Area not found", func() error {
+			req := valid()
+			req.AreaId = 8
+			_, err := client.AddAction(ctx, req)
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что ошибка возвращается с кодом статуса gRPC
+			assert.Equal(t, tt.expectedCode, status.Code(tt.call()))
+		})
+	}
+	assert.Empty(t, scheduler.scheduled)
+
+	// Проверяем, что действие, которое не удалось запланировать, помечается как невыполненное
+	scheduler.err = game.ErrSchedulerStopped
+	_, err := client.AddAction(ctx, valid())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, models.ActionNotDone, store.actions[1].Status)
+}
+
+// newWatchServer возвращает сервер, действия которого завершает настоящий планировщик,
+// публикующий смены статусов в шину, как в main
+func newWatchServer(store *fakeActions) *GameLogicServer {
+	bus := events.NewBus()
+	scheduler := game.NewScheduler(store, func(e game.ActionEvent) {
+		bus.Publish(events.Event{Type: e.Type, UserId: e.UserId, AreaId: e.AreaId, ActionId: e.ActionId, Payload: e})
+	}, nil)
+	return NewGameLogicServer(store, scheduler, bus)
+}
+
+// receive читает сообщения потока до его закрытия и возвращает статусы и ошибку закрытия
+func receive(stream grpc.ServerStreamingClient[pb.ActionStatus], count int) ([]string, error) {
+	var statuses []string
+	for i := 0; count == 0 || i < count; i++ {
+		msg, err := stream.Recv()
+		if err != nil {
+			return statuses, err
+		}
+		statuses = append(statuses, fmt.Sprintf("%v:%v", msg.ActionId, msg.Status))
+		if msg.Time == nil {
+			return statuses, errors.New("status without time")
+		}
+	}
+	return statuses, nil
+}
+
+func TestWatchActions(t *testing.T) {
+	store := &fakeActions{actions: map[int64]models.Action{}}
+	client := newTestClient(t, newWatchServer(store))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	add := func(userId int64, duration time.Duration) {
+		_, err := client.AddAction(ctx, &pb.LogicRequest{UserId: userId, AreaId: 4, ActionType: "move", Duration: durationpb.New(duration)})
+		assert.NoError(t, err)
+	}
+
+	// Проверяем, что поток пользователя получает смены статусов только его действий
+	stream, err := client.WatchActions(ctx, &pb.WatchRequest{UserId: 1})
+	assert.NoError(t, err)
+	_, err = stream.Header()
+	assert.NoError(t, err)
+	add(2, 0)
+	add(1, 10*time.Millisecond)
+	statuses, err := receive(stream, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2:PROCESS", "2:DONE"}, statuses)
+
+	// Проверяем, что поток действия начинается с текущего статуса и закрывается после завершения
+	add(1, 50*time.Millisecond)
+	stream, err = client.WatchActions(ctx, &pb.WatchRequest{ActionId: 3})
+	assert.NoError(t, err)
+	statuses, err = receive(stream, 0)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []string{"3:PROCESS", "3:DONE"}, statuses)
+
+	// Проверяем, что для завершенного действия приходит только итоговый статус
+	stream, err = client.WatchActions(ctx, &pb.WatchRequest{ActionId: 3})
+	assert.NoError(t, err)
+	statuses, err = receive(stream, 0)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []string{"3:DONE"}, statuses)
+}
+
+func TestWatchActionsErrors(t *testing.T) {
+	store := &fakeActions{actions: map[int64]models.Action{1: {Id: 1, UserId: 1, AreaId: 4, Status: models.ActionInProgress}}}
+	srv := newWatchServer(store)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		req          *pb.WatchRequest
+		expectedCode codes.Code
+	}{
+		{"Action not found", &pb.WatchRequest{ActionId: 9}, codes.NotFound},
+		{"Action of other user", &pb.WatchRequest{UserId: 2, ActionId: 1}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что поток закрывается с кодом статуса gRPC
+			stream, err := client.WatchActions(ctx, tt.req)
+			assert.NoError(t, err)
+			_, err = receive(stream, 0)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+
+	// Проверяем, что остановка сервера закрывает открытые потоки
+	stream, err := client.WatchActions(ctx, &pb.WatchRequest{AreaId: 4})
+	assert.NoError(t, err)
+	_, err = stream.Header()
+	assert.NoError(t, err)
+	srv.Shutdown()
+	_, err = receive(stream, 0)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
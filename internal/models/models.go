@@ -2,6 +2,9 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -23,6 +26,73 @@ type Resource struct {
 	Value decimal.Decimal `json:"value"` // Значение ресурса (количество)
 }
 
+// ResourcePrice представляет стоимость в нескольких ресурсах (например стоимость улучшения здания).
+// При чтении поддерживает список ресурсов, объект вида {"wood":450,"stone":150} из контракта
+// и устаревший формат схемы БД, где стоимость хранилась одним числом (считается золотом).
+type ResourcePrice []Resource
+
+// LegacyPriceResource - ресурс, в котором выражена стоимость, сохраненная одним числом.
+const LegacyPriceResource = "Gold"
+
+// UnmarshalJSON разбирает стоимость из списка, объекта или числа.
+func (p *ResourcePrice) UnmarshalJSON(data []byte) error {
+	var list []Resource
+	if err := json.Unmarshal(data, &list); err == nil {
+		*p = list
+		return nil
+	}
+
+	var object map[string]decimal.Decimal
+	if err := json.Unmarshal(data, &object); err == nil {
+		price := make(ResourcePrice, 0, len(object))
+		for name, value := range object {
+			price = append(price, Resource{Name: name, Value: value})
+		}
+		sort.Slice(price, func(i, j int) bool { return price[i].Name < price[j].Name })
+		*p = price
+		return nil
+	}
+
+	var value decimal.Decimal
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("unsupported price format: %s", data)
+	}
+	*p = ResourcePrice{{Name: LegacyPriceResource, Value: value}}
+	return nil
+}
+
+// Scan реализует sql.Scanner для чтения стоимости из jsonb или устаревшей decimal колонки.
+func (p *ResourcePrice) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		return p.UnmarshalJSON(v)
+	case string:
+		return p.UnmarshalJSON([]byte(v))
+	case float64:
+		*p = ResourcePrice{{Name: LegacyPriceResource, Value: decimal.NewFromFloat(v)}}
+		return nil
+	case int64:
+		*p = ResourcePrice{{Name: LegacyPriceResource, Value: decimal.NewFromInt(v)}}
+		return nil
+	default:
+		return fmt.Errorf("cant scan %T into ResourcePrice", src)
+	}
+}
+
+// ResourceTransaction представляет запись в истории изменений ресурсов пользователя.
+// История только пополняется, записи не изменяются и не удаляются.
+type ResourceTransaction struct {
+	Id           int64           `db:"id" json:"id"`                 // Идентификатор записи
+	UserId       int64           `db:"user_id" json:"user_id"`       // Идентификатор пользователя
+	ResourceName string          `db:"name" json:"resource"`         // Название ресурса
+	Amount       decimal.Decimal `db:"amount" json:"amount"`         // Изменение количества (отрицательное - списание)
+	Reason       string          `db:"reason" json:"reason"`         // Причина изменения (harvest, build, upgrade)
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"` // Время изменения
+}
+
 // User представляет пользователя системы.
 type User struct {
 	Id           int64           `db:"id"`           // Идентификатор пользователя
@@ -37,9 +107,9 @@ type User struct {
 
 // League представляет лигу, в которой состоят пользователи.
 type League struct {
-	Id        int64  `db:"id"`        // Идентификатор лиги
-	Name      string `db:"name"`      // Название лиги
-	Authority int    `db:"authority"` // Уровень авторитета лиги
+	Id        int64  `db:"id" json:"id"`               // Идентификатор лиги
+	Name      string `db:"name" json:"name"`           // Название лиги
+	Authority int    `db:"authority" json:"authority"` // Уровень авторитета лиги
 }
 
 // CellType представляет тип клетки на арене.
@@ -64,25 +134,25 @@ type Area struct {
 
 // Neutral представляет нейтральный объект на арене.
 type Neutral struct {
-	Id                      int64           `db:"id"`                             // Идентификатор объекта
-	Name                    string          `db:"name"`                           // Название объекта (например, золотая шахта)
-	Product                 string          `db:"product"`                        // Тип производимого продукта (популяция, еда, минералы и т.д.)
-	ProductivityCoefficient int             `db:"prod_cof"`                       // Коэффициент производительности
-	Capacity                decimal.Decimal `db:"capacity"`                       // Емкость ресурса объекта
-	ThresholdLevel1         decimal.Decimal `db:"threshold_level1"`               // Порог значения ресурса, после которого скорость добычи снижается в 5 раз
-	ThresholdLevel2         decimal.Decimal `db:"threshold_level2"`               // Порог значения ресурса, после которого скорость добычи снижается в 10 раз
-	Size                    int             `db:"size"`                           //Количество клеток которое занимает объект
-	Coordinates             []Hex           `db:"coordinates" json:"coordinates"` // Координаты объекта на арене
+	Id                      int64           `db:"id" json:"id"`                             // Идентификатор объекта
+	Name                    string          `db:"name" json:"name"`                         // Название объекта (например, золотая шахта)
+	Product                 string          `db:"product" json:"product"`                   // Тип производимого продукта (популяция, еда, минералы и т.д.)
+	ProductivityCoefficient int             `db:"prod_cof" json:"productivity_coefficient"` // Коэффициент производительности
+	Capacity                decimal.Decimal `db:"capacity" json:"capacity"`                 // Емкость ресурса объекта
+	ThresholdLevel1         decimal.Decimal `db:"threshold_level1" json:"threshold_level1"` // Порог значения ресурса, после которого скорость добычи снижается в 5 раз
+	ThresholdLevel2         decimal.Decimal `db:"threshold_level2" json:"threshold_level2"` // Порог значения ресурса, после которого скорость добычи снижается в 10 раз
+	Size                    int             `db:"size" json:"size"`                         //Количество клеток которое занимает объект
+	Coordinates             []Hex           `db:"coordinates" json:"coordinates"`           // Координаты объекта на арене
 }
 
 // Building представляет здание на арене.
 type Building struct {
-	Id               int64                   `db:"id"`                                     // Идентификатор здания
-	Name             string                  `db:"name"`                                   // Название здания
-	Product          string                  `db:"product"`                                // Тип производимого продукта
+	Id               int64                   `db:"id" json:"id"`                           // Идентификатор здания
+	Name             string                  `db:"name" json:"name"`                       // Название здания
+	Product          string                  `db:"product" json:"product"`                 // Тип производимого продукта
 	Charachteristics BuildingCharacteristics `db:"characteristics" json:"characteristics"` // Характеристики здания
-	Level            int                     `db:"level"`                                  // Уровень здания
-	UpgradePrice     []Resource              `db:"resources"`                              // Стоимость улучшения здания
+	Level            int                     `db:"level" json:"level"`                     // Уровень здания
+	UpgradePrice     ResourcePrice           `db:"upgrade_price" json:"upgrade_price"`     // Стоимость улучшения здания
 	Coordinates      []Hex                   `db:"coordinates" json:"coordinates"`         // Координаты объекта на арене
 }
 
@@ -96,14 +166,14 @@ type BuildingCharacteristics struct {
 
 // Heroe представляет героя.
 type Hero struct {
-	Id               int64               `db:"id"`                                     // Идентификатор героя
-	Name             string              `db:"name"`                                   // Название героя
-	Charachteristics HeroCharacteristics `db:"characteristics" json:"characteristics"` // Характеристики героя
-	Experience       decimal.Decimal     `db:"experience"`                             // Текущий опыт героя
-	ExperienceToUp   decimal.Decimal     `db:"experience_to_up"`                       // Опыт, необходимый для повышения уровня
-	Level            int                 `db:"level"`                                  // Уровень героя
-	Abilities        []Ability           `json:"abilities"`                            // Список идентификаторов способностей героя
-	Coordinates      []Hex               `db:"coordinates"`                            // Координаты объекта на арене
+	Id               int64               `db:"id" json:"id"`                             // Идентификатор героя
+	Name             string              `db:"name" json:"name"`                         // Название героя
+	Charachteristics HeroCharacteristics `db:"characteristics" json:"characteristics"`   // Характеристики героя
+	Experience       decimal.Decimal     `db:"experience" json:"experience"`             // Текущий опыт героя
+	ExperienceToUp   decimal.Decimal     `db:"experience_to_up" json:"experience_to_up"` // Опыт, необходимый для повышения уровня
+	Level            int                 `db:"level" json:"level"`                       // Уровень героя
+	Abilities        []Ability           `json:"abilities"`                              // Список идентификаторов способностей героя
+	Coordinates      []Hex               `db:"coordinates" json:"coordinates"`           // Координаты объекта на арене
 }
 
 // HeroCharacteristics представляет характеристики героя.
@@ -115,16 +185,16 @@ type HeroCharacteristics struct {
 	Vision     int             `json:"vision"`      // Дальность обзора героя
 	IsRange    bool            `json:"range"`       // Флаг, определяющий, является ли герой дальнобойным
 	AtackRange decimal.Decimal `json:"atack_range"` // Дальность атаки героя
-	Damage     int             `json:"damage"`      // Текущий урон героя
+	Damage     decimal.Decimal `json:"damage"`      // Текущий урон героя
 }
 
 // Ability представляет способность героя.
 type Ability struct {
-	Id               int64                   `db:"id"`                                     // Идентификатор способности
-	Name             string                  `db:"name"`                                   // Название способности
+	Id               int64                   `db:"id" json:"id"`                           // Идентификатор способности
+	Name             string                  `db:"name" json:"name"`                       // Название способности
 	Charachteristics AbilitytCharacteristics `db:"characteristics" json:"characteristics"` // Характеристики способности
-	Level            int                     `db:"level"`                                  // Уровень способности
-	ImageId          int64                   `db:"image_id"`                               // Идентификатор изображения способности
+	Level            int                     `db:"level" json:"level"`                     // Уровень способности
+	ImageId          int64                   `db:"image_id" json:"image_id"`               // Идентификатор изображения способности
 }
 
 // AbilitytCharacteristics представляет характеристики способности.
@@ -138,14 +208,14 @@ type AbilitytCharacteristics struct {
 
 // Unit представляет юнита.
 type Unit struct {
-	Id               int64               `db:"id"`                                     // Идентификатор юнита
-	Name             string              `db:"name"`                                   // Название юнита
-	Charachteristics UnitCharacteristics `db:"characteristics" json:"characteristics"` // Характеристики юнита
-	Experience       decimal.Decimal     `db:"experience"`                             // Текущий опыт юнита
-	ExperienceToUp   decimal.Decimal     `db:"experience_to_up"`                       // Опыт, необходимый для повышения уровня
-	Level            int                 `db:"level"`                                  // Уровень юнита
-	ImageId          int64               `db:"image_id"`                               // Идентификатор изображения юнита
-	Coordinates      []Hex               `db:"coordinates"`                            // Координаты объекта на арене
+	Id               int64               `db:"id" json:"id"`                             // Идентификатор юнита
+	Name             string              `db:"name" json:"name"`                         // Название юнита
+	Charachteristics UnitCharacteristics `db:"characteristics" json:"characteristics"`   // Характеристики юнита
+	Experience       decimal.Decimal     `db:"experience" json:"experience"`             // Текущий опыт юнита
+	ExperienceToUp   decimal.Decimal     `db:"experience_to_up" json:"experience_to_up"` // Опыт, необходимый для повышения уровня
+	Level            int                 `db:"level" json:"level"`                       // Уровень юнита
+	ImageId          int64               `db:"image_id" json:"image_id"`                 // Идентификатор изображения юнита
+	Coordinates      []Hex               `db:"coordinates" json:"coordinates"`           // Координаты объекта на арене
 }
 
 // UnitCharacteristics представляет характеристики юнита.
@@ -163,11 +233,12 @@ type UnitCharacteristics struct {
 
 // Enemy представляет врага.
 type Enemy struct {
-	Id               int64                `db:"id"`                                     // Идентификатор врага
-	Name             string               `db:"name"`                                   // Название врага
+	Id               int64                `db:"id" json:"id"`                           // Идентификатор врага
+	Name             string               `db:"name" json:"name"`                       // Название врага
 	Charachteristics EnemyCharacteristics `db:"characteristics" json:"characteristics"` // Характеристики врага
-	Level            int                  `db:"level"`                                  // Уровень врага
-	Coordinates      []Hex                `db:"coordinates"`                            // Координаты объекта на арене
+	Level            int                  `db:"level" json:"level"`                     // Уровень врага
+	Coordinates      []Hex                `db:"coordinates" json:"coordinates"`         // Координаты объекта на арене
+	Camp             int                  `db:"camp" json:"-"`                          // Номер лагеря на арене, 0 - враг волны
 }
 
 // EnemyCharacteristics представляет характеристики врага.
@@ -183,6 +254,17 @@ type EnemyCharacteristics struct {
 	Level      int             `json:"level"`       // Уровень врага
 }
 
+// ObjectKind - тип объекта на арене.
+type ObjectKind string
+
+const (
+	NeutralObject  ObjectKind = "neutral"  // Нейтральный объект
+	BuildingObject ObjectKind = "building" // Здание
+	HeroObject     ObjectKind = "hero"     // Герой
+	UnitObject     ObjectKind = "unit"     // Юнит
+	EnemyObject    ObjectKind = "enemy"    // Враг
+)
+
 // Action представляет действие, выполняемое пользователем.
 type Action struct {
 	Id              int64         `db:"id" json:"id"`                             // Идентификатор действия
@@ -201,40 +283,91 @@ type Action struct {
 type ActionType int
 
 const (
-	MoveAction    ActionType = iota + 1 // Действие: перемещение
-	HarvestAction                       // Действие: сбор ресурсов
-	BuildAction                         // Действие: строительство
-	AttackAction                        // Действие: атака
+	MoveAction      ActionType = iota + 1 // Действие: перемещение
+	HarvestAction                         // Действие: сбор ресурсов
+	BuildAction                           // Действие: строительство
+	AttackAction                          // Действие: атака
+	UpgradeAction                         // Действие: улучшение здания
+	GroupMoveAction                       // Действие: перемещение группы юнитов
+)
+
+// actionTypeNames - названия типов действий, как в сообщениях клиента.
+var actionTypeNames = map[ActionType]string{
+	MoveAction:      "move",
+	HarvestAction:   "harvest",
+	BuildAction:     "build",
+	AttackAction:    "attack",
+	UpgradeAction:   "upgrade",
+	GroupMoveAction: "group_move",
+}
+
+// String возвращает название типа действия.
+func (t ActionType) String() string {
+	return actionTypeNames[t]
+}
+
+// ParseActionType возвращает тип действия по названию. ok равен false для неизвестного названия.
+func ParseActionType(name string) (ActionType, bool) {
+	for t, n := range actionTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// Статусы действия.
+const (
+	ActionDone       = "DONE"     // Действие выполнено
+	ActionNotDone    = "NOT_DONE" // Действие прервано
+	ActionInProgress = "PROCESS"  // Действие выполняется
 )
 
 // MoveActionCharacteristics описывает характеристики перемещения.
 type MoveActionCharacteristics struct {
-	From  Hex             `json:"from"`  // Начальная точка перемещения
-	To    Hex             `json:"to"`    // Конечная точка перемещения
-	Speed decimal.Decimal `json:"speed"` // Скорость перемещения
+	From  Hex             `json:"from" validate:"in_area"`            // Начальная точка перемещения
+	To    Hex             `json:"to" validate:"in_area"`              // Конечная точка перемещения
+	Speed decimal.Decimal `json:"speed" validate:"optional,positive"` // Скорость перемещения, 0 - скорость объекта
+}
+
+// GroupMoveActionCharacteristics описывает характеристики перемещения группы юнитов к одной клетке.
+type GroupMoveActionCharacteristics struct {
+	Units []int64 `json:"units" validate:"required"` // Идентификаторы юнитов группы
+	To    Hex     `json:"to" validate:"in_area"`     // Клетка, к которой идет группа
 }
 
 // HarvestActionCharacteristics описывает характеристики сбора ресурсов.
 type HarvestActionCharacteristics struct {
-	Harvester    int64           `json:"harvester"`  // Идентификатор объекта, собирающего ресурсы
-	ResourceName string          `json:"resource"`   // Название ресурса
-	NeutralId    int64           `json:"neutral_id"` //id нейтрального объекта из которого будет проводиться добыча ресурса
-	Speed        decimal.Decimal `json:"speed"`      // Скорость сбора ресурсов в количествах ресурса в секунду
+	Harvester    int64           `json:"harvester" validate:"nonnegative"`            // Идентификатор объекта, собирающего ресурсы
+	ResourceName string          `json:"resource" validate:"optional,oneof=resource"` // Название ресурса
+	NeutralId    int64           `json:"neutral_id" validate:"nonnegative"`           //id нейтрального объекта из которого будет проводиться добыча ресурса
+	Speed        decimal.Decimal `json:"speed" validate:"optional,positive"`          // Скорость сбора ресурсов в количествах ресурса в секунду
 }
 
 // BuildActionCharacteristics описывает характеристики строительства.
 type BuildActionCharacteristics struct {
-	Builder          int64  `json:"builder"`           // Идентификатор объекта, выполняющего строительство
-	ObjectType       string `json:"object"`            // Тип объекта, который строится
-	ConstructionTime int    `json:"construction_time"` // Время строительства  в секундах
-	Place            Hex    `json:"place"`             // координаты на арене где будет произвоиться строителство
+	Builder          int64  `json:"builder" validate:"nonnegative"`          // Идентификатор объекта, выполняющего строительство
+	ObjectType       string `json:"object" validate:"required,oneof=object"` // Тип объекта, который строится
+	ConstructionTime int    `json:"construction_time" validate:"positive"`   // Время строительства  в секундах
+	Place            Hex    `json:"place" validate:"in_area"`                // координаты на арене где будет произвоиться строителство
 }
 
 // AttackActionCharacteristics описывает характеристики атаки.
 type AttackActionCharacteristics struct {
-	Atacker  int64           `json:"atacker"`  // Идентификатор атакующего объекта
-	Defenser int64           `json:"defenser"` // Идентификатор защищающегося объекта
-	Damage   decimal.Decimal `json:"damage"`   // Урон от атаки
+	Atacker     int64           `json:"atacker" validate:"nonnegative"`                  // Идентификатор атакующего объекта
+	AtackerKind ObjectKind      `json:"atacker_kind" validate:"required,oneof=attacker"` // Тип атакующего объекта - hero или unit
+	Defenser    int64           `json:"defenser" validate:"nonnegative"`                 // Идентификатор защищающегося объекта
+	Damage      decimal.Decimal `json:"damage" validate:"optional,positive"`             // Урон от атаки
+}
+
+// UpgradeActionCharacteristics описывает характеристики улучшения здания.
+type UpgradeActionCharacteristics struct {
+	BuildingId int64 `json:"building_id" validate:"nonnegative"` // Идентификатор улучшаемого здания
+}
+
+// ResourceHistoryCharacteristics описывает параметры запроса истории ресурсов.
+type ResourceHistoryCharacteristics struct {
+	Limit int `json:"limit" validate:"nonnegative"` // Количество последних записей, 0 - количество по умолчанию
 }
 
 // Hex представляет точку на плоскости.
@@ -0,0 +1,115 @@
+package postgress
+
+import (
+	"context"
+	models "cyber/internal/models"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// foreignKeyViolation - код ошибки PostgreSQL при ссылке на несуществующую запись
+const foreignKeyViolation = "23503"
+
+var (
+	ErrNotValidActionID = errors.New("Invalid action ID")
+	ErrUnknownAction    = errors.New("unknown action type")
+)
+
+// AddAction сохраняет действие пользователя и возвращает его ID.
+// Если пользователя или арены нет в БД, возвращается ErrNotFound.
+func (s *Storage) AddAction(a models.Action) (int64, error) {
+	if a.UserId < 1 {
+		return 0, ErrNotValidUserID
+	}
+	if a.AreaId < 1 {
+		return 0, ErrNotValidAreaID
+	}
+	actionType, ok := models.ParseActionType(a.ActionType)
+	if !ok {
+		return 0, ErrUnknownAction
+	}
+	characteristics := a.Characteristics
+	if len(characteristics) == 0 {
+		characteristics = []byte("{}")
+	}
+	query := `INSERT INTO actions (user_id, area_id, object_source_id, object_dest_id, action_type, characteristics, start_time, duration, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id;`
+
+	var id int64
+	err := s.Db.QueryRow(context.Background(), query,
+		a.UserId,
+		a.AreaId,
+		a.ObjectSourceId,
+		a.ObjectDestId,
+		int(actionType),
+		characteristics,
+		a.StartTime,
+		pgtype.Interval{Microseconds: a.Duration.Microseconds(), Valid: true},
+		a.Status).Scan(&id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant add action of user ID- %v into database! %v\n", a.UserId, err)
+		return 0, ErrDataBase
+	}
+	return id, nil
+}
+
+// GetAction получает действие по его ID
+func (s *Storage) GetAction(actionId int64) (models.Action, error) {
+	if actionId < 1 {
+		return models.Action{}, ErrNotValidActionID
+	}
+	query := `SELECT id, user_id, area_id, COALESCE(object_source_id, 0), COALESCE(object_dest_id, 0),
+		action_type, characteristics, start_time, duration, status FROM actions WHERE id=$1;`
+
+	var a models.Action
+	var actionType int
+	var duration pgtype.Interval
+	err := s.Db.QueryRow(context.Background(), query, actionId).Scan(
+		&a.Id,
+		&a.UserId,
+		&a.AreaId,
+		&a.ObjectSourceId,
+		&a.ObjectDestId,
+		&actionType,
+		&a.Characteristics,
+		&a.StartTime,
+		&duration,
+		&a.Status,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Action{}, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant read action ID- %v from database! %v\n", actionId, err)
+		return models.Action{}, ErrDataBase
+	}
+	a.ActionType = models.ActionType(actionType).String()
+	// Месяцы в длительности действий не используются
+	a.Duration = time.Duration(duration.Microseconds)*time.Microsecond + time.Duration(duration.Days)*24*time.Hour
+	return a, nil
+}
+
+// UpdateActionStatus сохраняет статус действия
+func (s *Storage) UpdateActionStatus(actionId int64, status string) error {
+	if actionId < 1 {
+		return ErrNotValidActionID
+	}
+	tag, err := s.Db.Exec(context.Background(), `UPDATE actions SET status=$1 WHERE id=$2;`, status, actionId)
+	if err != nil {
+		log.Printf("Cant update status of action ID- %v in database! %v\n", actionId, err)
+		return ErrDataBase
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
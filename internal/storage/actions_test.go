@@ -0,0 +1,171 @@
+package postgress
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+func TestAddAction(t *testing.T) {
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	action := models.Action{
+		UserId:          1,
+		AreaId:          4,
+		ObjectSourceId:  5,
+		ActionType:      "move",
+		Characteristics: []byte(`{"to":{"q":2,"r":2}}`),
+		StartTime:       start,
+		Duration:        90 * time.Second,
+		Status:          models.ActionInProgress,
+	}
+	query := regexp.QuoteMeta(`INSERT INTO actions (user_id, area_id, object_source_id, object_dest_id, action_type, characteristics, start_time, duration, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id;`)
+	args := []interface{}{int64(1), int64(4), int64(5), int64(0), int(models.MoveAction), action.Characteristics, start,
+		pgtype.Interval{Microseconds: 90_000_000, Valid: true}, models.ActionInProgress}
+
+	tests := []struct {
+		name          string
+		action        func(a models.Action) models.Action
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedId    int64
+		expectedError error
+	}{
+		{
+			name:   "Success - action added",
+			action: func(a models.Action) models.Action { return a },
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs(args...).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(7)))
+			},
+			expectedId: 7,
+		},
+		{
+			name: "Error - Unknown action type",
+			action: func(a models.Action) models.Action {
+				a.ActionType = "fly"
+				return a
+			},
+			mockSetup:     func(mock pgxmock.PgxPoolIface) {},
+			expectedError: ErrUnknownAction,
+		},
+		{
+			name: "Error - Invalid area ID",
+			action: func(a models.Action) models.Action {
+				a.AreaId = 0
+				return a
+			},
+			mockSetup:     func(mock pgxmock.PgxPoolIface) {},
+			expectedError: ErrNotValidAreaID,
+		},
+		{
+			name:   "Error - Area does not exist",
+			action: func(a models.Action) models.Action { return a },
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs(args...).WillReturnError(&pgconn.PgError{Code: foreignKeyViolation})
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:   "Error - Database query failed",
+			action: func(a models.Action) models.Action { return a },
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs(args...).WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем, что тип действия сохраняется номером, а длительность - интервалом
+			id, err := storage.AddAction(tt.action(action))
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedId, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAction(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := regexp.QuoteMeta(`SELECT id, user_id, area_id, COALESCE(object_source_id, 0), COALESCE(object_dest_id, 0),
+		action_type, characteristics, start_time, duration, status FROM actions WHERE id=$1;`)
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "area_id", "object_source_id", "object_dest_id", "action_type", "characteristics", "start_time", "duration", "status"}
+
+	mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(pgxmock.NewRows(columns).
+		AddRow(int64(7), int64(1), int64(4), int64(5), int64(0), int(models.UpgradeAction), []byte(`{"building_id":5}`), start,
+			pgtype.Interval{Days: 1, Microseconds: 30_000_000, Valid: true}, models.ActionDone))
+	action, err := storage.GetAction(7)
+	assert.NoError(t, err)
+	// Проверяем, что номер типа действия переводится в название, а интервал - в длительность
+	assert.Equal(t, models.Action{
+		Id:              7,
+		UserId:          1,
+		AreaId:          4,
+		ObjectSourceId:  5,
+		ActionType:      "upgrade",
+		Characteristics: []byte(`{"building_id":5}`),
+		StartTime:       start,
+		Duration:        24*time.Hour + 30*time.Second,
+		Status:          models.ActionDone,
+	}, action)
+
+	// Проверяем, что отсутствие действия возвращает ErrNotFound
+	mock.ExpectQuery(query).WithArgs(int64(9)).WillReturnError(pgx.ErrNoRows)
+	_, err = storage.GetAction(9)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = storage.GetAction(0)
+	assert.ErrorIs(t, err, ErrNotValidActionID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateActionStatus(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := regexp.QuoteMeta(`UPDATE actions SET status=$1 WHERE id=$2;`)
+
+	mock.ExpectExec(query).WithArgs(models.ActionDone, int64(7)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	assert.NoError(t, storage.UpdateActionStatus(7, models.ActionDone))
+
+	// Проверяем, что обновление несуществующего действия возвращает ErrNotFound
+	mock.ExpectExec(query).WithArgs(models.ActionDone, int64(9)).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	assert.ErrorIs(t, storage.UpdateActionStatus(9, models.ActionDone), ErrNotFound)
+
+	mock.ExpectExec(query).WithArgs(models.ActionDone, int64(3)).WillReturnError(fmt.Errorf("database error"))
+	assert.ErrorIs(t, storage.UpdateActionStatus(3, models.ActionDone), ErrDataBase)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
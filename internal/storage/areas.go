@@ -0,0 +1,99 @@
+package postgress
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+
+	"cyber/internal/models"
+)
+
+// clearAreaQueries удаляют объекты арены всех видов. Связи объектов с ареной
+// и способности героев удаляются каскадно.
+var clearAreaQueries = []string{
+	`DELETE FROM neutrals WHERE id IN (SELECT neutral_id FROM areas_neutrals WHERE area_id=$1);`,
+	`DELETE FROM buildings WHERE id IN (SELECT building_id FROM areas_buildings WHERE area_id=$1);`,
+	`DELETE FROM heroes WHERE id IN (SELECT hero_id FROM areas_heroes WHERE area_id=$1);`,
+	`DELETE FROM units WHERE id IN (SELECT unit_id FROM areas_units WHERE area_id=$1);`,
+	`DELETE FROM enemies WHERE id IN (SELECT enemy_id FROM areas_enemies WHERE area_id=$1);`,
+}
+
+// ClearArea удаляет все объекты арены, сама арена остается.
+func (s *Storage) ClearArea(areaId int64) error {
+	return s.clearArea(areaId, false)
+}
+
+// DeleteArea удаляет арену вместе с ее объектами. Действия и состояние мира арены удаляются каскадно.
+func (s *Storage) DeleteArea(areaId int64) error {
+	return s.clearArea(areaId, true)
+}
+
+func (s *Storage) clearArea(areaId int64, deleteArea bool) error {
+	if areaId < 1 {
+		return ErrNotValidAreaID
+	}
+	ctx := context.Background()
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		log.Printf("Cant begin transaction: %v\n", err)
+		return ErrDataBase
+	}
+	defer tx.Rollback(ctx)
+
+	// Арена блокируется, чтобы на нее не добавили объекты во время удаления
+	var id int64
+	err = tx.QueryRow(ctx, `SELECT id FROM areas WHERE id=$1 FOR UPDATE;`, areaId).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant lock area ID- %v: %v\n", areaId, err)
+		return ErrDataBase
+	}
+
+	for _, query := range clearAreaQueries {
+		if _, err := tx.Exec(ctx, query, areaId); err != nil {
+			log.Printf("Cant delete objects of area ID- %v: %v\n", areaId, err)
+			return ErrDataBase
+		}
+	}
+	if deleteArea {
+		if _, err := tx.Exec(ctx, `DELETE FROM areas WHERE id=$1;`, areaId); err != nil {
+			log.Printf("Cant delete area ID- %v: %v\n", areaId, err)
+			return ErrDataBase
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Cant commit transaction: %v\n", err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// GetAreas возвращает все арены в порядке их ID.
+func (s *Storage) GetAreas() ([]models.Area, error) {
+	rows, err := s.Db.Query(context.Background(), `SELECT id, user_id, width, height, cell_type_id FROM areas ORDER BY id;`)
+	if err != nil {
+		log.Printf("Cant read areas from database: %v\n", err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var areas []models.Area
+	for rows.Next() {
+		var a models.Area
+		if err := rows.Scan(&a.Id, &a.UserId, &a.Width, &a.Height, &a.CellTypeId); err != nil {
+			log.Printf("Cant read area from database: %v\n", err)
+			return nil, ErrDataBase
+		}
+		areas = append(areas, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Cant read areas from database: %v\n", err)
+		return nil, ErrDataBase
+	}
+	return areas, nil
+}
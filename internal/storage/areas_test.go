@@ -0,0 +1,157 @@
+package postgress
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+func TestClearArea(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(`SELECT id FROM areas WHERE id=$1 FOR UPDATE;`)
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM areas WHERE id=$1;`)
+	expectObjects := func(mock pgxmock.PgxPoolIface) {
+		for _, query := range clearAreaQueries {
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(int64(4)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		}
+	}
+
+	tests := []struct {
+		name          string
+		areaId        int64
+		deleteArea    bool
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name:   "Success - clear objects",
+			areaId: 4,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(int64(4)).WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(4)))
+				expectObjects(mock)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:       "Success - delete area with objects",
+			areaId:     4,
+			deleteArea: true,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(int64(4)).WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(4)))
+				expectObjects(mock)
+				mock.ExpectExec(deleteQuery).WithArgs(int64(4)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Error - area not found",
+			areaId: 9,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(int64(9)).WillReturnRows(mock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:   "Error - delete failed rolls back",
+			areaId: 4,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(int64(4)).WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(4)))
+				mock.ExpectExec(regexp.QuoteMeta(clearAreaQueries[0])).WithArgs(int64(4)).WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrDataBase,
+		},
+		{
+			name:          "Error - invalid area ID",
+			areaId:        0,
+			mockSetup:     func(mock pgxmock.PgxPoolIface) {},
+			expectedError: ErrNotValidAreaID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем, что объекты и арена удаляются в одной транзакции
+			if tt.deleteArea {
+				err = storage.DeleteArea(tt.areaId)
+			} else {
+				err = storage.ClearArea(tt.areaId)
+			}
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAreas(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT id, user_id, width, height, cell_type_id FROM areas ORDER BY id;`)
+	columns := []string{"id", "user_id", "width", "height", "cell_type_id"}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expected      []models.Area
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WillReturnRows(mock.NewRows(columns).
+					AddRow(int64(4), int64(1), 400, 400, 1).
+					AddRow(int64(8), int64(2), 50, 50, 2))
+			},
+			expected: []models.Area{
+				{Id: 4, UserId: 1, Width: 400, Height: 400, CellTypeId: 1},
+				{Id: 8, UserId: 2, Width: 50, Height: 50, CellTypeId: 2},
+			},
+		},
+		{
+			name: "Success - no areas",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WillReturnRows(mock.NewRows(columns))
+			},
+		},
+		{
+			name: "Error - database error",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем, что читаются все арены
+			areas, err := storage.GetAreas()
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, areas)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package postgress
+
+import (
+	"context"
+	models "cyber/internal/models"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ApplyResourceTransaction атомарно изменяет количество ресурсов пользователя на deltas
+// (положительное значение - начисление, отрицательное - списание) и дописывает изменения
+// в историю resource_transactions с причиной reason.
+// Если хотя бы одного ресурса недостаточно - ничего не изменяется и возвращается ErrNotEnoughRes.
+func (s *Storage) ApplyResourceTransaction(userId int64, reason string, deltas []models.Resource) error {
+	if userId < 1 {
+		return ErrNotValidUserID
+	}
+	return s.inResourceTransaction(func(ctx context.Context, tx pgx.Tx) error {
+		return applyResourceDeltas(ctx, tx, userId, reason, deltas)
+	})
+}
+
+// TransferResources в одной транзакции списывает resources с пользователя fromUserId
+// и начисляет их пользователю toUserId, дописывая изменения обоих в историю с причиной reason.
+// Если у fromUserId не хватает хотя бы одного ресурса - ничего не изменяется и возвращается ErrNotEnoughRes.
+func (s *Storage) TransferResources(fromUserId, toUserId int64, reason string, resources []models.Resource) error {
+	if fromUserId < 1 || toUserId < 1 {
+		return ErrNotValidUserID
+	}
+	debit := make([]models.Resource, 0, len(resources))
+	for _, r := range resources {
+		r.Value = r.Value.Neg()
+		debit = append(debit, r)
+	}
+	return s.inResourceTransaction(func(ctx context.Context, tx pgx.Tx) error {
+		if err := applyResourceDeltas(ctx, tx, fromUserId, reason, debit); err != nil {
+			return err
+		}
+		return applyResourceDeltas(ctx, tx, toUserId, reason, resources)
+	})
+}
+
+// inResourceTransaction выполняет apply в транзакции и фиксирует ее, если apply не вернул ошибку.
+func (s *Storage) inResourceTransaction(apply func(ctx context.Context, tx pgx.Tx) error) error {
+	ctx := context.Background()
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		log.Printf("Cant begin transaction: %v\n", err)
+		return ErrDataBase
+	}
+	defer tx.Rollback(ctx)
+
+	if err := apply(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Cant commit transaction: %v\n", err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// applyResourceDeltas изменяет ресурсы пользователя на deltas внутри транзакции tx
+// и дописывает каждое изменение в историю.
+func applyResourceDeltas(ctx context.Context, tx pgx.Tx, userId int64, reason string, deltas []models.Resource) error {
+	debitQuery := `UPDATE user_resources SET amount = user_resources.amount + $1
+		FROM resources
+		WHERE user_resources.resource_id = resources.id
+		AND user_resources.user_id = $2
+		AND resources.name = $3
+		AND user_resources.amount + $1 >= 0
+		RETURNING resources.id;`
+	creditQuery := `INSERT INTO user_resources (resource_id, user_id, amount)
+		SELECT id, $2, $1 FROM resources WHERE name = $3
+		ON CONFLICT (resource_id, user_id) DO UPDATE SET amount = user_resources.amount + EXCLUDED.amount
+		RETURNING resource_id;`
+	historyQuery := `INSERT INTO resource_transactions (user_id, resource_id, amount, reason) VALUES ($1, $2, $3, $4);`
+
+	for _, d := range deltas {
+		if d.Value.IsZero() {
+			continue
+		}
+		query := creditQuery
+		if d.Value.IsNegative() {
+			query = debitQuery
+		}
+
+		var resourceId int64
+		err := tx.QueryRow(ctx, query, d.Value, userId, d.Name).Scan(&resourceId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			if d.Value.IsNegative() {
+				return fmt.Errorf("%w: %v", ErrNotEnoughRes, d.Name)
+			}
+			return fmt.Errorf("%w: %v", ErrUnknownRes, d.Name)
+		}
+		if err != nil {
+			log.Printf("Cant change %v by %v for user ID- %v: %v\n", d.Name, d.Value, userId, err)
+			return ErrDataBase
+		}
+
+		if _, err := tx.Exec(ctx, historyQuery, userId, resourceId, d.Value, reason); err != nil {
+			log.Printf("Cant add resource transaction for user ID- %v: %v\n", userId, err)
+			return ErrDataBase
+		}
+	}
+	return nil
+}
+
+// GetResourceNames возвращает названия ресурсов из справочника resources
+func (s *Storage) GetResourceNames() ([]string, error) {
+	rows, err := s.Db.Query(context.Background(), `SELECT name FROM resources ORDER BY id;`)
+	if err != nil {
+		log.Printf("Cant read resources: %v\n", err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return names, nil
+}
+
+// GetUserResources возвращает количество каждого ресурса пользователя
+func (s *Storage) GetUserResources(userId int64) ([]models.Resource, error) {
+	if userId < 1 {
+		return nil, ErrNotValidUserID
+	}
+	query := `SELECT resources.id, resources.name, user_resources.amount FROM user_resources
+		JOIN resources ON user_resources.resource_id = resources.id
+		WHERE user_resources.user_id = $1 ORDER BY resources.id;`
+
+	rows, err := s.Db.Query(context.Background(), query, userId)
+	if err != nil {
+		log.Printf("Cant read resources of user ID- %v: %v\n", userId, err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var resources []models.Resource
+	for rows.Next() {
+		var r models.Resource
+		if err := rows.Scan(&r.Id, &r.Name, &r.Value); err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		resources = append(resources, r)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return resources, nil
+}
+
+// GetResourceTransactions возвращает последние limit записей истории ресурсов пользователя, новые первыми
+func (s *Storage) GetResourceTransactions(userId int64, limit int) ([]models.ResourceTransaction, error) {
+	if userId < 1 {
+		return nil, ErrNotValidUserID
+	}
+	query := `SELECT resource_transactions.id, resource_transactions.user_id, resources.name,
+		resource_transactions.amount, resource_transactions.reason, resource_transactions.created_at
+		FROM resource_transactions
+		JOIN resources ON resource_transactions.resource_id = resources.id
+		WHERE resource_transactions.user_id = $1
+		ORDER BY resource_transactions.id DESC LIMIT $2;`
+
+	rows, err := s.Db.Query(context.Background(), query, userId, limit)
+	if err != nil {
+		log.Printf("Cant read resource transactions of user ID- %v: %v\n", userId, err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var transactions []models.ResourceTransaction
+	for rows.Next() {
+		var t models.ResourceTransaction
+		if err := rows.Scan(&t.Id, &t.UserId, &t.ResourceName, &t.Amount, &t.Reason, &t.CreatedAt); err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		transactions = append(transactions, t)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return transactions, nil
+}
@@ -0,0 +1,352 @@
+package postgress
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+func TestApplyResourceTransaction(t *testing.T) {
+	deltas := []models.Resource{
+		{Name: "Gold", Value: decimal.NewFromFloat(-150)},
+		{Name: "Wood", Value: decimal.NewFromFloat(40)},
+	}
+	debitQuery := `UPDATE user_resources SET amount = user_resources.amount \+ \$1`
+	creditQuery := `INSERT INTO user_resources \(resource_id, user_id, amount\)`
+	historyQuery := `INSERT INTO resource_transactions \(user_id, resource_id, amount, reason\) VALUES \(\$1, \$2, \$3, \$4\);`
+
+	tests := []struct {
+		name          string
+		userId        int64
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name:   "Success - debit and credit with history",
+			userId: 1,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(deltas[0].Value, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(1)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(1), int64(1), deltas[0].Value, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(creditQuery).WithArgs(deltas[1].Value, int64(1), "Wood").
+					WillReturnRows(mock.NewRows([]string{"resource_id"}).AddRow(int64(2)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(1), int64(2), deltas[1].Value, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Error - not enough resources rolls back",
+			userId: 1,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(deltas[0].Value, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrNotEnoughRes,
+		},
+		{
+			name:   "Error - unknown resource rolls back",
+			userId: 1,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(deltas[0].Value, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(1)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(1), int64(1), deltas[0].Value, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(creditQuery).WithArgs(deltas[1].Value, int64(1), "Wood").
+					WillReturnRows(mock.NewRows([]string{"resource_id"}))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrUnknownRes,
+		},
+		{
+			name:   "Error - Database query failed",
+			userId: 1,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(deltas[0].Value, int64(1), "Gold").
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrDataBase,
+		},
+		{
+			name:          "Error - Invalid user ID",
+			userId:        0,
+			mockSetup:     func(mock pgxmock.PgxPoolIface) {},
+			expectedError: ErrNotValidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			err = storage.ApplyResourceTransaction(tt.userId, "trade", deltas)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferResources(t *testing.T) {
+	resources := []models.Resource{{Name: "Gold", Value: decimal.NewFromFloat(150)}}
+	debit := resources[0].Value.Neg()
+	debitQuery := `UPDATE user_resources SET amount = user_resources.amount \+ \$1`
+	creditQuery := `INSERT INTO user_resources \(resource_id, user_id, amount\)`
+	historyQuery := `INSERT INTO resource_transactions \(user_id, resource_id, amount, reason\) VALUES \(\$1, \$2, \$3, \$4\);`
+
+	tests := []struct {
+		name          string
+		toUserId      int64
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name:     "Success - debit seller and credit buyer in one transaction",
+			toUserId: 2,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(debit, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(1)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(1), int64(1), debit, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(creditQuery).WithArgs(resources[0].Value, int64(2), "Gold").
+					WillReturnRows(mock.NewRows([]string{"resource_id"}).AddRow(int64(1)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(2), int64(1), resources[0].Value, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "Error - seller has not enough resources, buyer is not credited",
+			toUserId: 2,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(debit, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrNotEnoughRes,
+		},
+		{
+			name:     "Error - buyer credit failed rolls back seller debit",
+			toUserId: 2,
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(debitQuery).WithArgs(debit, int64(1), "Gold").
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(1)))
+				mock.ExpectExec(historyQuery).WithArgs(int64(1), int64(1), debit, "trade").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(creditQuery).WithArgs(resources[0].Value, int64(2), "Gold").
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrDataBase,
+		},
+		{
+			name:          "Error - Invalid buyer ID",
+			toUserId:      0,
+			mockSetup:     func(mock pgxmock.PgxPoolIface) {},
+			expectedError: ErrNotValidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			err = storage.TransferResources(1, tt.toUserId, "trade", resources)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetUserResources(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer mock.Close()
+
+	storage := &Storage{Db: mock}
+
+	expected := []models.Resource{
+		{Id: 1, Name: "Gold", Value: decimal.NewFromFloat(1500)},
+		{Id: 2, Name: "Wood", Value: decimal.NewFromFloat(800)},
+	}
+	query := `SELECT resources.id, resources.name, user_resources.amount FROM user_resources`
+
+	tests := []struct {
+		name           string
+		userId         int64
+		mock           func()
+		expectedResult []models.Resource
+		expectedError  error
+	}{
+		{
+			name:   "Valid data",
+			userId: 1,
+			mock: func() {
+				rows := mock.NewRows([]string{"id", "name", "amount"}).
+					AddRow(expected[0].Id, expected[0].Name, expected[0].Value).
+					AddRow(expected[1].Id, expected[1].Name, expected[1].Value)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			},
+			expectedResult: expected,
+		},
+		{
+			name:          "Invalid user ID",
+			userId:        0,
+			mock:          func() {},
+			expectedError: ErrNotValidUserID,
+		},
+		{
+			name:   "DB error",
+			userId: 1,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			resources, err := storage.GetUserResources(tt.userId)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, resources)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetResourceNames(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer mock.Close()
+
+	storage := &Storage{Db: mock}
+	query := `SELECT name FROM resources ORDER BY id;`
+
+	tests := []struct {
+		name           string
+		mock           func()
+		expectedResult []string
+		expectedError  error
+	}{
+		{
+			name: "Valid data",
+			mock: func() {
+				rows := mock.NewRows([]string{"name"}).AddRow("Gold").AddRow("Wood")
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			},
+			expectedResult: []string{"Gold", "Wood"},
+		},
+		{
+			name: "DB error",
+			mock: func() {
+				mock.ExpectQuery(query).WillReturnError(errors.New("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			names, err := storage.GetResourceNames()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, names)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetResourceTransactions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer mock.Close()
+
+	storage := &Storage{Db: mock}
+
+	createdAt := time.Date(2025, 1, 22, 4, 39, 28, 0, time.UTC)
+	expected := []models.ResourceTransaction{
+		{Id: 2, UserId: 1, ResourceName: "Gold", Amount: decimal.NewFromFloat(-500), Reason: "upgrade", CreatedAt: createdAt},
+		{Id: 1, UserId: 1, ResourceName: "Gold", Amount: decimal.NewFromFloat(120), Reason: "harvest", CreatedAt: createdAt},
+	}
+	query := `SELECT resource_transactions.id, resource_transactions.user_id, resources.name`
+
+	rows := mock.NewRows([]string{"id", "user_id", "name", "amount", "reason", "created_at"})
+	for _, tr := range expected {
+		rows.AddRow(tr.Id, tr.UserId, tr.ResourceName, tr.Amount, tr.Reason, tr.CreatedAt)
+	}
+	mock.ExpectQuery(query).WithArgs(int64(1), 10).WillReturnRows(rows)
+
+	transactions, err := storage.GetResourceTransactions(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, transactions)
+
+	_, err = storage.GetResourceTransactions(0, 10)
+	assert.ErrorIs(t, err, ErrNotValidUserID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
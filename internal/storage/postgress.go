@@ -17,10 +17,14 @@ import (
 	"github.com/joho/godotenv"
 )
 
+// uniqueViolation - код ошибки PostgreSQL при нарушении ограничения уникальности
+const uniqueViolation = "23505"
+
 type PgxPool interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 	// Добавьте другие методы, которые вы используете из pgxpool.Pool
 }
 
@@ -38,6 +42,11 @@ var (
 	ErrNotValidChar      = errors.New("Failed to marshal characteristics")
 	ErrNotValidRes       = errors.New("Failed to marshal resources")
 	ErrNotValidAbilities = errors.New("Failed to marshal abilities")
+	ErrNotValidBuilding  = errors.New("Invalid building ID")
+	ErrNotFound          = errors.New("object not found")
+	ErrNotEnoughRes      = errors.New("not enough resources")
+	ErrUnknownRes        = errors.New("unknown resource")
+	ErrAlreadyExists     = errors.New("object already exists")
 )
 
 // Storage конструктор. Пароль БД загружается из переменной окружения.
@@ -51,7 +60,13 @@ func New() (*Storage, error) {
 
 	connString := "postgres://postgres:" + pwd + "@localhost:5432/cyberball"
 
-	db, err := pgxpool.New(context.Background(), connString)
+	return Connect(context.Background(), connString)
+}
+
+// Connect создает пул подключений к БД по строке подключения dsn.
+// Пул один на весь сервер и передается всем компонентам, работающим с БД.
+func Connect(ctx context.Context, dsn string) (*Storage, error) {
+	db, err := pgxpool.New(ctx, dsn)
 	if err != nil {
 		log.Printf("cant create new instance of DB: %v\n", err)
 		return nil, err
@@ -62,6 +77,13 @@ func New() (*Storage, error) {
 	return &s, nil
 }
 
+// Close закрывает пул подключений к БД.
+func (s *Storage) Close() {
+	if c, ok := s.Db.(interface{ Close() }); ok {
+		c.Close()
+	}
+}
+
 /* Дальнейшую часть кода, касающуюся работы с юзерами пока не удаляю, но ее можно не смотреть*/
 
 // функция проверки валидности email адресса
@@ -71,22 +93,41 @@ func IsEmailValid(email string) bool {
 }
 
 // Метод добавления пользователя в БД. На вход принимает слайс объектов, возвращает ошибку, при наличии.
+// Пароль пользователя должен быть уже захеширован, в БД он записывается как есть.
+// Если логин или email уже заняты, возвращается ErrAlreadyExists.
 // TODO: добавить проверок валидности данных пользователя
 func (s *Storage) AddUser(u models.User) error {
-	if IsEmailValid(u.Email) {
-		_, err := s.Db.Exec(context.Background(), `INSERT INTO users (login, password, email, subscription, league_id, balance, level) VALUES ($1, $2, $3, $4, $5, $6, $7);`,
-			u.Login, u.Password, u.Email, u.Subscription, u.LeagueId, u.Balance, u.Level)
-		if err != nil {
-			//log.Fatalf("Cant add data in database! %v\n", err)
-			return err
-		}
-	} else {
+	if !IsEmailValid(u.Email) {
 		return ErrInvalidEmail
 	}
-
+	_, err := s.Db.Exec(context.Background(), `INSERT INTO users (login, password, email, subscription, league_id, balance, level) VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+		u.Login, u.Password, u.Email, u.Subscription, u.LeagueId, u.Balance, u.Level)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrAlreadyExists
+		}
+		log.Printf("Cant add user in database: %v\n", err)
+		return ErrDataBase
+	}
 	return nil
 }
 
+// GetUserByLogin получает пользователя по логину вместе с хешем его пароля.
+func (s *Storage) GetUserByLogin(login string) (models.User, error) {
+	var u models.User
+	err := s.Db.QueryRow(context.Background(), `SELECT id, login, password, email, subscription, league_id, balance, level FROM users WHERE login=$1;`, login).
+		Scan(&u.Id, &u.Login, &u.Password, &u.Email, &u.Subscription, &u.LeagueId, &u.Balance, &u.Level)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.User{}, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant read user %v from database: %v\n", login, err)
+		return models.User{}, ErrDataBase
+	}
+	return u, nil
+}
+
 func (s *Storage) GetUser(userId int64) (models.User, error) {
 	if userId < 1 {
 
@@ -117,6 +158,9 @@ func (s *Storage) GetUser(userId int64) (models.User, error) {
 			return models.User{}, ErrRows
 		}
 	}
+	if u.Id == 0 {
+		return models.User{}, ErrNotFound
+	}
 	return u, nil
 }
 
@@ -126,7 +170,9 @@ func (s *Storage) GetNeutrals(areaID int64) ([]models.Neutral, error) {
 	if areaID < 1 {
 		return nil, ErrNotValidAreaID
 	}
-	rows, err := s.Db.Query(context.Background(), `SELECT neutrals.* FROM areas_neutrals JOIN neutrals ON areas_neutrals.neutral_id = neutrals.id WHERE areas_neutrals.area_id = $1;`, areaID)
+	rows, err := s.Db.Query(context.Background(), `SELECT neutrals.id, neutrals.name, neutrals.product, neutrals.productivity_coefficient,
+	neutrals.capacity, neutrals.threshold_level1, neutrals.threshold_level2, neutrals.size, neutrals.coordinates FROM areas_neutrals
+	JOIN neutrals ON areas_neutrals.neutral_id = neutrals.id WHERE areas_neutrals.area_id = $1;`, areaID)
 	if err != nil {
 		log.Printf("Failed to execute query GetNeutrals: %v\n", err)
 		return nil, ErrDataBase
@@ -137,6 +183,7 @@ func (s *Storage) GetNeutrals(areaID int64) ([]models.Neutral, error) {
 
 	for rows.Next() {
 		var n models.Neutral
+		var coordsJSON []byte
 		err := rows.Scan(
 			&n.Id,
 			&n.Name,
@@ -145,14 +192,23 @@ func (s *Storage) GetNeutrals(areaID int64) ([]models.Neutral, error) {
 			&n.Capacity,
 			&n.ThresholdLevel1,
 			&n.ThresholdLevel2,
-			&n.Coordinates,
+			&n.Size,
+			&coordsJSON,
 		)
 		if err != nil {
 			log.Printf("unable scan row: %v", err)
 			return nil, ErrRows
 		}
+		if n.Coordinates, err = unmarshalCoordinates(coordsJSON); err != nil {
+			log.Printf("Failed to unmarshal coordinates of neutral ID- %v: %v\n", n.Id, err)
+			return nil, ErrRows
+		}
 		neutrals = append(neutrals, n)
 	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
 	return neutrals, nil
 }
 
@@ -163,8 +219,9 @@ func (s *Storage) GetBuildings(areaID int64) ([]models.Building, error) {
 		log.Printf("Invalid ara id - %v", areaID)
 		return nil, ErrNotValidAreaID
 	}
-	rows, err := s.Db.Query(context.Background(), `SELECT buildings.* FROM areas_buildings
-	JOIN buildings ON areas_buildings.buildings_id=buildings.id WHERE areas_buildings.area_id=$1`, areaID)
+	rows, err := s.Db.Query(context.Background(), `SELECT buildings.id, buildings.name, buildings.product, buildings.characteristics,
+	buildings.level, buildings.upgrade_price, buildings.coordinates FROM areas_buildings
+	JOIN buildings ON areas_buildings.building_id = buildings.id WHERE areas_buildings.area_id = $1;`, areaID)
 	if err != nil {
 		log.Printf("Cant read data about buildings object from DB: %v\n", err)
 		return nil, ErrDataBase
@@ -175,32 +232,52 @@ func (s *Storage) GetBuildings(areaID int64) ([]models.Building, error) {
 
 	for rows.Next() {
 		var b models.Building
+		var charachteristicsJSON, coordsJSON []byte
 		err := rows.Scan(
 			&b.Id,
 			&b.Name,
 			&b.Product,
-			&b.Charachteristics,
+			&charachteristicsJSON,
 			&b.Level,
 			&b.UpgradePrice,
+			&coordsJSON,
 		)
 		if err != nil {
 			log.Printf("unable scan row: %v", err)
 			return nil, ErrRows
 		}
+		if err := json.Unmarshal(charachteristicsJSON, &b.Charachteristics); err != nil {
+			log.Printf("Failed to unmarshal characteristics of building ID- %v: %v\n", b.Id, err)
+			return nil, ErrRows
+		}
+		if b.Coordinates, err = unmarshalCoordinates(coordsJSON); err != nil {
+			log.Printf("Failed to unmarshal coordinates of building ID- %v: %v\n", b.Id, err)
+			return nil, ErrRows
+		}
 		buildings = append(buildings, b)
 	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
 	return buildings, nil
 }
 
-// GetHeroes получает всех героев по ID арены
+// GetHeroes получает всех героев по ID арены вместе с их способностями
 func (s *Storage) GetHeroes(areaID int64) ([]models.Hero, error) {
 	// Проверка на валидность areaID
 	if areaID < 1 {
 		log.Printf("Invalid ara id - %v", areaID)
 		return nil, ErrNotValidAreaID
 	}
-	rows, err := s.Db.Query(context.Background(), `SELECT heroes.* FROM areas_heroes
-	JOIN heroes ON areas_buildings.heroes_id=heroes.id WHERE areas_buildings.area_id=$1`, areaID)
+	rows, err := s.Db.Query(context.Background(), `SELECT heroes.id, heroes.name, heroes.characteristics, heroes.experience,
+	heroes.experience_to_up, heroes.level,
+	COALESCE((SELECT json_agg(json_build_object('id', abilities.id, 'name', abilities.name,
+		'characteristics', abilities.characteristics, 'level', abilities.level) ORDER BY abilities.id)
+		FROM hero_ability JOIN abilities ON hero_ability.ability_id = abilities.id
+		WHERE hero_ability.hero_id = heroes.id), '[]') AS abilities,
+	heroes.coordinates FROM areas_heroes
+	JOIN heroes ON areas_heroes.hero_id = heroes.id WHERE areas_heroes.area_id = $1;`, areaID)
 	if err != nil {
 		log.Printf("Cant read data about heroes object from DB: %v\n", err)
 		return nil, ErrDataBase
@@ -211,23 +288,222 @@ func (s *Storage) GetHeroes(areaID int64) ([]models.Hero, error) {
 
 	for rows.Next() {
 		var h models.Hero
+		var charachteristicsJSON, abilitiesJSON, coordsJSON []byte
 		err := rows.Scan(
 			&h.Id,
 			&h.Name,
-			&h.Charachteristics,
+			&charachteristicsJSON,
+			&h.Experience,
 			&h.ExperienceToUp,
 			&h.Level,
-			&h.Abilities,
+			&abilitiesJSON,
+			&coordsJSON,
 		)
 		if err != nil {
 			log.Printf("unable scan row: %v", err)
 			return nil, ErrRows
 		}
+		if err := json.Unmarshal(charachteristicsJSON, &h.Charachteristics); err != nil {
+			log.Printf("Failed to unmarshal characteristics of hero ID- %v: %v\n", h.Id, err)
+			return nil, ErrRows
+		}
+		if err := json.Unmarshal(abilitiesJSON, &h.Abilities); err != nil {
+			log.Printf("Failed to unmarshal abilities of hero ID- %v: %v\n", h.Id, err)
+			return nil, ErrRows
+		}
+		if h.Coordinates, err = unmarshalCoordinates(coordsJSON); err != nil {
+			log.Printf("Failed to unmarshal coordinates of hero ID- %v: %v\n", h.Id, err)
+			return nil, ErrRows
+		}
 		heroes = append(heroes, h)
 	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
 	return heroes, nil
 }
 
+// GetUnits получает всех юнитов по ID арены
+func (s *Storage) GetUnits(areaID int64) ([]models.Unit, error) {
+	// Проверка на валидность areaID
+	if areaID < 1 {
+		log.Printf("Invalid ara id - %v", areaID)
+		return nil, ErrNotValidAreaID
+	}
+	rows, err := s.Db.Query(context.Background(), `SELECT units.id, units.name, units.characteristics, units.experience,
+	units.experience_to_up, units.level, units.coordinates FROM areas_units
+	JOIN units ON areas_units.unit_id = units.id WHERE areas_units.area_id = $1;`, areaID)
+	if err != nil {
+		log.Printf("Cant read data about units object from DB: %v\n", err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var units []models.Unit
+
+	for rows.Next() {
+		var u models.Unit
+		var charachteristicsJSON, coordsJSON []byte
+		err := rows.Scan(
+			&u.Id,
+			&u.Name,
+			&charachteristicsJSON,
+			&u.Experience,
+			&u.ExperienceToUp,
+			&u.Level,
+			&coordsJSON,
+		)
+		if err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		if err := json.Unmarshal(charachteristicsJSON, &u.Charachteristics); err != nil {
+			log.Printf("Failed to unmarshal characteristics of unit ID- %v: %v\n", u.Id, err)
+			return nil, ErrRows
+		}
+		if u.Coordinates, err = unmarshalCoordinates(coordsJSON); err != nil {
+			log.Printf("Failed to unmarshal coordinates of unit ID- %v: %v\n", u.Id, err)
+			return nil, ErrRows
+		}
+		units = append(units, u)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return units, nil
+}
+
+// GetEnemies получает всех врагов по ID арены
+func (s *Storage) GetEnemies(areaID int64) ([]models.Enemy, error) {
+	// Проверка на валидность areaID
+	if areaID < 1 {
+		log.Printf("Invalid ara id - %v", areaID)
+		return nil, ErrNotValidAreaID
+	}
+	rows, err := s.Db.Query(context.Background(), `SELECT enemies.id, enemies.name, enemies.characteristics, enemies.level,
+	enemies.coordinates, COALESCE(areas_enemies.camp, 0) FROM areas_enemies
+	JOIN enemies ON areas_enemies.enemy_id = enemies.id WHERE areas_enemies.area_id = $1;`, areaID)
+	if err != nil {
+		log.Printf("Cant read data about enemies object from DB: %v\n", err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var enemies []models.Enemy
+
+	for rows.Next() {
+		var e models.Enemy
+		var charachteristicsJSON, coordsJSON []byte
+		err := rows.Scan(
+			&e.Id,
+			&e.Name,
+			&charachteristicsJSON,
+			&e.Level,
+			&coordsJSON,
+			&e.Camp,
+		)
+		if err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		if err := json.Unmarshal(charachteristicsJSON, &e.Charachteristics); err != nil {
+			log.Printf("Failed to unmarshal characteristics of enemy ID- %v: %v\n", e.Id, err)
+			return nil, ErrRows
+		}
+		if e.Coordinates, err = unmarshalCoordinates(coordsJSON); err != nil {
+			log.Printf("Failed to unmarshal coordinates of enemy ID- %v: %v\n", e.Id, err)
+			return nil, ErrRows
+		}
+		enemies = append(enemies, e)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return enemies, nil
+}
+
+// GetArea получает арену по ее ID
+func (s *Storage) GetArea(areaID int64) (models.Area, error) {
+	if areaID < 1 {
+		log.Printf("Invalid ara id - %v", areaID)
+		return models.Area{}, ErrNotValidAreaID
+	}
+	return s.getArea(`SELECT id, user_id, width, height, cell_type_id FROM areas WHERE id=$1;`, areaID)
+}
+
+// GetUserArea получает арену пользователя. Если арен несколько, возвращается первая созданная
+func (s *Storage) GetUserArea(userId int64) (models.Area, error) {
+	if userId < 1 {
+		log.Printf("Error!Invalid user id- %v", userId)
+		return models.Area{}, ErrNotValidUserID
+	}
+	return s.getArea(`SELECT id, user_id, width, height, cell_type_id FROM areas WHERE user_id=$1 ORDER BY id LIMIT 1;`, userId)
+}
+
+func (s *Storage) getArea(query string, id int64) (models.Area, error) {
+	var a models.Area
+	err := s.Db.QueryRow(context.Background(), query, id).Scan(&a.Id, &a.UserId, &a.Width, &a.Height, &a.CellTypeId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Area{}, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant read area from database: %v\n", err)
+		return models.Area{}, ErrDataBase
+	}
+	return a, nil
+}
+
+// GetAreaObjectKinds возвращает типы объектов с ID objectID, расположенных на арене areaID.
+// ID объектов разных типов независимы, поэтому на арене может быть, например, и герой, и юнит с одним ID.
+// Если на арене нет объектов с таким ID, возвращается пустой список.
+func (s *Storage) GetAreaObjectKinds(areaID, objectID int64) ([]models.ObjectKind, error) {
+	if areaID < 1 {
+		return nil, ErrNotValidAreaID
+	}
+	rows, err := s.Db.Query(context.Background(), `SELECT 'neutral' FROM areas_neutrals WHERE area_id=$1 AND neutral_id=$2
+		UNION ALL SELECT 'building' FROM areas_buildings WHERE area_id=$1 AND building_id=$2
+		UNION ALL SELECT 'hero' FROM areas_heroes WHERE area_id=$1 AND hero_id=$2
+		UNION ALL SELECT 'unit' FROM areas_units WHERE area_id=$1 AND unit_id=$2
+		UNION ALL SELECT 'enemy' FROM areas_enemies WHERE area_id=$1 AND enemy_id=$2;`, areaID, objectID)
+	if err != nil {
+		log.Printf("Cant read data from database: %v\n", err)
+		return nil, ErrDataBase
+	}
+	defer rows.Close()
+
+	var kinds []models.ObjectKind
+	for rows.Next() {
+		var kind string
+		if err := rows.Scan(&kind); err != nil {
+			log.Printf("unable scan row: %v", err)
+			return nil, ErrRows
+		}
+		kinds = append(kinds, models.ObjectKind(kind))
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error after iterating rows: %v\n", err)
+		return nil, ErrRows
+	}
+	return kinds, nil
+}
+
+// unmarshalCoordinates разбирает координаты объекта. Объект может занимать несколько клеток,
+// поэтому координаты хранятся списком, но допускается и одна клетка без списка.
+func unmarshalCoordinates(data []byte) ([]models.Hex, error) {
+	var coords []models.Hex
+	if err := json.Unmarshal(data, &coords); err == nil {
+		return coords, nil
+	}
+	var hex models.Hex
+	if err := json.Unmarshal(data, &hex); err != nil {
+		return nil, err
+	}
+	return []models.Hex{hex}, nil
+}
+
 // GetObstacles производит выборку координат всех объектов конкретной арены и добавляет их в результирующий слайс []Hex
 func (s *Storage) GetObstacles(areaID int64) ([]models.Hex, error) {
 	// Проверка на валидность areaID
@@ -275,13 +551,13 @@ func (s *Storage) GetObstacles(areaID int64) ([]models.Hex, error) {
 		}
 
 		// Парсим  координаты  из JSON  в Hex
-		var coords models.Hex
-		if err := json.Unmarshal(coordJSON, &coords); err != nil {
+		coords, err := unmarshalCoordinates(coordJSON)
+		if err != nil {
 			return nil, fmt.Errorf("unable to unmarshal coordinates: %w", err)
 		}
 
 		// Добавляем все координаты в общий слайс
-		obstacles = append(obstacles, coords)
+		obstacles = append(obstacles, coords...)
 	}
 
 	// Проверяем ошибки после итерации
@@ -294,7 +570,7 @@ func (s *Storage) GetObstacles(areaID int64) ([]models.Hex, error) {
 
 // AddEmptyArea добавляет пустую(без объектов на ней) арену в базу и возвращает ее ID
 func (s *Storage) AddEmptyArea(a models.Area) (int64, error) {
-	query := `INSERT INTO area
+	query := `INSERT INTO areas
 		(user_id, width,height,cell_type_id) 
 		VALUES ($1,$2,$3,$4) RETURNING id;`
 
@@ -314,8 +590,8 @@ func (s *Storage) AddEmptyArea(a models.Area) (int64, error) {
 // AddNeutral добавляет нейтральный объект в базу и возвращает его ID
 // TODO:избавиться от дополнительно маршалинга координат
 func (s *Storage) AddNeutral(n models.Neutral) (int64, error) {
-	query := `INSERT INTO neutral
-             (name, product, prod_cof, capacity, threshold_level1, threshold_level2, size, coordinates)
+	query := `INSERT INTO neutrals
+             (name, product, productivity_coefficient, capacity, threshold_level1, threshold_level2, size, coordinates)
              VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
 
 	var id int64
@@ -346,9 +622,9 @@ func (s *Storage) AddNeutral(n models.Neutral) (int64, error) {
 // AddBuilding добавляет здание в базу и возвращает его ID
 // TODO:избавиться от дополнительно маршалинга координат и характеристик
 func (s *Storage) AddBuilding(b models.Building) (int64, error) {
-	query := `INSERT INTO building 
-              (name, product, characteristics, level, resources, coordinates) 
-              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
+	query := `INSERT INTO buildings 
+              (name, product, characteristics, level, upgrade_price, size, coordinates) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;`
 
 	var id int64
 	coordsJSON, err := json.Marshal(b.Coordinates)
@@ -375,6 +651,7 @@ func (s *Storage) AddBuilding(b models.Building) (int64, error) {
 		charachteristicsJSON,
 		b.Level,
 		resourcesJSON,
+		b.Charachteristics.Size,
 		coordsJSON).Scan(&id)
 
 	if err != nil {
@@ -385,12 +662,13 @@ func (s *Storage) AddBuilding(b models.Building) (int64, error) {
 	return id, nil
 }
 
-// AddHero добавляет героя в базу и возвращает его ID
+// AddHero добавляет героя в базу и возвращает его ID. Способности героя связываются
+// с ним отдельно через AddAbilityAtHero
 // TODO:избавиться от дополнительно маршалинга координат и характеристик
 func (s *Storage) AddHero(h models.Hero) (int64, error) {
-	query := `INSERT INTO hero 
-              (name, characteristics, experience, experience_to_up, level, abilities, coordinates) 
-              VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;`
+	query := `INSERT INTO heroes 
+              (name, characteristics, experience, experience_to_up, level, coordinates) 
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
 	var id int64
 	coordsJSON, err := json.Marshal(h.Coordinates)
 	if err != nil {
@@ -404,19 +682,12 @@ func (s *Storage) AddHero(h models.Hero) (int64, error) {
 		return 0, ErrNotValidChar
 	}
 
-	abilitiesJSON, err := json.Marshal(h.Abilities)
-	if err != nil {
-		log.Printf("Failed to marshal abilities: %v\n", err)
-		return 0, ErrNotValidAbilities
-	}
-
 	err = s.Db.QueryRow(context.Background(), query,
 		h.Name,
 		charachteristicsJSON,
 		h.Experience,
 		h.ExperienceToUp,
 		h.Level,
-		abilitiesJSON,
 		coordsJSON).Scan(&id)
 
 	if err != nil {
@@ -430,9 +701,9 @@ func (s *Storage) AddHero(h models.Hero) (int64, error) {
 // AddHero добавляет юнита в базу и возвращает его ID
 // TODO:избавиться от дополнительно маршалинга координат и характеристик
 func (s *Storage) AddUnit(u models.Unit) (int64, error) {
-	query := `INSERT INTO unit 
-              (name, characteristics, experience, experience_to_up, level, image_id, coordinates) 
-              VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;`
+	query := `INSERT INTO units 
+              (name, characteristics, experience, experience_to_up, level, coordinates) 
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
 
 	var id int64
 	coordsJSON, err := json.Marshal(u.Coordinates)
@@ -453,7 +724,6 @@ func (s *Storage) AddUnit(u models.Unit) (int64, error) {
 		u.Experience,
 		u.ExperienceToUp,
 		u.Level,
-		u.ImageId,
 		coordsJSON).Scan(&id)
 
 	if err != nil {
@@ -464,8 +734,20 @@ func (s *Storage) AddUnit(u models.Unit) (int64, error) {
 	return id, nil
 }
 
+// AddAbilityAtHero связывает способность с героем
+func (s *Storage) AddAbilityAtHero(abilityId, heroId int64) error {
+	query := `INSERT INTO hero_ability (hero_id, ability_id) VALUES ($1, $2);`
+
+	_, err := s.Db.Exec(context.Background(), query, heroId, abilityId)
+	if err != nil {
+		log.Printf("Cant add link between ability ID- %v and hero ID- %v database! %v\n", abilityId, heroId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
 func (s *Storage) AddNeutralAtArea(neutralId, areaId int64) error {
-	query := `INSERT INTO areas_neutrals (area_id, neutral_id) VALUES $1,$2;`
+	query := `INSERT INTO areas_neutrals (area_id, neutral_id) VALUES ($1, $2);`
 
 	_, err := s.Db.Exec(context.Background(), query, areaId, neutralId)
 	if err != nil {
@@ -476,7 +758,7 @@ func (s *Storage) AddNeutralAtArea(neutralId, areaId int64) error {
 }
 
 func (s *Storage) AddBuildingAtArea(buildingId, areaId int64) error {
-	query := `INSERT INTO areas_buildings (area_id, building_id) VALUES $1,$2;`
+	query := `INSERT INTO areas_buildings (area_id, building_id) VALUES ($1, $2);`
 
 	_, err := s.Db.Exec(context.Background(), query, areaId, buildingId)
 	if err != nil {
@@ -487,7 +769,7 @@ func (s *Storage) AddBuildingAtArea(buildingId, areaId int64) error {
 }
 
 func (s *Storage) AddHeroAtArea(heroId, areaId int64) error {
-	query := `INSERT INTO areas_heroes (area_id, hero_id) VALUES $1,$2;`
+	query := `INSERT INTO areas_heroes (area_id, hero_id) VALUES ($1, $2);`
 
 	_, err := s.Db.Exec(context.Background(), query, areaId, heroId)
 	if err != nil {
@@ -498,7 +780,7 @@ func (s *Storage) AddHeroAtArea(heroId, areaId int64) error {
 }
 
 func (s *Storage) AddUnitAtArea(unitId, areaId int64) error {
-	query := `INSERT INTO areas_heroes (area_id, hero_id) VALUES $1,$2;`
+	query := `INSERT INTO areas_units (area_id, unit_id) VALUES ($1, $2);`
 
 	_, err := s.Db.Exec(context.Background(), query, areaId, unitId)
 	if err != nil {
@@ -507,3 +789,245 @@ func (s *Storage) AddUnitAtArea(unitId, areaId int64) error {
 	}
 	return nil
 }
+
+// AddEnemy добавляет врага в базу и возвращает его ID
+func (s *Storage) AddEnemy(e models.Enemy) (int64, error) {
+	query := `INSERT INTO enemies (name, characteristics, level, coordinates) VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	var id int64
+	coordsJSON, err := json.Marshal(e.Coordinates)
+	if err != nil {
+		log.Printf("Failed to marshal coordinates: %v\n", err)
+		return 0, ErrNotValidCoord
+	}
+
+	charachteristicsJSON, err := json.Marshal(e.Charachteristics)
+	if err != nil {
+		log.Printf("Failed to marshal characteristics: %v\n", err)
+		return 0, ErrNotValidChar
+	}
+
+	err = s.Db.QueryRow(context.Background(), query,
+		e.Name,
+		charachteristicsJSON,
+		e.Level,
+		coordsJSON).Scan(&id)
+
+	if err != nil {
+		log.Printf("Cant add data about enemy in database! %v\n", err)
+		return 0, ErrDataBase
+	}
+
+	return id, nil
+}
+
+// AddEnemyAtArea связывает врага с ареной. camp - номер лагеря врага, 0 - враг волны.
+func (s *Storage) AddEnemyAtArea(enemyId, areaId int64, camp int) error {
+	query := `INSERT INTO areas_enemies (area_id, enemy_id, camp) VALUES ($1, $2, NULLIF($3, 0));`
+
+	_, err := s.Db.Exec(context.Background(), query, areaId, enemyId, camp)
+	if err != nil {
+		log.Printf("Cant add link between enemy ID- %v and area ID- %v database! %v\n", enemyId, areaId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// DeleteEnemy удаляет убитого врага. Связь врага с ареной удаляется каскадно.
+func (s *Storage) DeleteEnemy(enemyId int64) error {
+	query := `DELETE FROM enemies WHERE id=$1;`
+
+	_, err := s.Db.Exec(context.Background(), query, enemyId)
+	if err != nil {
+		log.Printf("Cant delete enemy ID- %v from database! %v\n", enemyId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// UpdateEnemy сохраняет характеристики и координаты врага, например после атаки героя или хода ИИ
+func (s *Storage) UpdateEnemy(e models.Enemy) error {
+	query := `UPDATE enemies SET characteristics=$1, coordinates=$2 WHERE id=$3;`
+
+	charachteristicsJSON, err := json.Marshal(e.Charachteristics)
+	if err != nil {
+		log.Printf("Failed to marshal characteristics: %v\n", err)
+		return ErrNotValidChar
+	}
+
+	coordsJSON, err := json.Marshal(e.Coordinates)
+	if err != nil {
+		log.Printf("Failed to marshal coordinates: %v\n", err)
+		return ErrNotValidCoord
+	}
+
+	_, err = s.Db.Exec(context.Background(), query, charachteristicsJSON, coordsJSON, e.Id)
+	if err != nil {
+		log.Printf("Cant update enemy ID- %v in database! %v\n", e.Id, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// DeleteHero удаляет погибшего героя. Связи героя с ареной и способностями удаляются каскадно.
+func (s *Storage) DeleteHero(heroId int64) error {
+	query := `DELETE FROM heroes WHERE id=$1;`
+
+	_, err := s.Db.Exec(context.Background(), query, heroId)
+	if err != nil {
+		log.Printf("Cant delete hero ID- %v from database! %v\n", heroId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// DeleteUnit удаляет погибшего юнита. Связь юнита с ареной удаляется каскадно.
+func (s *Storage) DeleteUnit(unitId int64) error {
+	query := `DELETE FROM units WHERE id=$1;`
+
+	_, err := s.Db.Exec(context.Background(), query, unitId)
+	if err != nil {
+		log.Printf("Cant delete unit ID- %v from database! %v\n", unitId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// DeleteBuilding удаляет разрушенное здание. Связь здания с ареной удаляется каскадно.
+func (s *Storage) DeleteBuilding(buildingId int64) error {
+	query := `DELETE FROM buildings WHERE id=$1;`
+
+	_, err := s.Db.Exec(context.Background(), query, buildingId)
+	if err != nil {
+		log.Printf("Cant delete building ID- %v from database! %v\n", buildingId, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// GetLeague получает лигу по ID
+func (s *Storage) GetLeague(leagueId int64) (models.League, error) {
+	query := `SELECT id, name, authority FROM leagues WHERE id=$1;`
+
+	var l models.League
+	err := s.Db.QueryRow(context.Background(), query, leagueId).Scan(&l.Id, &l.Name, &l.Authority)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.League{}, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant read league ID- %v from database: %v\n", leagueId, err)
+		return models.League{}, ErrDataBase
+	}
+	return l, nil
+}
+
+// UpdateHeroProgress сохраняет опыт, уровень и характеристики героя
+func (s *Storage) UpdateHeroProgress(h models.Hero) error {
+	query := `UPDATE heroes SET characteristics=$1, experience=$2, experience_to_up=$3, level=$4 WHERE id=$5;`
+
+	charachteristicsJSON, err := json.Marshal(h.Charachteristics)
+	if err != nil {
+		log.Printf("Failed to marshal characteristics: %v\n", err)
+		return ErrNotValidChar
+	}
+
+	_, err = s.Db.Exec(context.Background(), query,
+		charachteristicsJSON,
+		h.Experience,
+		h.ExperienceToUp,
+		h.Level,
+		h.Id)
+	if err != nil {
+		log.Printf("Cant update progress of hero ID- %v in database! %v\n", h.Id, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// UpdateUnitProgress сохраняет опыт, уровень и характеристики юнита
+func (s *Storage) UpdateUnitProgress(u models.Unit) error {
+	query := `UPDATE units SET characteristics=$1, experience=$2, experience_to_up=$3, level=$4 WHERE id=$5;`
+
+	charachteristicsJSON, err := json.Marshal(u.Charachteristics)
+	if err != nil {
+		log.Printf("Failed to marshal characteristics: %v\n", err)
+		return ErrNotValidChar
+	}
+
+	_, err = s.Db.Exec(context.Background(), query,
+		charachteristicsJSON,
+		u.Experience,
+		u.ExperienceToUp,
+		u.Level,
+		u.Id)
+	if err != nil {
+		log.Printf("Cant update progress of unit ID- %v in database! %v\n", u.Id, err)
+		return ErrDataBase
+	}
+	return nil
+}
+
+// GetBuilding получает здание по его ID
+func (s *Storage) GetBuilding(buildingId int64) (models.Building, error) {
+	if buildingId < 1 {
+		log.Printf("Invalid building id - %v", buildingId)
+		return models.Building{}, ErrNotValidBuilding
+	}
+	query := `SELECT id, name, product, characteristics, level, upgrade_price, coordinates FROM buildings WHERE id=$1;`
+
+	var b models.Building
+	var charachteristicsJSON, coordsJSON []byte
+	err := s.Db.QueryRow(context.Background(), query, buildingId).Scan(
+		&b.Id,
+		&b.Name,
+		&b.Product,
+		&charachteristicsJSON,
+		&b.Level,
+		&b.UpgradePrice,
+		&coordsJSON,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Building{}, ErrNotFound
+	}
+	if err != nil {
+		log.Printf("Cant read building ID- %v from database! %v\n", buildingId, err)
+		return models.Building{}, ErrDataBase
+	}
+	if err := json.Unmarshal(charachteristicsJSON, &b.Charachteristics); err != nil {
+		log.Printf("Failed to unmarshal characteristics: %v\n", err)
+		return models.Building{}, ErrNotValidChar
+	}
+	if err := json.Unmarshal(coordsJSON, &b.Coordinates); err != nil {
+		log.Printf("Failed to unmarshal coordinates: %v\n", err)
+		return models.Building{}, ErrNotValidCoord
+	}
+	return b, nil
+}
+
+// UpdateBuilding сохраняет уровень, характеристики и стоимость улучшения здания
+func (s *Storage) UpdateBuilding(b models.Building) error {
+	query := `UPDATE buildings SET characteristics=$1, level=$2, upgrade_price=$3 WHERE id=$4;`
+
+	charachteristicsJSON, err := json.Marshal(b.Charachteristics)
+	if err != nil {
+		log.Printf("Failed to marshal characteristics: %v\n", err)
+		return ErrNotValidChar
+	}
+
+	resourcesJSON, err := json.Marshal(b.UpgradePrice)
+	if err != nil {
+		log.Printf("Failed to marshal resources: %v\n", err)
+		return ErrNotValidRes
+	}
+
+	_, err = s.Db.Exec(context.Background(), query,
+		charachteristicsJSON,
+		b.Level,
+		resourcesJSON,
+		b.Id)
+	if err != nil {
+		log.Printf("Cant update building ID- %v in database! %v\n", b.Id, err)
+		return ErrDataBase
+	}
+	return nil
+}
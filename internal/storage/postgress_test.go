@@ -13,6 +13,8 @@ import (
 
 	"cyber/internal/models"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
 )
@@ -211,6 +213,24 @@ func TestAddUser(t *testing.T) {
 			},
 			expectedError: ErrDataBase,
 		},
+		{
+			name: "Error - Login already taken",
+			user: models.User{
+				Login:        "testuser",
+				Password:     "password123",
+				Email:        "test@example.com",
+				Subscription: true,
+				LeagueId:     10,
+				Balance:      decimal.NewFromFloat(100.50),
+				Level:        5,
+			},
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(`INSERT INTO users \(login, password, email, subscription, league_id, balance, level\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\);`).
+					WithArgs("testuser", "password123", "test@example.com", true, int64(10), decimal.NewFromFloat(100.50), 5).
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+			},
+			expectedError: ErrAlreadyExists,
+		},
 	}
 
 	for _, tt := range tests {
@@ -233,7 +253,7 @@ func TestAddUser(t *testing.T) {
 
 			// Проверяем ошибку
 			if tt.expectedError != nil {
-				assert.Error(t, err, tt.expectedError)
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -244,6 +264,62 @@ func TestAddUser(t *testing.T) {
 	}
 }
 
+func TestGetUserByLogin(t *testing.T) {
+	query := `SELECT id, login, password, email, subscription, league_id, balance, level FROM users WHERE login=\$1;`
+	columns := []string{"id", "login", "password", "email", "subscription", "league_id", "balance", "level"}
+	expectedUser := models.User{Id: 1, Login: "test_user", Password: "$2a$10$hash", Email: "test@example.com",
+		Subscription: true, LeagueId: 2, Balance: decimal.NewFromInt(500), Level: 5}
+
+	tests := []struct {
+		name           string
+		mock           func(mock pgxmock.PgxPoolIface)
+		expectedResult models.User
+		expectedError  error
+	}{
+		{
+			name: "Valid user",
+			mock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("test_user").WillReturnRows(mock.NewRows(columns).AddRow(
+					expectedUser.Id, expectedUser.Login, expectedUser.Password, expectedUser.Email,
+					expectedUser.Subscription, expectedUser.LeagueId, expectedUser.Balance, expectedUser.Level))
+			},
+			expectedResult: expectedUser,
+		},
+		{
+			name: "User not found",
+			mock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("test_user").WillReturnRows(mock.NewRows(columns))
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name: "DB error",
+			mock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("test_user").WillReturnError(errors.New("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.mock(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем результат и ошибку
+			user, err := storage.GetUserByLogin("test_user")
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedResult, user)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestGetUser(t *testing.T) {
 	// Создаём мок базы данных
 	mock, err := pgxmock.NewPool()
@@ -327,6 +403,16 @@ func TestGetUser(t *testing.T) {
 			expectedResult: models.User{},
 			expectedError:  ErrRows,
 		},
+		{
+			name:   "User not found",
+			userId: 7,
+			mock: func() {
+				rows := mock.NewRows([]string{"id", "login", "password", "email", "subscription", "league_id", "balance", "level"})
+				mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(rows)
+			},
+			expectedResult: models.User{},
+			expectedError:  ErrNotFound,
+		},
 	}
 
 	// Проходим по всем тестовым случаям
@@ -413,8 +499,8 @@ func TestGetNeutrals(t *testing.T) {
 			areaID: areaID,
 			mock: func() {
 				rows := mock.NewRows([]string{"id", "name", "product", "productivity_coefficient", "capacity", "threshold_level1", "threshold_level2", "size", "coordinates"}).
-					AddRow(expected[0].Id, expected[0].Name, expected[0].Product, expected[0].ProductivityCoefficient, expected[0].Capacity, expected[0].ThresholdLevel1, expected[0].ThresholdLevel2, expected[0].Size, string(coordJSON1)).
-					AddRow(expected[1].Id, expected[1].Name, expected[1].Product, expected[1].ProductivityCoefficient, expected[1].Capacity, expected[1].ThresholdLevel1, expected[1].ThresholdLevel2, expected[1].Size, string(coordJSON2))
+					AddRow(expected[0].Id, expected[0].Name, expected[0].Product, expected[0].ProductivityCoefficient, expected[0].Capacity, expected[0].ThresholdLevel1, expected[0].ThresholdLevel2, expected[0].Size, coordJSON1).
+					AddRow(expected[1].Id, expected[1].Name, expected[1].Product, expected[1].ProductivityCoefficient, expected[1].Capacity, expected[1].ThresholdLevel1, expected[1].ThresholdLevel2, expected[1].Size, coordJSON2)
 				mock.ExpectQuery(query).WithArgs(areaID).WillReturnRows(rows)
 			},
 			expectedResult: expected,
@@ -502,8 +588,8 @@ func TestGetBuildings(t *testing.T) {
 		},
 		Level: 3,
 		UpgradePrice: []models.Resource{
-			{Id: 1, Name: "Wood", Value: decimal.NewFromFloat(300)},
-			{Id: 2, Name: "Stone", Value: decimal.NewFromFloat(300)},
+			{Id: 1, Name: "Wood", Value: decimal.NewFromInt(300)},
+			{Id: 2, Name: "Stone", Value: decimal.NewFromInt(300)},
 		},
 		Coordinates: []models.Hex{{Q: 1, R: 2}, {Q: 3, R: 4}},
 	}
@@ -519,8 +605,8 @@ func TestGetBuildings(t *testing.T) {
 		},
 		Level: 2,
 		UpgradePrice: []models.Resource{
-			{Id: 1, Name: "Wood", Value: decimal.NewFromFloat(300)},
-			{Id: 2, Name: "Stone", Value: decimal.NewFromFloat(300)},
+			{Id: 1, Name: "Wood", Value: decimal.NewFromInt(300)},
+			{Id: 2, Name: "Stone", Value: decimal.NewFromInt(300)},
 		},
 		Coordinates: []models.Hex{{Q: 4, R: 5}, {Q: 6, R: 7}},
 	}
@@ -535,8 +621,8 @@ func TestGetBuildings(t *testing.T) {
 	coordinatesJSON2, _ := json.Marshal(expectedBuilding2.Coordinates)
 
 	// Ожидаемый SQL-запрос
-	query := `SELECT neutrals.* FROM areas_neutrals 
-	JOIN neutrals ON areas_neutrals.neutral_id=neutrals.id WHERE areas_neutrals.area_id=\$1;`
+	query := `SELECT buildings.* FROM areas_buildings
+	JOIN buildings ON areas_buildings.building_id = buildings.id WHERE areas_buildings.area_id = \$1;`
 
 	// Таблица тестовых случаев
 	tests := []struct {
@@ -663,7 +749,7 @@ func TestGetHeroes(t *testing.T) {
 			Vision:     20,
 			IsRange:    false,
 			AtackRange: decimal.NewFromFloat(1.5),
-			Damage:     80,
+			Damage:     decimal.NewFromInt(80),
 		},
 		Experience:     decimal.NewFromFloat(1500.0),
 		ExperienceToUp: decimal.NewFromFloat(2000.0),
@@ -676,8 +762,8 @@ func TestGetHeroes(t *testing.T) {
 					IsPassive:      false,
 					Radius:         decimal.NewFromFloat(1.0),
 					Cooldown:       3 * time.Second,
-					Damage:         decimal.NewFromFloat(50.0),
-					ProjectilSpeed: decimal.NewFromFloat(10.0),
+					Damage:         decimal.NewFromInt(50),
+					ProjectilSpeed: decimal.NewFromInt(10),
 				},
 				Level:   3,
 				ImageId: 101,
@@ -696,7 +782,7 @@ func TestGetHeroes(t *testing.T) {
 			Vision:     30,
 			IsRange:    true,
 			AtackRange: decimal.NewFromFloat(5.0),
-			Damage:     50,
+			Damage:     decimal.NewFromInt(50),
 		},
 		Experience:     decimal.NewFromFloat(1200.0),
 		ExperienceToUp: decimal.NewFromFloat(1500.0),
@@ -709,8 +795,8 @@ func TestGetHeroes(t *testing.T) {
 					IsPassive:      false,
 					Radius:         decimal.NewFromFloat(5.0),
 					Cooldown:       10 * time.Second,
-					Damage:         decimal.NewFromFloat(100.0),
-					ProjectilSpeed: decimal.NewFromFloat(20.0),
+					Damage:         decimal.NewFromInt(100),
+					ProjectilSpeed: decimal.NewFromInt(20),
 				},
 				Level:   2,
 				ImageId: 102,
@@ -729,12 +815,8 @@ func TestGetHeroes(t *testing.T) {
 	coordinatesJSON2, _ := json.Marshal(expectedHero2.Coordinates)
 
 	// Ожидаемый SQL-запрос
-	query := `
-        SELECT id, name, characteristics, experience, experience_to_up, level, abilities, coordinates 
-        FROM areas_heroes 
-        JOIN heroes ON areas_heroes.hero_id = heroes.id 
-        WHERE areas_heroes.area_id = \$1;
-    `
+	query := `SELECT heroes.* AS abilities, heroes.coordinates FROM areas_heroes
+	JOIN heroes ON areas_heroes.hero_id = heroes.id WHERE areas_heroes.area_id = \$1;`
 
 	// Таблица тестовых случаев
 	tests := []struct {
@@ -858,7 +940,7 @@ func TestAddEmptyArea(t *testing.T) {
 				CellTypeId: 1,
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery(`INSERT INTO area \(user_id, width,height,cell_type_id\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING id;`).
+				mock.ExpectQuery(`INSERT INTO areas \(user_id, width,height,cell_type_id\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING id;`).
 					WithArgs(int64(1), 10, 10, 1).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(123)))
 			},
@@ -874,7 +956,7 @@ func TestAddEmptyArea(t *testing.T) {
 				CellTypeId: 1,
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery(`INSERT INTO area \(user_id, width,height,cell_type_id\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING id;`).
+				mock.ExpectQuery(`INSERT INTO areas \(user_id, width,height,cell_type_id\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING id;`).
 					WithArgs(int64(1), 10, 10, 1).
 					WillReturnError(fmt.Errorf("database error"))
 			},
@@ -940,7 +1022,7 @@ func TestAddNeutral(t *testing.T) {
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
 				coordsJSON, _ := json.Marshal([]models.Hex{{Q: 1.0, R: 2.0}, {Q: 3.0, R: 4.0}})
-				mock.ExpectQuery(`INSERT INTO neutral \(name, product, prod_cof, capacity, threshold_level1, threshold_level2, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id;`).
+				mock.ExpectQuery(`INSERT INTO neutrals \(name, product, productivity_coefficient, capacity, threshold_level1, threshold_level2, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id;`).
 					WithArgs("Tree", "Wood", 1, decimal.NewFromFloat(100), decimal.NewFromFloat(10), decimal.NewFromFloat(5), 2, coordsJSON).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(123)))
 			},
@@ -979,7 +1061,7 @@ func TestAddNeutral(t *testing.T) {
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
 				coordsJSON, _ := json.Marshal([]models.Hex{{Q: 1.0, R: 2.0}, {Q: 3.0, R: 4.0}})
-				mock.ExpectQuery(`INSERT INTO neutral \(name, product, prod_cof, capacity, threshold_level1, threshold_level2, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id;`).
+				mock.ExpectQuery(`INSERT INTO neutrals \(name, product, productivity_coefficient, capacity, threshold_level1, threshold_level2, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id;`).
 					WithArgs("Tree", "Wood", 1, decimal.NewFromFloat(100), decimal.NewFromFloat(10), decimal.NewFromFloat(5), 2, coordsJSON).
 					WillReturnError(fmt.Errorf("database error"))
 			},
@@ -1063,8 +1145,8 @@ func TestAddBuilding(t *testing.T) {
 				Coordinates: []models.Hex{{Q: 1.0, R: 2.0}, {Q: 3.0, R: 4.0}},
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery(`INSERT INTO building \(name, product, characteristics, level, resources, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id;`).
-					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, coordsJSON).
+				mock.ExpectQuery(`INSERT INTO buildings \(name, product, characteristics, level, upgrade_price, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id;`).
+					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, 4, coordsJSON).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(123)))
 			},
 			expectedID:    123,
@@ -1114,8 +1196,8 @@ func TestAddBuilding(t *testing.T) {
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
 				// Настраиваем мок, чтобы вернуть ошибку при маршалинге характеристик
-				mock.ExpectQuery(`INSERT INTO building \(name, product, characteristics, level, resources, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id;`).
-					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, coordsJSON).
+				mock.ExpectQuery(`INSERT INTO buildings \(name, product, characteristics, level, upgrade_price, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id;`).
+					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, 4, coordsJSON).
 					WillReturnError(fmt.Errorf("Failed to marshal characteristics"))
 			},
 			expectedID:    0,
@@ -1175,8 +1257,8 @@ func TestAddBuilding(t *testing.T) {
 					{Id: 1, Name: "Wood"},
 					{Id: 2, Name: "Stone"},
 				})
-				mock.ExpectQuery(`INSERT INTO building \(name, product, characteristics, level, resources, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id;`).
-					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, coordsJSON).
+				mock.ExpectQuery(`INSERT INTO buildings \(name, product, characteristics, level, upgrade_price, size, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id;`).
+					WithArgs("Farm", "Food", characteristicsJSON, 1, resourcesJSON, 4, coordsJSON).
 					WillReturnError(fmt.Errorf("database error"))
 			},
 			expectedID:    0,
@@ -1232,7 +1314,7 @@ func TestAddHero(t *testing.T) {
 			Vision:     10,
 			IsRange:    false,
 			AtackRange: decimal.NewFromFloat(1.0),
-			Damage:     20,
+			Damage:     decimal.NewFromInt(20),
 		},
 		Abilities: []models.Ability{
 			{
@@ -1253,7 +1335,6 @@ func TestAddHero(t *testing.T) {
 	}
 	coordsJSON, _ := json.Marshal(hero.Coordinates)
 	characteristicsJSON, _ := json.Marshal(hero.Charachteristics)
-	abilitiesJSON, _ := json.Marshal(hero.Abilities)
 
 	tests := []struct {
 		name          string
@@ -1266,8 +1347,8 @@ func TestAddHero(t *testing.T) {
 			name: "Success - Hero added",
 			hero: hero,
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery(`INSERT INTO hero \(name, characteristics, experience, experience_to_up, level, abilities, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id;`).
-					WithArgs(hero.Name, characteristicsJSON, hero.Experience, hero.ExperienceToUp, hero.Level, abilitiesJSON, coordsJSON).
+				mock.ExpectQuery(`INSERT INTO heroes \(name, characteristics, experience, experience_to_up, level, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id;`).
+					WithArgs(hero.Name, characteristicsJSON, hero.Experience, hero.ExperienceToUp, hero.Level, coordsJSON).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(123)))
 			},
 			expectedID:    123,
@@ -1288,7 +1369,7 @@ func TestAddHero(t *testing.T) {
 					Vision:     10,
 					IsRange:    false,
 					AtackRange: decimal.NewFromFloat(1.0),
-					Damage:     20,
+					Damage:     decimal.NewFromInt(20),
 				},
 				Abilities: []models.Ability{
 					{
@@ -1328,7 +1409,7 @@ func TestAddHero(t *testing.T) {
 					Vision:     10,
 					IsRange:    false,
 					AtackRange: decimal.NewFromFloat(1.0),
-					Damage:     20,
+					Damage:     decimal.NewFromInt(20),
 				},
 				Abilities: []models.Ability{
 					{
@@ -1348,8 +1429,8 @@ func TestAddHero(t *testing.T) {
 				Coordinates: []models.Hex{{Q: 1.0, R: 2.0}, {Q: 3.0, R: 4.0}},
 			},
 			mockSetup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery(`INSERT INTO hero \(name, characteristics, experience, experience_to_up, level, abilities, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id;`).
-					WithArgs("Ion Mash", characteristicsJSON, decimal.NewFromFloat(150.0), decimal.NewFromFloat(200.0), 1, abilitiesJSON, coordsJSON).
+				mock.ExpectQuery(`INSERT INTO heroes \(name, characteristics, experience, experience_to_up, level, coordinates\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id;`).
+					WithArgs("Ion Mash", characteristicsJSON, decimal.NewFromFloat(150.0), decimal.NewFromFloat(200.0), 1, coordsJSON).
 					WillReturnError(fmt.Errorf("Failed to marshal characteristics"))
 			},
 			expectedID:    0,
@@ -1390,3 +1471,737 @@ func TestAddHero(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateHeroProgress(t *testing.T) {
+	hero := models.Hero{
+		Id:             7,
+		Name:           "Ion Mash",
+		Experience:     decimal.NewFromFloat(20.0),
+		ExperienceToUp: decimal.NewFromFloat(300.0),
+		Level:          2,
+		Charachteristics: models.HeroCharacteristics{
+			HP:         120,
+			HPnow:      120,
+			Armor:      22,
+			Speed:      decimal.NewFromFloat(5.0),
+			Vision:     10,
+			AtackRange: decimal.NewFromFloat(1.0),
+			Damage:     decimal.NewFromInt(24),
+		},
+	}
+	characteristicsJSON, _ := json.Marshal(hero.Charachteristics)
+	query := `UPDATE heroes SET characteristics=\$1, experience=\$2, experience_to_up=\$3, level=\$4 WHERE id=\$5;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name: "Success - progress saved",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, hero.Experience, hero.ExperienceToUp, hero.Level, hero.Id).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, hero.Experience, hero.ExperienceToUp, hero.Level, hero.Id).
+					WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			err = storage.UpdateHeroProgress(hero)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateUnitProgress(t *testing.T) {
+	unit := models.Unit{
+		Id:             3,
+		Name:           "Miner",
+		Experience:     decimal.NewFromFloat(5.0),
+		ExperienceToUp: decimal.NewFromFloat(140.0),
+		Level:          2,
+		Charachteristics: models.UnitCharacteristics{
+			HP:                      90,
+			HPnow:                   90,
+			Armor:                   6,
+			Speed:                   decimal.NewFromFloat(4.0),
+			Vision:                  8,
+			AtackRange:              decimal.NewFromFloat(1.0),
+			Damage:                  decimal.NewFromFloat(17.0),
+			ProductivityCoefficient: 4,
+		},
+	}
+	characteristicsJSON, _ := json.Marshal(unit.Charachteristics)
+	query := `UPDATE units SET characteristics=\$1, experience=\$2, experience_to_up=\$3, level=\$4 WHERE id=\$5;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name: "Success - progress saved",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, unit.Experience, unit.ExperienceToUp, unit.Level, unit.Id).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, unit.Experience, unit.ExperienceToUp, unit.Level, unit.Id).
+					WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			err = storage.UpdateUnitProgress(unit)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBuilding(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer mock.Close()
+
+	storage := &Storage{Db: mock}
+
+	expected := models.Building{
+		Id:               1,
+		Name:             "Farm",
+		Product:          "Food",
+		Charachteristics: models.BuildingCharacteristics{HP: 600, Armor: 20, ProductivityCoefficient: 2, Size: 2},
+		Level:            2,
+		UpgradePrice: models.ResourcePrice{
+			{Name: "Gold", Value: decimal.NewFromFloat(150)},
+			{Name: "Wood", Value: decimal.NewFromFloat(50)},
+		},
+		Coordinates: []models.Hex{{Q: 1, R: 2}, {Q: 1, R: 3}},
+	}
+	characteristicsJSON, _ := json.Marshal(expected.Charachteristics)
+	coordsJSON, _ := json.Marshal(expected.Coordinates)
+	columns := []string{"id", "name", "product", "characteristics", "level", "upgrade_price", "coordinates"}
+
+	query := `SELECT id, name, product, characteristics, level, upgrade_price, coordinates FROM buildings WHERE id=\$1;`
+
+	tests := []struct {
+		name           string
+		buildingId     int64
+		mock           func()
+		expectedResult models.Building
+		expectedError  error
+	}{
+		{
+			name:       "Upgrade price as list",
+			buildingId: 1,
+			mock: func() {
+				rows := mock.NewRows(columns).
+					AddRow(int64(1), "Farm", "Food", characteristicsJSON, 2, []byte(`[{"name":"Gold","value":"150"},{"name":"Wood","value":"50"}]`), coordsJSON)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			},
+			expectedResult: expected,
+		},
+		{
+			name:       "Upgrade price as object from contract",
+			buildingId: 1,
+			mock: func() {
+				rows := mock.NewRows(columns).
+					AddRow(int64(1), "Farm", "Food", characteristicsJSON, 2, []byte(`{"Wood":50,"Gold":150}`), coordsJSON)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			},
+			expectedResult: expected,
+		},
+		{
+			name:       "Legacy decimal upgrade price",
+			buildingId: 1,
+			mock: func() {
+				rows := mock.NewRows(columns).
+					AddRow(int64(1), "Farm", "Food", characteristicsJSON, 2, float64(150), coordsJSON)
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+			},
+			expectedResult: func() models.Building {
+				b := expected
+				b.UpgradePrice = models.ResourcePrice{{Name: models.LegacyPriceResource, Value: decimal.NewFromFloat(150)}}
+				return b
+			}(),
+		},
+		{
+			name:          "Invalid building ID",
+			buildingId:    0,
+			mock:          func() {},
+			expectedError: ErrNotValidBuilding,
+		},
+		{
+			name:       "Not found",
+			buildingId: 2,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(mock.NewRows(columns))
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:       "DB error",
+			buildingId: 1,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(errors.New("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			building, err := storage.GetBuilding(tt.buildingId)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, len(tt.expectedResult.UpgradePrice), len(building.UpgradePrice))
+			for i := range tt.expectedResult.UpgradePrice {
+				assert.Equal(t, tt.expectedResult.UpgradePrice[i].Name, building.UpgradePrice[i].Name)
+				assert.True(t, tt.expectedResult.UpgradePrice[i].Value.Equal(building.UpgradePrice[i].Value))
+			}
+			building.UpgradePrice, tt.expectedResult.UpgradePrice = nil, nil
+			assert.Equal(t, tt.expectedResult, building)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateBuilding(t *testing.T) {
+	building := models.Building{
+		Id:               4,
+		Name:             "Farm",
+		Charachteristics: models.BuildingCharacteristics{HP: 700, Armor: 22, ProductivityCoefficient: 3},
+		Level:            3,
+		UpgradePrice:     models.ResourcePrice{{Name: "Gold", Value: decimal.NewFromFloat(300)}},
+	}
+	characteristicsJSON, _ := json.Marshal(building.Charachteristics)
+	resourcesJSON, _ := json.Marshal(building.UpgradePrice)
+	query := `UPDATE buildings SET characteristics=\$1, level=\$2, upgrade_price=\$3 WHERE id=\$4;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name: "Success - building updated",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, building.Level, resourcesJSON, building.Id).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).
+					WithArgs(characteristicsJSON, building.Level, resourcesJSON, building.Id).
+					WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			err = storage.UpdateBuilding(building)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// Табличный тест для функции AddEnemy
+func TestAddEnemy(t *testing.T) {
+	enemy := models.Enemy{
+		Name: "Goblin",
+		Charachteristics: models.EnemyCharacteristics{
+			HP:     120,
+			Armor:  3,
+			Speed:  decimal.NewFromFloat(1),
+			Vision: 6,
+			Damage: decimal.NewFromFloat(12),
+			Level:  2,
+		},
+		Level:       2,
+		Coordinates: []models.Hex{{Q: 10, R: 12}},
+	}
+	characteristicsJSON, _ := json.Marshal(enemy.Charachteristics)
+	coordsJSON, _ := json.Marshal(enemy.Coordinates)
+	query := `INSERT INTO enemies \(name, characteristics, level, coordinates\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedID    int64
+		expectedError error
+	}{
+		{
+			name: "Success - enemy added",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(enemy.Name, characteristicsJSON, enemy.Level, coordsJSON).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(7)))
+			},
+			expectedID: 7,
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(enemy.Name, characteristicsJSON, enemy.Level, coordsJSON).
+					WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			id, err := storage.AddEnemy(enemy)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedID, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddAbilityAtHero(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `INSERT INTO hero_ability \(hero_id, ability_id\) VALUES \(\$1, \$2\);`
+
+	mock.ExpectExec(query).WithArgs(int64(5), int64(1)).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	assert.NoError(t, storage.AddAbilityAtHero(1, 5))
+
+	mock.ExpectExec(query).WithArgs(int64(5), int64(2)).WillReturnError(fmt.Errorf("database error"))
+	assert.ErrorIs(t, storage.AddAbilityAtHero(2, 5), ErrDataBase)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddEnemyAtArea(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `INSERT INTO areas_enemies \(area_id, enemy_id, camp\) VALUES \(\$1, \$2, NULLIF\(\$3, 0\)\);`
+
+	// Проверяем, что враг связывается с ареной вместе с номером лагеря, а враг волны - без него
+	mock.ExpectExec(query).WithArgs(int64(3), int64(7), 2).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	assert.NoError(t, storage.AddEnemyAtArea(7, 3, 2))
+	mock.ExpectExec(query).WithArgs(int64(3), int64(9), 0).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	assert.NoError(t, storage.AddEnemyAtArea(9, 3, 0))
+
+	mock.ExpectExec(query).WithArgs(int64(3), int64(8), 1).WillReturnError(fmt.Errorf("database error"))
+	assert.ErrorIs(t, storage.AddEnemyAtArea(8, 3, 1), ErrDataBase)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLeague(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `SELECT id, name, authority FROM leagues WHERE id=\$1;`
+
+	mock.ExpectQuery(query).WithArgs(int64(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "authority"}).AddRow(int64(2), "Silver", 20))
+	league, err := storage.GetLeague(2)
+	assert.NoError(t, err)
+	assert.Equal(t, models.League{Id: 2, Name: "Silver", Authority: 20}, league)
+
+	// Проверяем, что отсутствие лиги возвращает ErrNotFound
+	mock.ExpectQuery(query).WithArgs(int64(9)).WillReturnError(pgx.ErrNoRows)
+	_, err = storage.GetLeague(9)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mock.ExpectQuery(query).WithArgs(int64(3)).WillReturnError(fmt.Errorf("database error"))
+	_, err = storage.GetLeague(3)
+	assert.ErrorIs(t, err, ErrDataBase)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUnits(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `SELECT units.* FROM areas_units
+	JOIN units ON areas_units.unit_id = units.id WHERE areas_units.area_id = \$1;`
+
+	expected := models.Unit{
+		Id:               1,
+		Name:             "CyMan",
+		Charachteristics: models.UnitCharacteristics{HP: 1500, HPnow: 1400, Armor: 5, Speed: decimal.NewFromInt(12), AtackRange: decimal.NewFromInt(1), Damage: decimal.NewFromInt(3)},
+		Experience:       decimal.NewFromInt(500),
+		ExperienceToUp:   decimal.NewFromInt(1000),
+		Level:            1,
+		Coordinates:      []models.Hex{{Q: 22, R: 4}},
+	}
+	characteristicsJSON, _ := json.Marshal(expected.Charachteristics)
+	columns := []string{"id", "name", "characteristics", "experience", "experience_to_up", "level", "coordinates"}
+
+	mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(pgxmock.NewRows(columns).
+		AddRow(expected.Id, expected.Name, characteristicsJSON, expected.Experience, expected.ExperienceToUp, expected.Level, []byte(`[{"q": 22, "r": 4}]`)))
+	units, err := storage.GetUnits(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Unit{expected}, units)
+
+	// Проверяем, что некорректные характеристики возвращают ErrRows
+	mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(pgxmock.NewRows(columns).
+		AddRow(expected.Id, expected.Name, []byte("invalid json"), expected.Experience, expected.ExperienceToUp, expected.Level, []byte(`[]`)))
+	_, err = storage.GetUnits(1)
+	assert.ErrorIs(t, err, ErrRows)
+
+	_, err = storage.GetUnits(0)
+	assert.ErrorIs(t, err, ErrNotValidAreaID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEnemies(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `SELECT enemies.* FROM areas_enemies
+	JOIN enemies ON areas_enemies.enemy_id = enemies.id WHERE areas_enemies.area_id = \$1;`
+
+	expected := models.Enemy{
+		Id:   3,
+		Name: "Goblin",
+		Charachteristics: models.EnemyCharacteristics{
+			HP: 100, Armor: 2, Vision: 4, Speed: decimal.NewFromInt(2), AtackRange: decimal.NewFromInt(1),
+			Damage: decimal.NewFromInt(10), Experience: decimal.NewFromInt(20), Level: 2,
+		},
+		Level:       2,
+		Coordinates: []models.Hex{{Q: 25, R: 26}},
+		Camp:        1,
+	}
+	characteristicsJSON, _ := json.Marshal(expected.Charachteristics)
+	columns := []string{"id", "name", "characteristics", "level", "coordinates", "camp"}
+
+	// Проверяем, что координаты одной клеткой без списка тоже читаются, а номер лагеря читается из связи с ареной
+	mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(pgxmock.NewRows(columns).
+		AddRow(expected.Id, expected.Name, characteristicsJSON, expected.Level, []byte(`{"q": 25, "r": 26}`), expected.Camp))
+	enemies, err := storage.GetEnemies(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Enemy{expected}, enemies)
+
+	mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(fmt.Errorf("database error"))
+	_, err = storage.GetEnemies(1)
+	assert.ErrorIs(t, err, ErrDataBase)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetArea(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	columns := []string{"id", "user_id", "width", "height", "cell_type_id"}
+	expected := models.Area{Id: 4, UserId: 2, Width: 100, Height: 80, CellTypeId: 1}
+
+	mock.ExpectQuery(`SELECT id, user_id, width, height, cell_type_id FROM areas WHERE id=\$1;`).WithArgs(int64(4)).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(int64(4), int64(2), 100, 80, 1))
+	area, err := storage.GetArea(4)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, area)
+
+	// Проверяем, что арена пользователя ищется по user_id
+	mock.ExpectQuery(`SELECT id, user_id, width, height, cell_type_id FROM areas WHERE user_id=\$1 ORDER BY id LIMIT 1;`).WithArgs(int64(2)).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(int64(4), int64(2), 100, 80, 1))
+	area, err = storage.GetUserArea(2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, area)
+
+	// Проверяем, что отсутствие арены возвращает ErrNotFound
+	mock.ExpectQuery(`SELECT id, user_id, width, height, cell_type_id FROM areas WHERE id=\$1;`).WithArgs(int64(9)).
+		WillReturnError(pgx.ErrNoRows)
+	_, err = storage.GetArea(9)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = storage.GetUserArea(0)
+	assert.ErrorIs(t, err, ErrNotValidUserID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAreaObjectKinds(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storage := &Storage{Db: mock}
+	query := `SELECT 'neutral' FROM areas_neutrals WHERE area_id=\$1 AND neutral_id=\$2.*UNION ALL SELECT 'enemy' FROM areas_enemies WHERE area_id=\$1 AND enemy_id=\$2;`
+
+	// Проверяем, что возвращаются все типы объектов с этим ID на арене
+	mock.ExpectQuery(query).WithArgs(int64(4), int64(3)).
+		WillReturnRows(pgxmock.NewRows([]string{"kind"}).AddRow("hero").AddRow("unit"))
+	kinds, err := storage.GetAreaObjectKinds(4, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ObjectKind{models.HeroObject, models.UnitObject}, kinds)
+
+	// Проверяем, что объекта нет на арене
+	mock.ExpectQuery(query).WithArgs(int64(4), int64(9)).WillReturnRows(pgxmock.NewRows([]string{"kind"}))
+	kinds, err = storage.GetAreaObjectKinds(4, 9)
+	assert.NoError(t, err)
+	assert.Empty(t, kinds)
+
+	mock.ExpectQuery(query).WithArgs(int64(4), int64(3)).WillReturnError(errors.New("database error"))
+	_, err = storage.GetAreaObjectKinds(4, 3)
+	assert.ErrorIs(t, err, ErrDataBase)
+
+	_, err = storage.GetAreaObjectKinds(0, 3)
+	assert.ErrorIs(t, err, ErrNotValidAreaID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteEnemy(t *testing.T) {
+	query := `DELETE FROM enemies WHERE id=\$1;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name: "Success - enemy deleted",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).WithArgs(int64(7)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).WithArgs(int64(7)).WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем, что враг удаляется по ID
+			err = storage.DeleteEnemy(7)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateEnemy(t *testing.T) {
+	enemy := models.Enemy{
+		Id:               7,
+		Name:             "Goblin",
+		Charachteristics: models.EnemyCharacteristics{HP: 40, Armor: 2, Damage: decimal.NewFromFloat(10)},
+		Coordinates:      []models.Hex{{Q: 3, R: 4}},
+	}
+	characteristicsJSON, _ := json.Marshal(enemy.Charachteristics)
+	coordsJSON, _ := json.Marshal(enemy.Coordinates)
+	query := `UPDATE enemies SET characteristics=\$1, coordinates=\$2 WHERE id=\$3;`
+
+	tests := []struct {
+		name          string
+		mockSetup     func(mock pgxmock.PgxPoolIface)
+		expectedError error
+	}{
+		{
+			name: "Success - enemy updated",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).WithArgs(characteristicsJSON, coordsJSON, enemy.Id).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name: "Error - Database query failed",
+			mockSetup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(query).WithArgs(characteristicsJSON, coordsJSON, enemy.Id).
+					WillReturnError(fmt.Errorf("database error"))
+			},
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mockSetup(mock)
+			storage := &Storage{Db: mock}
+
+			// Проверяем, что сохраняются характеристики и координаты врага
+			err = storage.UpdateEnemy(enemy)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeletePlayerObjects(t *testing.T) {
+	storage := &Storage{}
+
+	tests := []struct {
+		name          string
+		query         string
+		delete        func(id int64) error
+		dbErr         error
+		expectedError error
+	}{
+		{name: "Hero deleted", query: `DELETE FROM heroes WHERE id=\$1;`, delete: storage.DeleteHero},
+		{name: "Unit deleted", query: `DELETE FROM units WHERE id=\$1;`, delete: storage.DeleteUnit},
+		{name: "Building deleted", query: `DELETE FROM buildings WHERE id=\$1;`, delete: storage.DeleteBuilding},
+		{
+			name:          "Database query failed",
+			query:         `DELETE FROM units WHERE id=\$1;`,
+			delete:        storage.DeleteUnit,
+			dbErr:         fmt.Errorf("database error"),
+			expectedError: ErrDataBase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			expect := mock.ExpectExec(tt.query).WithArgs(int64(7))
+			if tt.dbErr != nil {
+				expect.WillReturnError(tt.dbErr)
+			} else {
+				expect.WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			}
+			storage.Db = mock
+
+			// Проверяем, что погибший объект удаляется по ID
+			err = tt.delete(7)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Пакет validation проверяет данные, полученные от клиента, по правилам из тегов validate.
+Правила перечисляются через запятую и проверяются по порядку:
+
+	required    - значение не нулевое
+	optional    - нулевое значение допустимо, остальные правила для него не проверяются
+	positive    - число больше нуля (целые, дробные и decimal.Decimal)
+	nonnegative - число не меньше нуля
+	in_area     - координаты models.Hex целые и находятся в пределах арены из Context
+	oneof=set   - строка входит в набор допустимых названий set из Context
+
+Ошибки возвращаются списком по полям, имя поля берется из тега json.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"cyber/internal/models"
+)
+
+// FieldError - ошибка значения одного поля.
+type FieldError struct {
+	Field   string `json:"field"`   // Имя поля из тега json
+	Message string `json:"message"` // Описание ошибки
+}
+
+// Errors - ошибки проверки по полям.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, f := range e {
+		messages = append(messages, f.Field+": "+f.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Context - данные, с которыми сравниваются значения полей.
+type Context struct {
+	Width  int                 // Ширина арены, 0 - координаты не проверяются
+	Height int                 // Высота арены
+	Names  map[string][]string // Наборы допустимых названий для правила oneof
+}
+
+var (
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	hexType     = reflect.TypeOf(models.Hex{})
+)
+
+// Struct проверяет поля структуры v (или указателя на нее) по тегам validate.
+// Возвращает Errors, если хотя бы одно поле не прошло проверку.
+func Struct(v interface{}, ctx Context) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validation: %T is not a struct", v)
+	}
+
+	var errs Errors
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		rules := t.Field(i).Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+		name := fieldName(t.Field(i))
+		for _, rule := range strings.Split(rules, ",") {
+			message, stop := check(value.Field(i), rule, ctx)
+			if message != "" {
+				errs = append(errs, FieldError{Field: name, Message: message})
+			}
+			if message != "" || stop {
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// check проверяет значение field по правилу rule. Возвращает описание ошибки
+// и признак того, что остальные правила поля проверять не нужно.
+func check(field reflect.Value, rule string, ctx Context) (string, bool) {
+	rule, arg, _ := strings.Cut(rule, "=")
+	switch rule {
+	case "required":
+		if field.IsZero() {
+			return "required", false
+		}
+	case "optional":
+		return "", field.IsZero()
+	case "positive":
+		if sign, ok := sign(field); !ok || sign <= 0 {
+			return "must be positive", false
+		}
+	case "nonnegative":
+		if sign, ok := sign(field); !ok || sign < 0 {
+			return "must not be negative", false
+		}
+	case "in_area":
+		if field.Type() != hexType {
+			return "not a coordinate", false
+		}
+		h := field.Interface().(models.Hex)
+		if h.Q != math.Trunc(h.Q) || h.R != math.Trunc(h.R) {
+			return "must be a cell with integer coordinates", false
+		}
+		if ctx.Width > 0 && (h.Q < 0 || h.R < 0 || h.Q >= float64(ctx.Width) || h.R >= float64(ctx.Height)) {
+			return fmt.Sprintf("must be within area %dx%d", ctx.Width, ctx.Height), false
+		}
+	case "oneof":
+		if field.Kind() != reflect.String || !slices.Contains(ctx.Names[arg], field.String()) {
+			return fmt.Sprintf("unknown %s %q", arg, fmt.Sprint(field.Interface())), false
+		}
+	default:
+		panic("validation: unknown rule " + rule)
+	}
+	return "", false
+}
+
+// sign возвращает знак числа: -1, 0 или 1. ok равен false, если поле не число.
+func sign(field reflect.Value) (int, bool) {
+	if field.Type() == decimalType {
+		return field.Interface().(decimal.Decimal).Sign(), true
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(float64(field.Int())), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compare(float64(field.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		return compare(field.Float()), true
+	}
+	return 0, false
+}
+
+func compare(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"cyber/internal/models"
+)
+
+func TestStruct(t *testing.T) {
+	ctx := Context{Width: 10, Height: 5, Names: map[string][]string{"resource": {"Gold", "Wood"}, "object": {"Farm"}, "attacker": {"hero", "unit"}}}
+
+	tests := []struct {
+		name           string
+		value          interface{}
+		expectedFields Errors
+	}{
+		{
+			name:  "Valid move",
+			value: models.MoveActionCharacteristics{From: models.Hex{Q: 0, R: 0}, To: models.Hex{Q: 9, R: 4}, Speed: decimal.NewFromInt(2)},
+		},
+		{
+			name:  "Move outside area and negative speed",
+			value: &models.MoveActionCharacteristics{From: models.Hex{Q: -1, R: 0}, To: models.Hex{Q: 10, R: 4}, Speed: decimal.NewFromInt(-2)},
+			expectedFields: Errors{
+				{Field: "from", Message: "must be within area 10x5"},
+				{Field: "to", Message: "must be within area 10x5"},
+				{Field: "speed", Message: "must be positive"},
+			},
+		},
+		{
+			name:  "Move between cells",
+			value: models.MoveActionCharacteristics{From: models.Hex{Q: 1.5, R: 0}, To: models.Hex{Q: 2, R: 3.25}},
+			expectedFields: Errors{
+				{Field: "from", Message: "must be a cell with integer coordinates"},
+				{Field: "to", Message: "must be a cell with integer coordinates"},
+			},
+		},
+		{
+			name:  "Valid harvest without optional fields",
+			value: models.HarvestActionCharacteristics{Harvester: 5, NeutralId: 1},
+		},
+		{
+			name:  "Unknown resource",
+			value: models.HarvestActionCharacteristics{ResourceName: "Mithril", Speed: decimal.NewFromFloat(0.5)},
+			expectedFields: Errors{
+				{Field: "resource", Message: `unknown resource "Mithril"`},
+			},
+		},
+		{
+			name:  "Valid build",
+			value: models.BuildActionCharacteristics{ObjectType: "Farm", ConstructionTime: 60, Place: models.Hex{Q: 3, R: 3}},
+		},
+		{
+			name:  "Build without object and time",
+			value: models.BuildActionCharacteristics{Place: models.Hex{Q: 3, R: 5}},
+			expectedFields: Errors{
+				{Field: "object", Message: "required"},
+				{Field: "construction_time", Message: "must be positive"},
+				{Field: "place", Message: "must be within area 10x5"},
+			},
+		},
+		{
+			name:  "Unknown object and negative ID",
+			value: models.BuildActionCharacteristics{Builder: -1, ObjectType: "Castle", ConstructionTime: 1},
+			expectedFields: Errors{
+				{Field: "builder", Message: "must not be negative"},
+				{Field: "object", Message: `unknown object "Castle"`},
+			},
+		},
+		{
+			name:           "Zero damage is not set",
+			value:          models.AttackActionCharacteristics{Atacker: 3, AtackerKind: models.HeroObject, Defenser: 6},
+			expectedFields: nil,
+		},
+		{
+			name:  "Attack without attacker kind",
+			value: models.AttackActionCharacteristics{Atacker: 3, Defenser: 6},
+			expectedFields: Errors{
+				{Field: "atacker_kind", Message: "required"},
+			},
+		},
+		{
+			name:  "Attack by enemy",
+			value: models.AttackActionCharacteristics{Atacker: 3, AtackerKind: models.EnemyObject, Defenser: 6},
+			expectedFields: Errors{
+				{Field: "atacker_kind", Message: `unknown attacker "enemy"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.value, ctx)
+
+			// Проверяем, что ошибки возвращаются по каждому полю, а не только по первому
+			if tt.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var fields Errors
+			if assert.ErrorAs(t, err, &fields) {
+				assert.Equal(t, tt.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestStructWithoutArea(t *testing.T) {
+	// Проверяем, что без размера арены координаты не проверяются
+	err := Struct(models.MoveActionCharacteristics{To: models.Hex{Q: 500, R: 500}}, Context{})
+	assert.NoError(t, err)
+
+	// Проверяем, что дробные координаты отклоняются и без размера арены
+	assert.Error(t, Struct(models.MoveActionCharacteristics{To: models.Hex{Q: 0.5, R: 500}}, Context{}))
+
+	assert.Error(t, Struct(42, Context{}))
+}
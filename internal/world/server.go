@@ -0,0 +1,238 @@
+/*
+gRPC сервер WorldService.
+Создает арены пользователей по шаблонам и управляет ими, чтобы генератор миров
+мог работать отдельным процессом.
+*/
+
+package world
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"cyber/internal/game"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/pkg/pb"
+)
+
+// WorldStore - хранилище арен и их объектов. Реализуется *postgress.Storage.
+type WorldStore interface {
+	game.WorldStore
+	GetArea(areaID int64) (models.Area, error)
+	GetNeutrals(areaID int64) ([]models.Neutral, error)
+	GetBuildings(areaID int64) ([]models.Building, error)
+	GetHeroes(areaID int64) ([]models.Hero, error)
+	GetUnits(areaID int64) ([]models.Unit, error)
+	GetEnemies(areaID int64) ([]models.Enemy, error)
+	ClearArea(areaId int64) error
+	DeleteArea(areaId int64) error
+}
+
+// LiveAreas - арены в памяти сервера, на которых работает ИИ врагов. Реализуется *game.Areas.
+type LiveAreas interface {
+	Get(areaId int64) (*game.LiveArea, error)
+	Unload(areaId int64)
+	FindRoute(areaId int64, start, goal game.Hex, speed float64) (game.Route, error)
+}
+
+// WorldServer реализует pb.WorldServiceServer.
+type WorldServer struct {
+	pb.UnimplementedWorldServiceServer
+	store WorldStore
+	areas LiveAreas
+
+	mu sync.Mutex // изменения объектов существующих арен выполняются по очереди
+}
+
+// Конструктор для WorldServer
+func NewWorldServer(store WorldStore, areas LiveAreas) *WorldServer {
+	return &WorldServer{store: store, areas: areas}
+}
+
+// CreateWorld создает арену пользователя со стартовыми объектами шаблона.
+func (s *WorldServer) CreateWorld(ctx context.Context, req *pb.CreateWorldRequest) (*pb.Area, error) {
+	// Пользователь проверяется до создания арены, чтобы не оставить пустую арену
+	if _, err := s.store.GetUser(req.GetUserId()); err != nil {
+		return nil, statusError(err)
+	}
+	area, err := game.CreateTemplateWorld(s.store, req.GetUserId(), req.GetTemplate(), req.GetSeed())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	s.load(area.Id)
+	return areaResponse(area), nil
+}
+
+// GetArea возвращает арену по ID.
+func (s *WorldServer) GetArea(ctx context.Context, req *pb.AreaRequest) (*pb.Area, error) {
+	area, err := s.store.GetArea(req.GetAreaId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return areaResponse(area), nil
+}
+
+// ListAreaObjects возвращает объекты арены, упорядоченные по виду: нейтральные объекты,
+// здания, герои, юниты и враги.
+func (s *WorldServer) ListAreaObjects(ctx context.Context, req *pb.AreaRequest) (*pb.AreaObjects, error) {
+	area, err := s.store.GetArea(req.GetAreaId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	var objects []*pb.AreaObject
+	neutrals, err := s.store.GetNeutrals(area.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for _, n := range neutrals {
+		objects = append(objects, areaObject(n.Id, models.NeutralObject, n.Name, 0, 0, n.Coordinates))
+	}
+	buildings, err := s.store.GetBuildings(area.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for _, b := range buildings {
+		objects = append(objects, areaObject(b.Id, models.BuildingObject, b.Name, b.Level, b.Charachteristics.HP, b.Coordinates))
+	}
+	heroes, err := s.store.GetHeroes(area.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for _, h := range heroes {
+		objects = append(objects, areaObject(h.Id, models.HeroObject, h.Name, h.Level, h.Charachteristics.HPnow, h.Coordinates))
+	}
+	units, err := s.store.GetUnits(area.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for _, u := range units {
+		objects = append(objects, areaObject(u.Id, models.UnitObject, u.Name, u.Level, u.Charachteristics.HPnow, u.Coordinates))
+	}
+	enemies, err := s.store.GetEnemies(area.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for _, e := range enemies {
+		objects = append(objects, areaObject(e.Id, models.EnemyObject, e.Name, e.Level, e.Charachteristics.HP, e.Coordinates))
+	}
+	return &pb.AreaObjects{Objects: objects}, nil
+}
+
+// DeleteArea удаляет арену вместе с объектами.
+func (s *WorldServer) DeleteArea(ctx context.Context, req *pb.AreaRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.store.DeleteArea(req.GetAreaId()); err != nil {
+		return nil, statusError(err)
+	}
+	s.areas.Unload(req.GetAreaId())
+	return &emptypb.Empty{}, nil
+}
+
+// ResetArea удаляет объекты арены и размещает на ней стартовые объекты шаблона заново.
+// ID арены сохраняется.
+func (s *WorldServer) ResetArea(ctx context.Context, req *pb.ResetAreaRequest) (*pb.Area, error) {
+	if _, err := game.Template(req.GetTemplate()); err != nil {
+		return nil, statusError(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	area, err := s.store.GetArea(req.GetAreaId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	// ИИ не должен работать со старыми объектами арены, пока она заполняется заново
+	s.areas.Unload(area.Id)
+	if err := s.store.ClearArea(area.Id); err != nil {
+		return nil, statusError(err)
+	}
+	if err := game.PopulateWorld(s.store, area, req.GetTemplate(), req.GetSeed()); err != nil {
+		return nil, statusError(err)
+	}
+	s.load(area.Id)
+	return areaResponse(area), nil
+}
+
+// FindPath находит путь юнита по арене в обход ее объектов тем же поиском по арене в памяти,
+// которым сервер проверяет перемещения, и оценивает время его прохождения.
+func (s *WorldServer) FindPath(ctx context.Context, req *pb.PathRequest) (*pb.PathResponse, error) {
+	if req.Start == nil || req.Goal == nil {
+		return nil, status.Error(codes.InvalidArgument, "start and goal are required")
+	}
+	speed := req.GetSpeed()
+	if speed == 0 {
+		var err error
+		if speed, err = game.UnitSpeed(req.GetUnitType()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v %q", err, req.GetUnitType())
+		}
+	}
+	start := game.Hex{Q: req.Start.GetQ(), R: req.Start.GetR()}
+	goal := game.Hex{Q: req.Goal.GetQ(), R: req.Goal.GetR()}
+	route, err := s.areas.FindRoute(req.GetAreaId(), start, goal, speed)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &pb.PathResponse{Cost: route.Cost, TravelTime: durationpb.New(route.TravelTime)}
+	for _, h := range route.Path {
+		resp.Path = append(resp.Path, &pb.Hex{Q: h.Q, R: h.R})
+	}
+	return resp, nil
+}
+
+// load загружает арену в память, чтобы на ней сразу начал работать ИИ врагов.
+// Арена уже сохранена в БД, поэтому ошибка загрузки только логируется:
+// арена будет загружена при первом обращении к ней.
+func (s *WorldServer) load(areaId int64) {
+	if _, err := s.areas.Get(areaId); err != nil {
+		log.Printf("Cant load area ID- %v: %v\n", areaId, err)
+	}
+}
+
+// areaResponse переводит арену в ответ WorldService.
+func areaResponse(a models.Area) *pb.Area {
+	return &pb.Area{
+		Id:         a.Id,
+		UserId:     a.UserId,
+		Width:      int32(a.Width),
+		Height:     int32(a.Height),
+		CellTypeId: int32(a.CellTypeId),
+	}
+}
+
+// areaObject переводит объект арены в сообщение ListAreaObjects.
+func areaObject(id int64, kind models.ObjectKind, name string, level, hp int, coordinates []models.Hex) *pb.AreaObject {
+	o := &pb.AreaObject{Id: id, Kind: string(kind), Name: name, Level: int32(level), Hp: int32(hp)}
+	for _, c := range coordinates {
+		o.Coordinates = append(o.Coordinates, &pb.Hex{Q: c.Q, R: c.R})
+	}
+	return o
+}
+
+// statusError переводит ошибку хранилища или генератора арен в ошибку gRPC с кодом статуса.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrNotValidAreaID),
+		errors.Is(err, storage.ErrNotValidUserID),
+		errors.Is(err, game.ErrUnknownTemplate),
+		errors.Is(err, game.ErrOutOfArea),
+		errors.Is(err, game.ErrNotValidSpeed):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, game.ErrNoPath):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, game.ErrNoFreePlace):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	log.Printf("WorldService error: %v\n", err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,512 @@
+package world
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cyber/internal/game"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/pkg/pb"
+)
+
+// fakeWorldStore хранит арены и их объекты в памяти
+type fakeWorldStore struct {
+	mu        sync.Mutex
+	nextId    int64
+	areas     map[int64]models.Area
+	neutrals  map[int64][]models.Neutral
+	buildings map[int64][]models.Building
+	heroes    map[int64][]models.Hero
+	units     map[int64][]models.Unit
+	enemies   map[int64][]models.Enemy
+
+	// Объекты, еще не связанные с ареной
+	newNeutrals  map[int64]models.Neutral
+	newBuildings map[int64]models.Building
+	newHeroes    map[int64]models.Hero
+	newUnits     map[int64]models.Unit
+	newEnemies   map[int64]models.Enemy
+
+	enemyErr error // ошибка создания врагов, чтобы прервать заполнение арены
+}
+
+func newFakeWorldStore() *fakeWorldStore {
+	return &fakeWorldStore{
+		areas:        make(map[int64]models.Area),
+		neutrals:     make(map[int64][]models.Neutral),
+		buildings:    make(map[int64][]models.Building),
+		heroes:       make(map[int64][]models.Hero),
+		units:        make(map[int64][]models.Unit),
+		enemies:      make(map[int64][]models.Enemy),
+		newNeutrals:  make(map[int64]models.Neutral),
+		newBuildings: make(map[int64]models.Building),
+		newHeroes:    make(map[int64]models.Hero),
+		newUnits:     make(map[int64]models.Unit),
+		newEnemies:   make(map[int64]models.Enemy),
+	}
+}
+
+func (s *fakeWorldStore) id() int64 {
+	s.nextId++
+	return s.nextId
+}
+
+func (s *fakeWorldStore) AddEmptyArea(a models.Area) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a.Id = s.id()
+	s.areas[a.Id] = a
+	return a.Id, nil
+}
+
+func (s *fakeWorldStore) AddNeutral(n models.Neutral) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n.Id = s.id()
+	s.newNeutrals[n.Id] = n
+	return n.Id, nil
+}
+
+func (s *fakeWorldStore) AddBuilding(b models.Building) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b.Id = s.id()
+	s.newBuildings[b.Id] = b
+	return b.Id, nil
+}
+
+func (s *fakeWorldStore) AddHero(h models.Hero) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h.Id = s.id()
+	s.newHeroes[h.Id] = h
+	return h.Id, nil
+}
+
+func (s *fakeWorldStore) AddUnit(u models.Unit) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u.Id = s.id()
+	s.newUnits[u.Id] = u
+	return u.Id, nil
+}
+
+func (s *fakeWorldStore) AddEnemy(e models.Enemy) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enemyErr != nil {
+		return 0, s.enemyErr
+	}
+	e.Id = s.id()
+	s.newEnemies[e.Id] = e
+	return e.Id, nil
+}
+
+func (s *fakeWorldStore) AddAbilityAtHero(abilityId, heroId int64) error {
+	return nil
+}
+
+func (s *fakeWorldStore) AddNeutralAtArea(neutralId, areaId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.neutrals[areaId] = append(s.neutrals[areaId], s.newNeutrals[neutralId])
+	return nil
+}
+
+func (s *fakeWorldStore) AddBuildingAtArea(buildingId, areaId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buildings[areaId] = append(s.buildings[areaId], s.newBuildings[buildingId])
+	return nil
+}
+
+func (s *fakeWorldStore) AddHeroAtArea(heroId, areaId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heroes[areaId] = append(s.heroes[areaId], s.newHeroes[heroId])
+	return nil
+}
+
+func (s *fakeWorldStore) AddUnitAtArea(unitId, areaId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.units[areaId] = append(s.units[areaId], s.newUnits[unitId])
+	return nil
+}
+
+func (s *fakeWorldStore) AddEnemyAtArea(enemyId, areaId int64, camp int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enemy := s.newEnemies[enemyId]
+	enemy.Camp = camp
+	s.enemies[areaId] = append(s.enemies[areaId], enemy)
+	return nil
+}
+
+func (s *fakeWorldStore) GetUser(userId int64) (models.User, error) {
+	if userId < 1 {
+		return models.User{}, storage.ErrNotValidUserID
+	}
+	if userId > 10 {
+		return models.User{}, storage.ErrNotFound
+	}
+	return models.User{Id: userId, Level: 1}, nil
+}
+
+func (s *fakeWorldStore) GetLeague(leagueId int64) (models.League, error) {
+	return models.League{}, storage.ErrNotFound
+}
+
+func (s *fakeWorldStore) GetAreas() ([]models.Area, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var areas []models.Area
+	for _, a := range s.areas {
+		areas = append(areas, a)
+	}
+	return areas, nil
+}
+
+func (s *fakeWorldStore) GetArea(areaID int64) (models.Area, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if areaID < 1 {
+		return models.Area{}, storage.ErrNotValidAreaID
+	}
+	a, ok := s.areas[areaID]
+	if !ok {
+		return models.Area{}, storage.ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *fakeWorldStore) GetNeutrals(areaID int64) ([]models.Neutral, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.neutrals[areaID], nil
+}
+
+func (s *fakeWorldStore) GetBuildings(areaID int64) ([]models.Building, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buildings[areaID], nil
+}
+
+func (s *fakeWorldStore) GetHeroes(areaID int64) ([]models.Hero, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heroes[areaID], nil
+}
+
+func (s *fakeWorldStore) GetUnits(areaID int64) ([]models.Unit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.units[areaID], nil
+}
+
+func (s *fakeWorldStore) GetEnemies(areaID int64) ([]models.Enemy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enemies[areaID], nil
+}
+
+// DeleteEnemy не используется WorldService: врагов убивают в игре
+func (s *fakeWorldStore) DeleteEnemy(enemyId int64) error {
+	return nil
+}
+
+// Изменения арены, сделанные ИИ врагов, не используются WorldService
+func (s *fakeWorldStore) UpdateEnemy(e models.Enemy) error {
+	return nil
+}
+
+func (s *fakeWorldStore) UpdateHeroProgress(h models.Hero) error {
+	return nil
+}
+
+func (s *fakeWorldStore) UpdateUnitProgress(u models.Unit) error {
+	return nil
+}
+
+func (s *fakeWorldStore) UpdateBuilding(b models.Building) error {
+	return nil
+}
+
+func (s *fakeWorldStore) DeleteHero(heroId int64) error {
+	return nil
+}
+
+func (s *fakeWorldStore) DeleteUnit(unitId int64) error {
+	return nil
+}
+
+func (s *fakeWorldStore) DeleteBuilding(buildingId int64) error {
+	return nil
+}
+
+func (s *fakeWorldStore) ClearArea(areaId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.areas[areaId]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(s.neutrals, areaId)
+	delete(s.buildings, areaId)
+	delete(s.heroes, areaId)
+	delete(s.units, areaId)
+	delete(s.enemies, areaId)
+	return nil
+}
+
+func (s *fakeWorldStore) DeleteArea(areaId int64) error {
+	if err := s.ClearArea(areaId); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.areas, areaId)
+	return nil
+}
+
+// newTestServer создает сервер с аренами в памяти, которые останавливаются по завершении теста
+func newTestServer(t *testing.T, store *fakeWorldStore) (*WorldServer, *game.Areas) {
+	areas := game.NewAreas(store, game.DefaultAreasConfig, nil, nil)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		areas.Shutdown(ctx)
+	})
+	return NewWorldServer(store, areas), areas
+}
+
+// newTestClient запускает сервер в памяти процесса и возвращает клиента к нему
+func newTestClient(t *testing.T, srv *WorldServer) pb.WorldServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterWorldServiceServer(grpcServer, srv)
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewWorldServiceClient(conn)
+}
+
+// layout возвращает виды и координаты объектов арены без их ID
+func layout(t *testing.T, client pb.WorldServiceClient, areaId int64) map[string][][]float64 {
+	objects, err := client.ListAreaObjects(context.Background(), &pb.AreaRequest{AreaId: areaId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string][][]float64)
+	for _, o := range objects.Objects {
+		var coordinates []float64
+		for _, c := range o.Coordinates {
+			coordinates = append(coordinates, c.Q, c.R)
+		}
+		result[o.Kind] = append(result[o.Kind], coordinates)
+	}
+	return result
+}
+
+func TestWorldServer(t *testing.T) {
+	srv, areas := newTestServer(t, newFakeWorldStore())
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	// Проверяем, что арена создается по шаблону по умолчанию со всеми видами объектов
+	area, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 1, Seed: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), area.UserId)
+	assert.Equal(t, int32(400), area.Width)
+	objects := layout(t, client, area.Id)
+	for _, kind := range []string{"neutral", "building", "hero", "unit"} {
+		assert.Len(t, objects[kind], 1, kind)
+	}
+	assert.NotEmpty(t, objects["enemy"])
+
+	// Проверяем, что созданная арена загружена в память вместе с врагами
+	live, err := areas.Get(area.Id)
+	assert.NoError(t, err)
+	live.World.Lock()
+	assert.Len(t, live.World.Enemies, len(objects["enemy"]))
+	live.World.Unlock()
+
+	// Проверяем, что одинаковые зерно и шаблон дают одинаковое расположение объектов
+	same, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 2, Seed: 42})
+	assert.NoError(t, err)
+	assert.NotEqual(t, area.Id, same.Id)
+	assert.Equal(t, objects, layout(t, client, same.Id))
+
+	// Проверяем, что сброс заменяет объекты арены, сохраняя ее ID
+	reset, err := client.ResetArea(ctx, &pb.ResetAreaRequest{AreaId: area.Id, Template: "peaceful", Seed: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, area.Id, reset.Id)
+	objects = layout(t, client, area.Id)
+	assert.Len(t, objects["hero"], 1)
+	assert.Empty(t, objects["enemy"])
+
+	// Проверяем, что в памяти старые объекты арены заменены новыми
+	reloaded, err := areas.Get(area.Id)
+	assert.NoError(t, err)
+	assert.NotSame(t, live, reloaded)
+	reloaded.World.Lock()
+	assert.Empty(t, reloaded.World.Enemies)
+	reloaded.World.Unlock()
+
+	got, err := client.GetArea(ctx, &pb.AreaRequest{AreaId: area.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, area.Id, got.Id)
+
+	// Проверяем, что удаленная арена больше не находится
+	_, err = client.DeleteArea(ctx, &pb.AreaRequest{AreaId: area.Id})
+	assert.NoError(t, err)
+	_, err = client.GetArea(ctx, &pb.AreaRequest{AreaId: area.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestWorldServerErrors(t *testing.T) {
+	store := newFakeWorldStore()
+	srv, _ := newTestServer(t, store)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		call         func() error
+		expectedCode codes.Code
+	}{
+		{"Unknown template", func() error {
+			_, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 1, Template: "castle"})
+			return err
+		}, codes.InvalidArgument},
+		{"User not found", func() error {
+			_, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 20})
+			return err
+		}, codes.NotFound},
+		{"Invalid user", func() error {
+			_, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"Area not found", func() error {
+			_, err := client.ListAreaObjects(ctx, &pb.AreaRequest{AreaId: 9})
+			return err
+		}, codes.NotFound},
+		{"Invalid area", func() error {
+			_, err := client.GetArea(ctx, &pb.AreaRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"Reset of missing area", func() error {
+			_, err := client.ResetArea(ctx, &pb.ResetAreaRequest{AreaId: 9})
+			return err
+		}, codes.NotFound},
+		{"Reset with unknown template", func() error {
+			_, err := client.ResetArea(ctx, &pb.ResetAreaRequest{AreaId: 9, Template: "castle"})
+			return err
+		}, codes.InvalidArgument},
+		{"Delete of missing area", func() error {
+			_, err := client.DeleteArea(ctx, &pb.AreaRequest{AreaId: 9})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что ошибка возвращается с кодом статуса gRPC
+			assert.Equal(t, tt.expectedCode, status.Code(tt.call()))
+		})
+	}
+	// Проверяем, что при ошибках арены не создаются
+	assert.Empty(t, store.areas)
+}
+
+func TestWorldServerPopulateFailure(t *testing.T) {
+	store := newFakeWorldStore()
+	srv, _ := newTestServer(t, store)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	area, err := client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 1, Seed: 42})
+	assert.NoError(t, err)
+
+	// Проверяем, что арена, которую не удалось заполнить, удаляется вместе с созданными объектами
+	store.enemyErr = errors.New("database error")
+	_, err = client.CreateWorld(ctx, &pb.CreateWorldRequest{UserId: 2, Seed: 42})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Len(t, store.areas, 1)
+	assert.Contains(t, store.areas, area.Id)
+
+	// Проверяем, что после неудачного сброса на арене не остается части новых объектов
+	_, err = client.ResetArea(ctx, &pb.ResetAreaRequest{AreaId: area.Id, Seed: 7})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, store.areas, area.Id)
+	assert.Empty(t, store.neutrals[area.Id])
+	assert.Empty(t, store.buildings[area.Id])
+	assert.Empty(t, store.heroes[area.Id])
+	assert.Empty(t, store.units[area.Id])
+}
+
+func TestWorldServerFindPath(t *testing.T) {
+	store := newFakeWorldStore()
+	store.areas[1] = models.Area{Id: 1, UserId: 1, Width: 5, Height: 5}
+	// Стена по q=2 с проходом только в r=4
+	store.neutrals[1] = []models.Neutral{{Id: 1, Coordinates: []models.Hex{{Q: 2, R: 0}, {Q: 2, R: 1}, {Q: 2, R: 2}, {Q: 2, R: 3}}}}
+	srv, _ := newTestServer(t, store)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+	request := func(goal *pb.Hex, unitType string, speed float64) *pb.PathRequest {
+		return &pb.PathRequest{AreaId: 1, Start: &pb.Hex{Q: 0, R: 0}, Goal: goal, UnitType: unitType, Speed: speed}
+	}
+
+	// Проверяем, что путь обходит объекты арены, а время считается по скорости типа юнита
+	resp, err := client.FindPath(ctx, request(&pb.Hex{Q: 4, R: 0}, "unit", 0))
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Path, 10) {
+		assert.Equal(t, &pb.Hex{Q: 2, R: 4}, resp.Path[5])
+	}
+	assert.Equal(t, 20.0, resp.Cost)
+	assert.Equal(t, 2500*time.Millisecond, resp.TravelTime.AsDuration())
+
+	// Проверяем, что заданная скорость заменяет скорость типа юнита
+	resp, err = client.FindPath(ctx, request(&pb.Hex{Q: 1, R: 0}, "", 0.5))
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, resp.TravelTime.AsDuration())
+
+	tests := []struct {
+		name         string
+		req          *pb.PathRequest
+		expectedCode codes.Code
+	}{
+		{"Goal is occupied", request(&pb.Hex{Q: 2, R: 1}, "hero", 0), codes.NotFound},
+		{"Goal out of area", request(&pb.Hex{Q: 9, R: 0}, "hero", 0), codes.InvalidArgument},
+		{"Unknown unit type", request(&pb.Hex{Q: 1, R: 0}, "tank", 0), codes.InvalidArgument},
+		{"Negative speed", request(&pb.Hex{Q: 1, R: 0}, "hero", -1), codes.InvalidArgument},
+		{"Without goal", request(nil, "hero", 0), codes.InvalidArgument},
+		{"Area not found", &pb.PathRequest{AreaId: 9, Start: &pb.Hex{}, Goal: &pb.Hex{Q: 1}, UnitType: "hero"}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Проверяем, что ошибка возвращается с кодом статуса gRPC
+			_, err := client.FindPath(ctx, tt.req)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -1,13 +1,227 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	server "cyber/internal/api"
+	"cyber/internal/auth"
+	"cyber/internal/config"
+	"cyber/internal/events"
+	"cyber/internal/game"
+	"cyber/internal/interceptors"
+	"cyber/internal/ledger"
+	"cyber/internal/logic"
+	"cyber/internal/models"
+	storage "cyber/internal/storage"
+	"cyber/internal/world"
+	"cyber/pkg/pb"
+)
+
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Один пул подключений к БД на весь сервер
+	db, err := storage.Connect(ctx, cfg.DSN())
+	if err != nil {
+		log.Fatalf("Cant connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// События игры рассылаются подписанным websocket соединениям через общую шину
+	bus := events.NewBus()
+	registry := server.NewRegistry(server.DefaultQueueSize)
+	go registry.Run(ctx, bus)
+
+	// Опыт начисляется героям и юнитам за убийства, добычу и строительство, о новых уровнях узнает клиент
+	experience := game.NewExperience(game.DefaultExperienceConfig, db, func(e game.LevelUpEvent) {
+		bus.Publish(events.Event{Type: e.Type, AreaId: e.AreaId, Payload: e})
+	})
+	// Арены загружаются в память, где на них по тикам работает ИИ врагов;
+	// события ИИ рассылаются подписчикам арены через общую шину
+	// Размер группового перемещения задается конфигурацией игры
+	areasConfig := game.DefaultAreasConfig
+	areasConfig.MaxGroupUnits = cfg.MaxGroupUnits
+	areas := game.NewAreas(db, areasConfig, experience, func(e game.AIEvent) {
+		bus.Publish(events.Event{Type: e.Type, AreaId: e.AreaId, Payload: e})
+	})
+	if err := areas.LoadAll(); err != nil {
+		log.Printf("Cant load areas: %v\n", err)
+	}
+
+	upgrader := game.NewUpgrader(game.DefaultUpgradeTable, db, ledger.New(db), areas, func(e game.UpgradeEvent) {
+		bus.Publish(events.Event{Type: e.Type, AreaId: e.AreaId, Payload: e})
+	})
+	// Websocket соединения и запросы state принимаются только с токеном сессии,
+	// который пользователь получает при регистрации или входе
+	tokens := auth.NewTokens([]byte(cfg.AuthSecret), cfg.SessionTTL)
+	authenticator := server.NewAuthenticator(tokens)
+	accounts := server.NewAuthHandler(auth.NewService(db, tokens))
+
+	// Частота и размер сообщений websocket ограничиваются, срабатывания ограничений видны в /metrics
+	metrics := prometheus.NewRegistry()
+	actionLimits := make(map[string]server.Limit, len(cfg.ActionLimits))
+	for action, l := range cfg.ActionLimits {
+		actionLimits[action] = server.Limit{Rate: l.Rate, Burst: l.Burst}
+	}
+	limits := server.DefaultLimits.WithActions(actionLimits)
+	limits.MaxMessageSize = cfg.MaxMessageSize
+	limits.Connection = server.Limit{Rate: cfg.ConnRate, Burst: cfg.ConnBurst}
+	limits.User = server.Limit{Rate: cfg.UserRate, Burst: cfg.UserBurst}
+	limits.Violations = server.Limit{Rate: float64(cfg.MaxViolations) / 60, Burst: cfg.MaxViolations}
+	limiter := server.NewRateLimiter(limits, server.NewMetrics(metrics))
+
+	// Действия других микросервисов и добыча и строительство из websocket сохраняются в БД
+	// и завершаются планировщиком
+	// Смены статусов действий идут через общую шину: их получают и websocket сессии, и WatchActions
+	// За выполненные добычу и строительство юнит получает опыт
+	scheduler := game.NewScheduler(db, func(e game.ActionEvent) {
+		bus.Publish(events.Event{Type: e.Type, UserId: e.UserId, AreaId: e.AreaId, ActionId: e.ActionId, Payload: e})
+	}, func(a models.Action) {
+		if err := areas.Reward(a); err != nil {
+			log.Printf("Cant reward action ID- %v: %v\n", a.Id, err)
+		}
+	})
+
+	wsServer := server.NewWebsocketServer(server.NewWebSocketHandler(db, areas, upgrader, scheduler, registry, limiter), authenticator)
+	wsServer.Handle("/register", http.HandlerFunc(accounts.Register))
+	wsServer.Handle("/login", http.HandlerFunc(accounts.Login))
+	// Инициализирующий запрос: арена пользователя создается при первом входе
+	wsServer.Handle("/state", authenticator.Require(server.NewStateHandler(db, func(userId int64) (int64, error) {
+		areaId, err := game.CreateUserWorld(db, userId)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := areas.Get(areaId); err != nil {
+			log.Printf("Cant load area ID- %v: %v\n", areaId, err)
+		}
+		return areaId, nil
+	})))
+
+	logicServer := logic.NewGameLogicServer(db, scheduler, bus)
+	grpcOptions, err := grpcServerOptions(cfg, metrics)
+	if err != nil {
+		log.Fatalf("Invalid gRPC configuration: %v", err)
+	}
+	grpcServer := grpc.NewServer(grpcOptions...)
+	pb.RegisterGameLogicServiceServer(grpcServer, logicServer)
+	// Генератор арен пока работает в этом же процессе, но доступен другим сервисам только через WorldService
+	pb.RegisterWorldServiceServer(grpcServer, world.NewWorldServer(db, areas))
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		log.Fatalf("Cant listen gRPC address %s: %v", cfg.GRPCAddr, err)
+	}
+	wsListener, err := net.Listen("tcp", cfg.WSAddr)
+	if err != nil {
+		log.Fatalf("Cant listen WebSocket address %s: %v", cfg.WSAddr, err)
+	}
+	// Метрики отдаются на отдельном внутреннем адресе, а не рядом с публичным websocket API
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
+	metricsServer := &http.Server{Handler: metricsMux, ReadHeaderTimeout: 5 * time.Second}
+	metricsListener, err := net.Listen("tcp", cfg.MetricsAddr)
+	if err != nil {
+		log.Fatalf("Cant listen metrics address %s: %v", cfg.MetricsAddr, err)
+	}
+
+	errs := make(chan error, 3)
+	go func() {
+		log.Printf("gRPC server starting at: %s...\n", grpcListener.Addr())
+		errs <- grpcServer.Serve(grpcListener)
+	}()
+	go func() {
+		errs <- wsServer.Serve(wsListener)
+	}()
+	go func() {
+		log.Printf("Metrics server starting at: %s...\n", metricsListener.Addr())
+		if err := metricsServer.Serve(metricsListener); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Println("Shutdown signal received")
+	case err := <-errs:
+		log.Printf("Server failed: %v", err)
+	}
+	stop()
+
+	shutdown(cfg, wsServer, metricsServer, logicServer, grpcServer, upgrader, scheduler, areas)
+}
+
+// grpcServerOptions настраивает TLS gRPC сервера и перехватчики: проверку сервиса-клиента
+// по общему токену или сертификату mTLS, журнал вызовов, метрики и восстановление после паники.
+func grpcServerOptions(cfg config.Config, metrics prometheus.Registerer) ([]grpc.ServerOption, error) {
+	var auth *interceptors.ServiceAuth
+	if cfg.GRPCToken != "" || cfg.GRPCClientCA != "" {
+		auth = interceptors.NewServiceAuth(cfg.GRPCToken, cfg.GRPCServices...)
+	} else {
+		// Config.Load допускает это только с GRPC_INSECURE
+		log.Println("GRPC_INSECURE is set, gRPC calls are not authenticated")
+	}
+	options := interceptors.ServerOptions(auth, interceptors.NewMetrics(metrics))
+	if cfg.GRPCCert != "" {
+		creds, err := interceptors.ServerTLS(cfg.GRPCCert, cfg.GRPCKey, cfg.GRPCClientCA)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+	return options, nil
+}
+
+// shutdown останавливает серверы в порядке, при котором уже начатые действия
+// успевают сохранить свое состояние до закрытия пула подключений к БД.
+func shutdown(cfg config.Config, wsServer *server.WebSocketServer, metricsServer *http.Server, logicServer *logic.GameLogicServer,
+	grpcServer *grpc.Server, upgrader *game.Upgrader, scheduler *game.Scheduler, areas *game.Areas) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := wsServer.Shutdown(ctx); err != nil {
+		log.Printf("WebSocket server shutdown: %v", err)
+	}
 
-	//go func() {
-	//	wsServer := server.NewWebSocketServer()
-	//	log.Println("WebSocket server is listening on :8080...")
-	//	if err := wsServer.Start(":8080"); err != nil {
-	//		log.Fatalf("WebSocket server failed: %v", err)
-	//	}
-	//}()
+	logicServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
 
+	if err := upgrader.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("Upgrades shutdown: %v", err)
+	}
+	if err := scheduler.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("Actions shutdown: %v", err)
+	}
+	if err := areas.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("Areas shutdown: %v", err)
+	}
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Printf("Metrics server shutdown: %v", err)
+	}
+	log.Println("Server stopped")
 }
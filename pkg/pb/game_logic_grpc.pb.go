@@ -4,11 +4,10 @@
 // - protoc             v5.29.3
 // source: game_logic.proto
 
-package cyber
+package pb
 
 import (
 	context "context"
-	"cyber/pkg/pb"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -21,16 +20,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GameLogicService_GetAction_FullMethodName = "/game_logic.GameLogicService/GetAction"
-	GameLogicService_AddAction_FullMethodName = "/game_logic.GameLogicService/AddAction"
+	GameLogicService_GetAction_FullMethodName    = "/game_logic.GameLogicService/GetAction"
+	GameLogicService_AddAction_FullMethodName    = "/game_logic.GameLogicService/AddAction"
+	GameLogicService_WatchActions_FullMethodName = "/game_logic.GameLogicService/WatchActions"
 )
 
 // GameLogicServiceClient is the client API for GameLogicService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GameLogicServiceClient interface {
-	GetAction(ctx context.Context, in *pb.ActionRequest, opts ...grpc.CallOption) (*pb.ActionResponse, error)
-	AddAction(ctx context.Context, in *pb.LogicRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAction(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	AddAction(ctx context.Context, in *LogicRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WatchActions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ActionStatus], error)
 }
 
 type gameLogicServiceClient struct {
@@ -41,9 +42,9 @@ func NewGameLogicServiceClient(cc grpc.ClientConnInterface) GameLogicServiceClie
 	return &gameLogicServiceClient{cc}
 }
 
-func (c *gameLogicServiceClient) GetAction(ctx context.Context, in *pb.ActionRequest, opts ...grpc.CallOption) (*pb.ActionResponse, error) {
+func (c *gameLogicServiceClient) GetAction(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(pb.ActionResponse)
+	out := new(ActionResponse)
 	err := c.cc.Invoke(ctx, GameLogicService_GetAction_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -51,7 +52,7 @@ func (c *gameLogicServiceClient) GetAction(ctx context.Context, in *pb.ActionReq
 	return out, nil
 }
 
-func (c *gameLogicServiceClient) AddAction(ctx context.Context, in *pb.LogicRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *gameLogicServiceClient) AddAction(ctx context.Context, in *LogicRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, GameLogicService_AddAction_FullMethodName, in, out, cOpts...)
@@ -61,12 +62,32 @@ func (c *gameLogicServiceClient) AddAction(ctx context.Context, in *pb.LogicRequ
 	return out, nil
 }
 
+func (c *gameLogicServiceClient) WatchActions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ActionStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameLogicService_ServiceDesc.Streams[0], GameLogicService_WatchActions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, ActionStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameLogicService_WatchActionsClient = grpc.ServerStreamingClient[ActionStatus]
+
 // GameLogicServiceServer is the server API for GameLogicService service.
 // All implementations must embed UnimplementedGameLogicServiceServer
 // for forward compatibility.
 type GameLogicServiceServer interface {
-	GetAction(context.Context, *pb.ActionRequest) (*pb.ActionResponse, error)
-	AddAction(context.Context, *pb.LogicRequest) (*emptypb.Empty, error)
+	GetAction(context.Context, *ActionRequest) (*ActionResponse, error)
+	AddAction(context.Context, *LogicRequest) (*emptypb.Empty, error)
+	WatchActions(*WatchRequest, grpc.ServerStreamingServer[ActionStatus]) error
 	mustEmbedUnimplementedGameLogicServiceServer()
 }
 
@@ -77,12 +98,15 @@ type GameLogicServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedGameLogicServiceServer struct{}
 
-func (UnimplementedGameLogicServiceServer) GetAction(context.Context, *pb.ActionRequest) (*pb.ActionResponse, error) {
+func (UnimplementedGameLogicServiceServer) GetAction(context.Context, *ActionRequest) (*ActionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAction not implemented")
 }
-func (UnimplementedGameLogicServiceServer) AddAction(context.Context, *pb.LogicRequest) (*emptypb.Empty, error) {
+func (UnimplementedGameLogicServiceServer) AddAction(context.Context, *LogicRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddAction not implemented")
 }
+func (UnimplementedGameLogicServiceServer) WatchActions(*WatchRequest, grpc.ServerStreamingServer[ActionStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchActions not implemented")
+}
 func (UnimplementedGameLogicServiceServer) mustEmbedUnimplementedGameLogicServiceServer() {}
 func (UnimplementedGameLogicServiceServer) testEmbeddedByValue()                          {}
 
@@ -105,7 +129,7 @@ func RegisterGameLogicServiceServer(s grpc.ServiceRegistrar, srv GameLogicServic
 }
 
 func _GameLogicService_GetAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(pb.ActionRequest)
+	in := new(ActionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -117,13 +141,13 @@ func _GameLogicService_GetAction_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: GameLogicService_GetAction_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GameLogicServiceServer).GetAction(ctx, req.(*pb.ActionRequest))
+		return srv.(GameLogicServiceServer).GetAction(ctx, req.(*ActionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _GameLogicService_AddAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(pb.LogicRequest)
+	in := new(LogicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -135,11 +159,22 @@ func _GameLogicService_AddAction_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: GameLogicService_AddAction_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GameLogicServiceServer).AddAction(ctx, req.(*pb.LogicRequest))
+		return srv.(GameLogicServiceServer).AddAction(ctx, req.(*LogicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameLogicService_WatchActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameLogicServiceServer).WatchActions(m, &grpc.GenericServerStream[WatchRequest, ActionStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameLogicService_WatchActionsServer = grpc.ServerStreamingServer[ActionStatus]
+
 // GameLogicService_ServiceDesc is the grpc.ServiceDesc for GameLogicService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -156,6 +191,12 @@ var GameLogicService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _GameLogicService_AddAction_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchActions",
+			Handler:       _GameLogicService_WatchActions_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "game_logic.proto",
 }
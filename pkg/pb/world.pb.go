@@ -0,0 +1,751 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.3
+// source: world.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CreateWorldRequest - запрос на создание арены.
+type CreateWorldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"` // Шаблон арены, пустой - шаблон по умолчанию
+	Seed          int64                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`        // Зерно генератора, 0 - случайное. Одно зерно и шаблон дают одинаковую арену
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorldRequest) Reset() {
+	*x = CreateWorldRequest{}
+	mi := &file_world_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorldRequest) ProtoMessage() {}
+
+func (x *CreateWorldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorldRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorldRequest) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateWorldRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateWorldRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CreateWorldRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type AreaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int64                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreaRequest) Reset() {
+	*x = AreaRequest{}
+	mi := &file_world_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreaRequest) ProtoMessage() {}
+
+func (x *AreaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaRequest.ProtoReflect.Descriptor instead.
+func (*AreaRequest) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AreaRequest) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+// ResetAreaRequest - запрос на повторное создание объектов арены. ID арены не меняется.
+type ResetAreaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int64                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Seed          int64                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetAreaRequest) Reset() {
+	*x = ResetAreaRequest{}
+	mi := &file_world_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetAreaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetAreaRequest) ProtoMessage() {}
+
+func (x *ResetAreaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetAreaRequest.ProtoReflect.Descriptor instead.
+func (*ResetAreaRequest) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResetAreaRequest) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *ResetAreaRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *ResetAreaRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type Area struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	CellTypeId    int32                  `protobuf:"varint,5,opt,name=cell_type_id,json=cellTypeId,proto3" json:"cell_type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Area) Reset() {
+	*x = Area{}
+	mi := &file_world_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Area) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Area) ProtoMessage() {}
+
+func (x *Area) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Area.ProtoReflect.Descriptor instead.
+func (*Area) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Area) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Area) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Area) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Area) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Area) GetCellTypeId() int32 {
+	if x != nil {
+		return x.CellTypeId
+	}
+	return 0
+}
+
+// Hex - клетка арены в осевых координатах.
+type Hex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             float64                `protobuf:"fixed64,1,opt,name=q,proto3" json:"q,omitempty"`
+	R             float64                `protobuf:"fixed64,2,opt,name=r,proto3" json:"r,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hex) Reset() {
+	*x = Hex{}
+	mi := &file_world_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hex) ProtoMessage() {}
+
+func (x *Hex) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hex.ProtoReflect.Descriptor instead.
+func (*Hex) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Hex) GetQ() float64 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *Hex) GetR() float64 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+// AreaObject - объект арены.
+type AreaObject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // neutral, building, hero, unit или enemy
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"` // 0 у нейтральных объектов
+	Hp            int32                  `protobuf:"varint,5,opt,name=hp,proto3" json:"hp,omitempty"`       // Текущее здоровье, 0 у нейтральных объектов
+	Coordinates   []*Hex                 `protobuf:"bytes,6,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreaObject) Reset() {
+	*x = AreaObject{}
+	mi := &file_world_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreaObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreaObject) ProtoMessage() {}
+
+func (x *AreaObject) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaObject.ProtoReflect.Descriptor instead.
+func (*AreaObject) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AreaObject) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AreaObject) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AreaObject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AreaObject) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *AreaObject) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *AreaObject) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type AreaObjects struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objects       []*AreaObject          `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreaObjects) Reset() {
+	*x = AreaObjects{}
+	mi := &file_world_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreaObjects) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreaObjects) ProtoMessage() {}
+
+func (x *AreaObjects) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaObjects.ProtoReflect.Descriptor instead.
+func (*AreaObjects) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AreaObjects) GetObjects() []*AreaObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+// PathRequest - запрос пути юнита между двумя клетками арены.
+type PathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int64                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	Start         *Hex                   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Goal          *Hex                   `protobuf:"bytes,3,opt,name=goal,proto3" json:"goal,omitempty"`
+	UnitType      string                 `protobuf:"bytes,4,opt,name=unit_type,json=unitType,proto3" json:"unit_type,omitempty"` // hero, unit или название врага (goblin, orc, dragon)
+	Speed         float64                `protobuf:"fixed64,5,opt,name=speed,proto3" json:"speed,omitempty"`                     // Скорость в клетках в секунду, 0 - скорость типа юнита
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathRequest) Reset() {
+	*x = PathRequest{}
+	mi := &file_world_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathRequest) ProtoMessage() {}
+
+func (x *PathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
+func (*PathRequest) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PathRequest) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *PathRequest) GetStart() *Hex {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PathRequest) GetGoal() *Hex {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+func (x *PathRequest) GetUnitType() string {
+	if x != nil {
+		return x.UnitType
+	}
+	return ""
+}
+
+func (x *PathRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+// PathResponse - путь в обход объектов арены.
+type PathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          []*Hex                 `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`                               // Клетки пути без стартовой
+	Cost          float64                `protobuf:"fixed64,2,opt,name=cost,proto3" json:"cost,omitempty"`                             // Стоимость пути A*
+	TravelTime    *durationpb.Duration   `protobuf:"bytes,3,opt,name=travel_time,json=travelTime,proto3" json:"travel_time,omitempty"` // Время прохождения пути со скоростью юнита
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathResponse) Reset() {
+	*x = PathResponse{}
+	mi := &file_world_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathResponse) ProtoMessage() {}
+
+func (x *PathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_world_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathResponse.ProtoReflect.Descriptor instead.
+func (*PathResponse) Descriptor() ([]byte, []int) {
+	return file_world_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PathResponse) GetPath() []*Hex {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PathResponse) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *PathResponse) GetTravelTime() *durationpb.Duration {
+	if x != nil {
+		return x.TravelTime
+	}
+	return nil
+}
+
+var File_world_proto protoreflect.FileDescriptor
+
+var file_world_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x5d, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64,
+	0x22, 0x26, 0x0a, 0x0b, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x61, 0x72, 0x65, 0x61, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x65,
+	0x74, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x61, 0x72, 0x65, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61,
+	0x72, 0x65, 0x61, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0x7f, 0x0a, 0x04, 0x41, 0x72, 0x65, 0x61, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x65, 0x6c, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x21, 0x0a, 0x03, 0x48, 0x65, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x71, 0x12, 0x0c, 0x0a, 0x01, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x72, 0x22, 0x98, 0x01, 0x0a, 0x0a, 0x41, 0x72,
+	0x65, 0x61, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x68, 0x70, 0x12, 0x2c, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x77, 0x6f,
+	0x72, 0x6c, 0x64, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x0b, 0x41, 0x72, 0x65, 0x61, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72, 0x65,
+	0x61, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x61, 0x72, 0x65, 0x61, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+	0x2e, 0x48, 0x65, 0x78, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x04, 0x67,
+	0x6f, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x77, 0x6f, 0x72, 0x6c,
+	0x64, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x04, 0x67, 0x6f, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x75,
+	0x6e, 0x69, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x6e, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x7e,
+	0x0a, 0x0c, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f,
+	0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x32, 0xce,
+	0x02, 0x0a, 0x0c, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x35, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x19,
+	0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72,
+	0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x77, 0x6f, 0x72, 0x6c,
+	0x64, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x12, 0x2a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x41, 0x72, 0x65,
+	0x61, 0x12, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72,
+	0x65, 0x61, 0x12, 0x39, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x65, 0x61, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72,
+	0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6c,
+	0x64, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x38, 0x0a,
+	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72, 0x65, 0x61, 0x12, 0x12, 0x2e, 0x77, 0x6f,
+	0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x41, 0x72, 0x65, 0x61, 0x12, 0x17, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x52, 0x65, 0x73,
+	0x65, 0x74, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e,
+	0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x12, 0x33, 0x0a, 0x08, 0x46, 0x69,
+	0x6e, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72,
+	0x6c, 0x64, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x0e, 0x5a, 0x0c, 0x63, 0x79, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_world_proto_rawDescOnce sync.Once
+	file_world_proto_rawDescData = file_world_proto_rawDesc
+)
+
+func file_world_proto_rawDescGZIP() []byte {
+	file_world_proto_rawDescOnce.Do(func() {
+		file_world_proto_rawDescData = protoimpl.X.CompressGZIP(file_world_proto_rawDescData)
+	})
+	return file_world_proto_rawDescData
+}
+
+var file_world_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_world_proto_goTypes = []any{
+	(*CreateWorldRequest)(nil),  // 0: world.CreateWorldRequest
+	(*AreaRequest)(nil),         // 1: world.AreaRequest
+	(*ResetAreaRequest)(nil),    // 2: world.ResetAreaRequest
+	(*Area)(nil),                // 3: world.Area
+	(*Hex)(nil),                 // 4: world.Hex
+	(*AreaObject)(nil),          // 5: world.AreaObject
+	(*AreaObjects)(nil),         // 6: world.AreaObjects
+	(*PathRequest)(nil),         // 7: world.PathRequest
+	(*PathResponse)(nil),        // 8: world.PathResponse
+	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
+	(*emptypb.Empty)(nil),       // 10: google.protobuf.Empty
+}
+var file_world_proto_depIdxs = []int32{
+	4,  // 0: world.AreaObject.coordinates:type_name -> world.Hex
+	5,  // 1: world.AreaObjects.objects:type_name -> world.AreaObject
+	4,  // 2: world.PathRequest.start:type_name -> world.Hex
+	4,  // 3: world.PathRequest.goal:type_name -> world.Hex
+	4,  // 4: world.PathResponse.path:type_name -> world.Hex
+	9,  // 5: world.PathResponse.travel_time:type_name -> google.protobuf.Duration
+	0,  // 6: world.WorldService.CreateWorld:input_type -> world.CreateWorldRequest
+	1,  // 7: world.WorldService.GetArea:input_type -> world.AreaRequest
+	1,  // 8: world.WorldService.ListAreaObjects:input_type -> world.AreaRequest
+	1,  // 9: world.WorldService.DeleteArea:input_type -> world.AreaRequest
+	2,  // 10: world.WorldService.ResetArea:input_type -> world.ResetAreaRequest
+	7,  // 11: world.WorldService.FindPath:input_type -> world.PathRequest
+	3,  // 12: world.WorldService.CreateWorld:output_type -> world.Area
+	3,  // 13: world.WorldService.GetArea:output_type -> world.Area
+	6,  // 14: world.WorldService.ListAreaObjects:output_type -> world.AreaObjects
+	10, // 15: world.WorldService.DeleteArea:output_type -> google.protobuf.Empty
+	3,  // 16: world.WorldService.ResetArea:output_type -> world.Area
+	8,  // 17: world.WorldService.FindPath:output_type -> world.PathResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_world_proto_init() }
+func file_world_proto_init() {
+	if File_world_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_world_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_world_proto_goTypes,
+		DependencyIndexes: file_world_proto_depIdxs,
+		MessageInfos:      file_world_proto_msgTypes,
+	}.Build()
+	File_world_proto = out.File
+	file_world_proto_rawDesc = nil
+	file_world_proto_goTypes = nil
+	file_world_proto_depIdxs = nil
+}
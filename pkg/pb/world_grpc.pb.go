@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: world.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WorldService_CreateWorld_FullMethodName     = "/world.WorldService/CreateWorld"
+	WorldService_GetArea_FullMethodName         = "/world.WorldService/GetArea"
+	WorldService_ListAreaObjects_FullMethodName = "/world.WorldService/ListAreaObjects"
+	WorldService_DeleteArea_FullMethodName      = "/world.WorldService/DeleteArea"
+	WorldService_ResetArea_FullMethodName       = "/world.WorldService/ResetArea"
+	WorldService_FindPath_FullMethodName        = "/world.WorldService/FindPath"
+)
+
+// WorldServiceClient is the client API for WorldService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WorldService создает арены пользователей и управляет ими.
+type WorldServiceClient interface {
+	CreateWorld(ctx context.Context, in *CreateWorldRequest, opts ...grpc.CallOption) (*Area, error)
+	GetArea(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*Area, error)
+	ListAreaObjects(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*AreaObjects, error)
+	DeleteArea(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResetArea(ctx context.Context, in *ResetAreaRequest, opts ...grpc.CallOption) (*Area, error)
+	FindPath(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*PathResponse, error)
+}
+
+type worldServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorldServiceClient(cc grpc.ClientConnInterface) WorldServiceClient {
+	return &worldServiceClient{cc}
+}
+
+func (c *worldServiceClient) CreateWorld(ctx context.Context, in *CreateWorldRequest, opts ...grpc.CallOption) (*Area, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Area)
+	err := c.cc.Invoke(ctx, WorldService_CreateWorld_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worldServiceClient) GetArea(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*Area, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Area)
+	err := c.cc.Invoke(ctx, WorldService_GetArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worldServiceClient) ListAreaObjects(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*AreaObjects, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AreaObjects)
+	err := c.cc.Invoke(ctx, WorldService_ListAreaObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worldServiceClient) DeleteArea(ctx context.Context, in *AreaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WorldService_DeleteArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worldServiceClient) ResetArea(ctx context.Context, in *ResetAreaRequest, opts ...grpc.CallOption) (*Area, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Area)
+	err := c.cc.Invoke(ctx, WorldService_ResetArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worldServiceClient) FindPath(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*PathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResponse)
+	err := c.cc.Invoke(ctx, WorldService_FindPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorldServiceServer is the server API for WorldService service.
+// All implementations must embed UnimplementedWorldServiceServer
+// for forward compatibility.
+//
+// WorldService создает арены пользователей и управляет ими.
+type WorldServiceServer interface {
+	CreateWorld(context.Context, *CreateWorldRequest) (*Area, error)
+	GetArea(context.Context, *AreaRequest) (*Area, error)
+	ListAreaObjects(context.Context, *AreaRequest) (*AreaObjects, error)
+	DeleteArea(context.Context, *AreaRequest) (*emptypb.Empty, error)
+	ResetArea(context.Context, *ResetAreaRequest) (*Area, error)
+	FindPath(context.Context, *PathRequest) (*PathResponse, error)
+	mustEmbedUnimplementedWorldServiceServer()
+}
+
+// UnimplementedWorldServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWorldServiceServer struct{}
+
+func (UnimplementedWorldServiceServer) CreateWorld(context.Context, *CreateWorldRequest) (*Area, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorld not implemented")
+}
+func (UnimplementedWorldServiceServer) GetArea(context.Context, *AreaRequest) (*Area, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArea not implemented")
+}
+func (UnimplementedWorldServiceServer) ListAreaObjects(context.Context, *AreaRequest) (*AreaObjects, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAreaObjects not implemented")
+}
+func (UnimplementedWorldServiceServer) DeleteArea(context.Context, *AreaRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteArea not implemented")
+}
+func (UnimplementedWorldServiceServer) ResetArea(context.Context, *ResetAreaRequest) (*Area, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetArea not implemented")
+}
+func (UnimplementedWorldServiceServer) FindPath(context.Context, *PathRequest) (*PathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPath not implemented")
+}
+func (UnimplementedWorldServiceServer) mustEmbedUnimplementedWorldServiceServer() {}
+func (UnimplementedWorldServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeWorldServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorldServiceServer will
+// result in compilation errors.
+type UnsafeWorldServiceServer interface {
+	mustEmbedUnimplementedWorldServiceServer()
+}
+
+func RegisterWorldServiceServer(s grpc.ServiceRegistrar, srv WorldServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWorldServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WorldService_ServiceDesc, srv)
+}
+
+func _WorldService_CreateWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).CreateWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_CreateWorld_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).CreateWorld(ctx, req.(*CreateWorldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorldService_GetArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).GetArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_GetArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).GetArea(ctx, req.(*AreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorldService_ListAreaObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).ListAreaObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_ListAreaObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).ListAreaObjects(ctx, req.(*AreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorldService_DeleteArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).DeleteArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_DeleteArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).DeleteArea(ctx, req.(*AreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorldService_ResetArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).ResetArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_ResetArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).ResetArea(ctx, req.(*ResetAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorldService_FindPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorldServiceServer).FindPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorldService_FindPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorldServiceServer).FindPath(ctx, req.(*PathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorldService_ServiceDesc is the grpc.ServiceDesc for WorldService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorldService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "world.WorldService",
+	HandlerType: (*WorldServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorld",
+			Handler:    _WorldService_CreateWorld_Handler,
+		},
+		{
+			MethodName: "GetArea",
+			Handler:    _WorldService_GetArea_Handler,
+		},
+		{
+			MethodName: "ListAreaObjects",
+			Handler:    _WorldService_ListAreaObjects_Handler,
+		},
+		{
+			MethodName: "DeleteArea",
+			Handler:    _WorldService_DeleteArea_Handler,
+		},
+		{
+			MethodName: "ResetArea",
+			Handler:    _WorldService_ResetArea_Handler,
+		},
+		{
+			MethodName: "FindPath",
+			Handler:    _WorldService_FindPath_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "world.proto",
+}
@@ -0,0 +1,3527 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.3
+// source: websocket.proto
+
+package wspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope - конверт сообщений websocket.
+type Envelope struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	V         uint32                 `protobuf:"varint,1,opt,name=v,proto3" json:"v,omitempty"`                                 // Версия формата сообщений
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                            // Тип сообщения: move, harvest, ..., error
+	RequestId string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Идентификатор запроса, задается фронтом
+	Seq       int64                  `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`                             // Номер события арены, только в событиях
+	Error     *Error                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                          // Ошибка обработки запроса
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_Json
+	//	*Envelope_Action
+	//	*Envelope_Subscribe
+	//	*Envelope_SyncWorld
+	//	*Envelope_Result
+	//	*Envelope_Subscribed
+	//	*Envelope_WorldState
+	//	*Envelope_AreaData
+	//	*Envelope_UserData
+	//	*Envelope_WorldDelta
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_websocket_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetV() uint32 {
+	if x != nil {
+		return x.V
+	}
+	return 0
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *Envelope) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Envelope) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetJson() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Json); ok {
+			return x.Json
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetAction() *ActionPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Action); ok {
+			return x.Action
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSubscribe() *SubscribePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Subscribe); ok {
+			return x.Subscribe
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSyncWorld() *SyncWorldPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_SyncWorld); ok {
+			return x.SyncWorld
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetResult() *ActionResult {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSubscribed() *SubscribeResult {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Subscribed); ok {
+			return x.Subscribed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetWorldState() *WorldState {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_WorldState); ok {
+			return x.WorldState
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetAreaData() *AreaData {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_AreaData); ok {
+			return x.AreaData
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserData() *UserData {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserData); ok {
+			return x.UserData
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetWorldDelta() *WorldDelta {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_WorldDelta); ok {
+			return x.WorldDelta
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_Json struct {
+	Json []byte `protobuf:"bytes,10,opt,name=json,proto3,oneof"` // Данные в JSON: события игры и сообщения без отдельного типа
+}
+
+type Envelope_Action struct {
+	Action *ActionPayload `protobuf:"bytes,11,opt,name=action,proto3,oneof"`
+}
+
+type Envelope_Subscribe struct {
+	Subscribe *SubscribePayload `protobuf:"bytes,12,opt,name=subscribe,proto3,oneof"`
+}
+
+type Envelope_SyncWorld struct {
+	SyncWorld *SyncWorldPayload `protobuf:"bytes,13,opt,name=sync_world,json=syncWorld,proto3,oneof"`
+}
+
+type Envelope_Result struct {
+	Result *ActionResult `protobuf:"bytes,14,opt,name=result,proto3,oneof"`
+}
+
+type Envelope_Subscribed struct {
+	Subscribed *SubscribeResult `protobuf:"bytes,15,opt,name=subscribed,proto3,oneof"`
+}
+
+type Envelope_WorldState struct {
+	WorldState *WorldState `protobuf:"bytes,16,opt,name=world_state,json=worldState,proto3,oneof"`
+}
+
+type Envelope_AreaData struct {
+	AreaData *AreaData `protobuf:"bytes,17,opt,name=area_data,json=areaData,proto3,oneof"`
+}
+
+type Envelope_UserData struct {
+	UserData *UserData `protobuf:"bytes,18,opt,name=user_data,json=userData,proto3,oneof"`
+}
+
+type Envelope_WorldDelta struct {
+	WorldDelta *WorldDelta `protobuf:"bytes,19,opt,name=world_delta,json=worldDelta,proto3,oneof"`
+}
+
+func (*Envelope_Json) isEnvelope_Payload() {}
+
+func (*Envelope_Action) isEnvelope_Payload() {}
+
+func (*Envelope_Subscribe) isEnvelope_Payload() {}
+
+func (*Envelope_SyncWorld) isEnvelope_Payload() {}
+
+func (*Envelope_Result) isEnvelope_Payload() {}
+
+func (*Envelope_Subscribed) isEnvelope_Payload() {}
+
+func (*Envelope_WorldState) isEnvelope_Payload() {}
+
+func (*Envelope_AreaData) isEnvelope_Payload() {}
+
+func (*Envelope_UserData) isEnvelope_Payload() {}
+
+func (*Envelope_WorldDelta) isEnvelope_Payload() {}
+
+// Error - ошибка из контракта ошибок. Коды совпадают с кодами HTTP.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fields        []*FieldError          `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_websocket_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetFields() []*FieldError {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// FieldError - ошибка значения одного поля запроса.
+type FieldError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldError) Reset() {
+	*x = FieldError{}
+	mi := &file_websocket_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldError) ProtoMessage() {}
+
+func (x *FieldError) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldError.ProtoReflect.Descriptor instead.
+func (*FieldError) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Hex - координаты клетки арены.
+type Hex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             float64                `protobuf:"fixed64,1,opt,name=q,proto3" json:"q,omitempty"`
+	R             float64                `protobuf:"fixed64,2,opt,name=r,proto3" json:"r,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hex) Reset() {
+	*x = Hex{}
+	mi := &file_websocket_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hex) ProtoMessage() {}
+
+func (x *Hex) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hex.ProtoReflect.Descriptor instead.
+func (*Hex) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Hex) GetQ() float64 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *Hex) GetR() float64 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+// ActionPayload - действие пользователя. Характеристики зависят от типа действия.
+type ActionPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Не используется: пользователь определяется по сессии соединения
+	AreaId         int64                  `protobuf:"varint,2,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	ObjectSourceId int64                  `protobuf:"varint,3,opt,name=object_source_id,json=objectSourceId,proto3" json:"object_source_id,omitempty"`
+	ObjectDestId   int64                  `protobuf:"varint,4,opt,name=object_dest_id,json=objectDestId,proto3" json:"object_dest_id,omitempty"`
+	// Types that are valid to be assigned to Characteristics:
+	//
+	//	*ActionPayload_Move
+	//	*ActionPayload_Harvest
+	//	*ActionPayload_Build
+	//	*ActionPayload_Attack
+	//	*ActionPayload_Upgrade
+	//	*ActionPayload_GroupMove
+	Characteristics isActionPayload_Characteristics `protobuf_oneof:"characteristics"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ActionPayload) Reset() {
+	*x = ActionPayload{}
+	mi := &file_websocket_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionPayload) ProtoMessage() {}
+
+func (x *ActionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionPayload.ProtoReflect.Descriptor instead.
+func (*ActionPayload) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActionPayload) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ActionPayload) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *ActionPayload) GetObjectSourceId() int64 {
+	if x != nil {
+		return x.ObjectSourceId
+	}
+	return 0
+}
+
+func (x *ActionPayload) GetObjectDestId() int64 {
+	if x != nil {
+		return x.ObjectDestId
+	}
+	return 0
+}
+
+func (x *ActionPayload) GetCharacteristics() isActionPayload_Characteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetMove() *MoveCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_Move); ok {
+			return x.Move
+		}
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetHarvest() *HarvestCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_Harvest); ok {
+			return x.Harvest
+		}
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetBuild() *BuildCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_Build); ok {
+			return x.Build
+		}
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetAttack() *AttackCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_Attack); ok {
+			return x.Attack
+		}
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetUpgrade() *UpgradeCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_Upgrade); ok {
+			return x.Upgrade
+		}
+	}
+	return nil
+}
+
+func (x *ActionPayload) GetGroupMove() *GroupMoveCharacteristics {
+	if x != nil {
+		if x, ok := x.Characteristics.(*ActionPayload_GroupMove); ok {
+			return x.GroupMove
+		}
+	}
+	return nil
+}
+
+type isActionPayload_Characteristics interface {
+	isActionPayload_Characteristics()
+}
+
+type ActionPayload_Move struct {
+	Move *MoveCharacteristics `protobuf:"bytes,10,opt,name=move,proto3,oneof"`
+}
+
+type ActionPayload_Harvest struct {
+	Harvest *HarvestCharacteristics `protobuf:"bytes,11,opt,name=harvest,proto3,oneof"`
+}
+
+type ActionPayload_Build struct {
+	Build *BuildCharacteristics `protobuf:"bytes,12,opt,name=build,proto3,oneof"`
+}
+
+type ActionPayload_Attack struct {
+	Attack *AttackCharacteristics `protobuf:"bytes,13,opt,name=attack,proto3,oneof"`
+}
+
+type ActionPayload_Upgrade struct {
+	Upgrade *UpgradeCharacteristics `protobuf:"bytes,14,opt,name=upgrade,proto3,oneof"`
+}
+
+type ActionPayload_GroupMove struct {
+	GroupMove *GroupMoveCharacteristics `protobuf:"bytes,15,opt,name=group_move,json=groupMove,proto3,oneof"`
+}
+
+func (*ActionPayload_Move) isActionPayload_Characteristics() {}
+
+func (*ActionPayload_Harvest) isActionPayload_Characteristics() {}
+
+func (*ActionPayload_Build) isActionPayload_Characteristics() {}
+
+func (*ActionPayload_Attack) isActionPayload_Characteristics() {}
+
+func (*ActionPayload_Upgrade) isActionPayload_Characteristics() {}
+
+func (*ActionPayload_GroupMove) isActionPayload_Characteristics() {}
+
+// Дробные значения (скорость, урон, ресурсы, опыт) передаются строками, как в JSON.
+type MoveCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Hex                   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Hex                   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Speed         string                 `protobuf:"bytes,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCharacteristics) Reset() {
+	*x = MoveCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCharacteristics) ProtoMessage() {}
+
+func (x *MoveCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCharacteristics.ProtoReflect.Descriptor instead.
+func (*MoveCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MoveCharacteristics) GetFrom() *Hex {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *MoveCharacteristics) GetTo() *Hex {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *MoveCharacteristics) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+type HarvestCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Harvester     int64                  `protobuf:"varint,1,opt,name=harvester,proto3" json:"harvester,omitempty"`
+	Resource      string                 `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	NeutralId     int64                  `protobuf:"varint,3,opt,name=neutral_id,json=neutralId,proto3" json:"neutral_id,omitempty"`
+	Speed         string                 `protobuf:"bytes,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HarvestCharacteristics) Reset() {
+	*x = HarvestCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HarvestCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HarvestCharacteristics) ProtoMessage() {}
+
+func (x *HarvestCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HarvestCharacteristics.ProtoReflect.Descriptor instead.
+func (*HarvestCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HarvestCharacteristics) GetHarvester() int64 {
+	if x != nil {
+		return x.Harvester
+	}
+	return 0
+}
+
+func (x *HarvestCharacteristics) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *HarvestCharacteristics) GetNeutralId() int64 {
+	if x != nil {
+		return x.NeutralId
+	}
+	return 0
+}
+
+func (x *HarvestCharacteristics) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+type BuildCharacteristics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Builder          int64                  `protobuf:"varint,1,opt,name=builder,proto3" json:"builder,omitempty"`
+	Object           string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	ConstructionTime int32                  `protobuf:"varint,3,opt,name=construction_time,json=constructionTime,proto3" json:"construction_time,omitempty"`
+	Place            *Hex                   `protobuf:"bytes,4,opt,name=place,proto3" json:"place,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BuildCharacteristics) Reset() {
+	*x = BuildCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildCharacteristics) ProtoMessage() {}
+
+func (x *BuildCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildCharacteristics.ProtoReflect.Descriptor instead.
+func (*BuildCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BuildCharacteristics) GetBuilder() int64 {
+	if x != nil {
+		return x.Builder
+	}
+	return 0
+}
+
+func (x *BuildCharacteristics) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *BuildCharacteristics) GetConstructionTime() int32 {
+	if x != nil {
+		return x.ConstructionTime
+	}
+	return 0
+}
+
+func (x *BuildCharacteristics) GetPlace() *Hex {
+	if x != nil {
+		return x.Place
+	}
+	return nil
+}
+
+type AttackCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Atacker       int64                  `protobuf:"varint,1,opt,name=atacker,proto3" json:"atacker,omitempty"`
+	Defenser      int64                  `protobuf:"varint,2,opt,name=defenser,proto3" json:"defenser,omitempty"`
+	Damage        string                 `protobuf:"bytes,3,opt,name=damage,proto3" json:"damage,omitempty"`
+	AtackerKind   string                 `protobuf:"bytes,4,opt,name=atacker_kind,json=atackerKind,proto3" json:"atacker_kind,omitempty"` // Тип атакующего объекта - hero или unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttackCharacteristics) Reset() {
+	*x = AttackCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackCharacteristics) ProtoMessage() {}
+
+func (x *AttackCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttackCharacteristics.ProtoReflect.Descriptor instead.
+func (*AttackCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AttackCharacteristics) GetAtacker() int64 {
+	if x != nil {
+		return x.Atacker
+	}
+	return 0
+}
+
+func (x *AttackCharacteristics) GetDefenser() int64 {
+	if x != nil {
+		return x.Defenser
+	}
+	return 0
+}
+
+func (x *AttackCharacteristics) GetDamage() string {
+	if x != nil {
+		return x.Damage
+	}
+	return ""
+}
+
+func (x *AttackCharacteristics) GetAtackerKind() string {
+	if x != nil {
+		return x.AtackerKind
+	}
+	return ""
+}
+
+type UpgradeCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildingId    int64                  `protobuf:"varint,1,opt,name=building_id,json=buildingId,proto3" json:"building_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeCharacteristics) Reset() {
+	*x = UpgradeCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeCharacteristics) ProtoMessage() {}
+
+func (x *UpgradeCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeCharacteristics.ProtoReflect.Descriptor instead.
+func (*UpgradeCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpgradeCharacteristics) GetBuildingId() int64 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+type GroupMoveCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Units         []int64                `protobuf:"varint,1,rep,packed,name=units,proto3" json:"units,omitempty"`
+	To            *Hex                   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMoveCharacteristics) Reset() {
+	*x = GroupMoveCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMoveCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMoveCharacteristics) ProtoMessage() {}
+
+func (x *GroupMoveCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMoveCharacteristics.ProtoReflect.Descriptor instead.
+func (*GroupMoveCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GroupMoveCharacteristics) GetUnits() []int64 {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *GroupMoveCharacteristics) GetTo() *Hex {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// SubscribePayload - подписка на события арены.
+type SubscribePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int64                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	LastSeq       *int64                 `protobuf:"varint,2,opt,name=last_seq,json=lastSeq,proto3,oneof" json:"last_seq,omitempty"` // Номер последнего полученного события при переподключении
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribePayload) Reset() {
+	*x = SubscribePayload{}
+	mi := &file_websocket_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribePayload) ProtoMessage() {}
+
+func (x *SubscribePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribePayload.ProtoReflect.Descriptor instead.
+func (*SubscribePayload) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscribePayload) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *SubscribePayload) GetLastSeq() int64 {
+	if x != nil && x.LastSeq != nil {
+		return *x.LastSeq
+	}
+	return 0
+}
+
+// SyncWorldPayload - запрос изменений состояния мира.
+type SyncWorldPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ack           *int64                 `protobuf:"varint,1,opt,name=ack,proto3,oneof" json:"ack,omitempty"` // Последняя примененная версия, без ack - полный снимок
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncWorldPayload) Reset() {
+	*x = SyncWorldPayload{}
+	mi := &file_websocket_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncWorldPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncWorldPayload) ProtoMessage() {}
+
+func (x *SyncWorldPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncWorldPayload.ProtoReflect.Descriptor instead.
+func (*SyncWorldPayload) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SyncWorldPayload) GetAck() int64 {
+	if x != nil && x.Ack != nil {
+		return *x.Ack
+	}
+	return 0
+}
+
+// ActionResult - ответ на действие пользователя.
+type ActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Paths         []*UnitPath            `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`                        // Пути юнитов группового перемещения
+	ActionId      int64                  `protobuf:"varint,4,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"` // Действие, запущенное добычей или строительством
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	mi := &file_websocket_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResult) ProtoMessage() {}
+
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ActionResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ActionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ActionResult) GetPaths() []*UnitPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *ActionResult) GetActionId() int64 {
+	if x != nil {
+		return x.ActionId
+	}
+	return 0
+}
+
+// UnitPath - путь юнита без стартовой клетки.
+type UnitPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UnitId        int64                  `protobuf:"varint,1,opt,name=unit_id,json=unitId,proto3" json:"unit_id,omitempty"`
+	Path          []*Hex                 `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitPath) Reset() {
+	*x = UnitPath{}
+	mi := &file_websocket_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitPath) ProtoMessage() {}
+
+func (x *UnitPath) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitPath.ProtoReflect.Descriptor instead.
+func (*UnitPath) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnitPath) GetUnitId() int64 {
+	if x != nil {
+		return x.UnitId
+	}
+	return 0
+}
+
+func (x *UnitPath) GetPath() []*Hex {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+// SubscribeResult - ответ на подписку.
+type SubscribeResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Seq           int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Snapshot      *AreaData              `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Снимок арены, если пропущенные события недоступны
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeResult) Reset() {
+	*x = SubscribeResult{}
+	mi := &file_websocket_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResult) ProtoMessage() {}
+
+func (x *SubscribeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResult.ProtoReflect.Descriptor instead.
+func (*SubscribeResult) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubscribeResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubscribeResult) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SubscribeResult) GetSnapshot() *AreaData {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type Resource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	mi := &file_websocket_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Resource) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Resource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Resource) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type League struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Authority     int32                  `protobuf:"varint,3,opt,name=authority,proto3" json:"authority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *League) Reset() {
+	*x = League{}
+	mi := &file_websocket_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *League) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*League) ProtoMessage() {}
+
+func (x *League) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use League.ProtoReflect.Descriptor instead.
+func (*League) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *League) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *League) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *League) GetAuthority() int32 {
+	if x != nil {
+		return x.Authority
+	}
+	return 0
+}
+
+// UserData - ответ на get_user_data.
+type UserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Resources     []*Resource            `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
+	Subscription  bool                   `protobuf:"varint,4,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	LeagueId      int64                  `protobuf:"varint,5,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	League        *League                `protobuf:"bytes,6,opt,name=league,proto3" json:"league,omitempty"`
+	Balance       string                 `protobuf:"bytes,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	Level         int32                  `protobuf:"varint,8,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserData) Reset() {
+	*x = UserData{}
+	mi := &file_websocket_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserData) ProtoMessage() {}
+
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UserData) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserData) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *UserData) GetResources() []*Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *UserData) GetSubscription() bool {
+	if x != nil {
+		return x.Subscription
+	}
+	return false
+}
+
+func (x *UserData) GetLeagueId() int64 {
+	if x != nil {
+		return x.LeagueId
+	}
+	return 0
+}
+
+func (x *UserData) GetLeague() *League {
+	if x != nil {
+		return x.League
+	}
+	return nil
+}
+
+func (x *UserData) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *UserData) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type Neutral struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Product                 string                 `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	ProductivityCoefficient int32                  `protobuf:"varint,4,opt,name=productivity_coefficient,json=productivityCoefficient,proto3" json:"productivity_coefficient,omitempty"`
+	Capacity                string                 `protobuf:"bytes,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	ThresholdLevel1         string                 `protobuf:"bytes,6,opt,name=threshold_level1,json=thresholdLevel1,proto3" json:"threshold_level1,omitempty"`
+	ThresholdLevel2         string                 `protobuf:"bytes,7,opt,name=threshold_level2,json=thresholdLevel2,proto3" json:"threshold_level2,omitempty"`
+	Size                    int32                  `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	Coordinates             []*Hex                 `protobuf:"bytes,9,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Neutral) Reset() {
+	*x = Neutral{}
+	mi := &file_websocket_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Neutral) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Neutral) ProtoMessage() {}
+
+func (x *Neutral) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Neutral.ProtoReflect.Descriptor instead.
+func (*Neutral) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Neutral) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Neutral) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Neutral) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *Neutral) GetProductivityCoefficient() int32 {
+	if x != nil {
+		return x.ProductivityCoefficient
+	}
+	return 0
+}
+
+func (x *Neutral) GetCapacity() string {
+	if x != nil {
+		return x.Capacity
+	}
+	return ""
+}
+
+func (x *Neutral) GetThresholdLevel1() string {
+	if x != nil {
+		return x.ThresholdLevel1
+	}
+	return ""
+}
+
+func (x *Neutral) GetThresholdLevel2() string {
+	if x != nil {
+		return x.ThresholdLevel2
+	}
+	return ""
+}
+
+func (x *Neutral) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Neutral) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type BuildingCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Armor         int32                  `protobuf:"varint,2,opt,name=armor,proto3" json:"armor,omitempty"`
+	ProdCof       int32                  `protobuf:"varint,3,opt,name=prod_cof,json=prodCof,proto3" json:"prod_cof,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"` // В JSON поле называется Size
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildingCharacteristics) Reset() {
+	*x = BuildingCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildingCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildingCharacteristics) ProtoMessage() {}
+
+func (x *BuildingCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildingCharacteristics.ProtoReflect.Descriptor instead.
+func (*BuildingCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BuildingCharacteristics) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *BuildingCharacteristics) GetArmor() int32 {
+	if x != nil {
+		return x.Armor
+	}
+	return 0
+}
+
+func (x *BuildingCharacteristics) GetProdCof() int32 {
+	if x != nil {
+		return x.ProdCof
+	}
+	return 0
+}
+
+func (x *BuildingCharacteristics) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type Building struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	Id              int64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Product         string                   `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	Characteristics *BuildingCharacteristics `protobuf:"bytes,4,opt,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Level           int32                    `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+	UpgradePrice    []*Resource              `protobuf:"bytes,6,rep,name=upgrade_price,json=upgradePrice,proto3" json:"upgrade_price,omitempty"`
+	Coordinates     []*Hex                   `protobuf:"bytes,7,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Building) Reset() {
+	*x = Building{}
+	mi := &file_websocket_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Building) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Building) ProtoMessage() {}
+
+func (x *Building) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Building.ProtoReflect.Descriptor instead.
+func (*Building) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Building) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Building) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Building) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *Building) GetCharacteristics() *BuildingCharacteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *Building) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Building) GetUpgradePrice() []*Resource {
+	if x != nil {
+		return x.UpgradePrice
+	}
+	return nil
+}
+
+func (x *Building) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type HeroCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	HpNow         int32                  `protobuf:"varint,2,opt,name=hp_now,json=hpNow,proto3" json:"hp_now,omitempty"`
+	Armor         int32                  `protobuf:"varint,3,opt,name=armor,proto3" json:"armor,omitempty"`
+	Speed         string                 `protobuf:"bytes,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	Vision        int32                  `protobuf:"varint,5,opt,name=vision,proto3" json:"vision,omitempty"`
+	Range         bool                   `protobuf:"varint,6,opt,name=range,proto3" json:"range,omitempty"`
+	AtackRange    string                 `protobuf:"bytes,7,opt,name=atack_range,json=atackRange,proto3" json:"atack_range,omitempty"`
+	Damage        string                 `protobuf:"bytes,9,opt,name=damage,proto3" json:"damage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeroCharacteristics) Reset() {
+	*x = HeroCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeroCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeroCharacteristics) ProtoMessage() {}
+
+func (x *HeroCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeroCharacteristics.ProtoReflect.Descriptor instead.
+func (*HeroCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HeroCharacteristics) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *HeroCharacteristics) GetHpNow() int32 {
+	if x != nil {
+		return x.HpNow
+	}
+	return 0
+}
+
+func (x *HeroCharacteristics) GetArmor() int32 {
+	if x != nil {
+		return x.Armor
+	}
+	return 0
+}
+
+func (x *HeroCharacteristics) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+func (x *HeroCharacteristics) GetVision() int32 {
+	if x != nil {
+		return x.Vision
+	}
+	return 0
+}
+
+func (x *HeroCharacteristics) GetRange() bool {
+	if x != nil {
+		return x.Range
+	}
+	return false
+}
+
+func (x *HeroCharacteristics) GetAtackRange() string {
+	if x != nil {
+		return x.AtackRange
+	}
+	return ""
+}
+
+func (x *HeroCharacteristics) GetDamage() string {
+	if x != nil {
+		return x.Damage
+	}
+	return ""
+}
+
+type AbilityCharacteristics struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IsPassive      bool                   `protobuf:"varint,1,opt,name=is_passive,json=isPassive,proto3" json:"is_passive,omitempty"`
+	Radius         string                 `protobuf:"bytes,2,opt,name=radius,proto3" json:"radius,omitempty"`
+	Cooldown       int64                  `protobuf:"varint,3,opt,name=cooldown,proto3" json:"cooldown,omitempty"` // Время перезарядки в наносекундах
+	Damage         string                 `protobuf:"bytes,4,opt,name=damage,proto3" json:"damage,omitempty"`
+	ProjectilSpeed string                 `protobuf:"bytes,5,opt,name=projectil_speed,json=projectilSpeed,proto3" json:"projectil_speed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AbilityCharacteristics) Reset() {
+	*x = AbilityCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbilityCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbilityCharacteristics) ProtoMessage() {}
+
+func (x *AbilityCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbilityCharacteristics.ProtoReflect.Descriptor instead.
+func (*AbilityCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AbilityCharacteristics) GetIsPassive() bool {
+	if x != nil {
+		return x.IsPassive
+	}
+	return false
+}
+
+func (x *AbilityCharacteristics) GetRadius() string {
+	if x != nil {
+		return x.Radius
+	}
+	return ""
+}
+
+func (x *AbilityCharacteristics) GetCooldown() int64 {
+	if x != nil {
+		return x.Cooldown
+	}
+	return 0
+}
+
+func (x *AbilityCharacteristics) GetDamage() string {
+	if x != nil {
+		return x.Damage
+	}
+	return ""
+}
+
+func (x *AbilityCharacteristics) GetProjectilSpeed() string {
+	if x != nil {
+		return x.ProjectilSpeed
+	}
+	return ""
+}
+
+type Ability struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Id              int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Characteristics *AbilityCharacteristics `protobuf:"bytes,3,opt,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Level           int32                   `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	ImageId         int64                   `protobuf:"varint,5,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Ability) Reset() {
+	*x = Ability{}
+	mi := &file_websocket_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ability) ProtoMessage() {}
+
+func (x *Ability) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ability.ProtoReflect.Descriptor instead.
+func (*Ability) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Ability) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ability) GetCharacteristics() *AbilityCharacteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *Ability) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Ability) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+type Hero struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Characteristics *HeroCharacteristics   `protobuf:"bytes,3,opt,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Experience      string                 `protobuf:"bytes,4,opt,name=experience,proto3" json:"experience,omitempty"`
+	ExperienceToUp  string                 `protobuf:"bytes,5,opt,name=experience_to_up,json=experienceToUp,proto3" json:"experience_to_up,omitempty"`
+	Level           int32                  `protobuf:"varint,6,opt,name=level,proto3" json:"level,omitempty"`
+	Abilities       []*Ability             `protobuf:"bytes,7,rep,name=abilities,proto3" json:"abilities,omitempty"`
+	Coordinates     []*Hex                 `protobuf:"bytes,8,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Hero) Reset() {
+	*x = Hero{}
+	mi := &file_websocket_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hero) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hero) ProtoMessage() {}
+
+func (x *Hero) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hero.ProtoReflect.Descriptor instead.
+func (*Hero) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Hero) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Hero) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Hero) GetCharacteristics() *HeroCharacteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *Hero) GetExperience() string {
+	if x != nil {
+		return x.Experience
+	}
+	return ""
+}
+
+func (x *Hero) GetExperienceToUp() string {
+	if x != nil {
+		return x.ExperienceToUp
+	}
+	return ""
+}
+
+func (x *Hero) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Hero) GetAbilities() []*Ability {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *Hero) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type UnitCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	HpNow         int32                  `protobuf:"varint,2,opt,name=hp_now,json=hpNow,proto3" json:"hp_now,omitempty"`
+	Armor         int32                  `protobuf:"varint,3,opt,name=armor,proto3" json:"armor,omitempty"`
+	Speed         string                 `protobuf:"bytes,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	Vision        int32                  `protobuf:"varint,5,opt,name=vision,proto3" json:"vision,omitempty"`
+	Range         bool                   `protobuf:"varint,6,opt,name=range,proto3" json:"range,omitempty"`
+	AtackRange    string                 `protobuf:"bytes,7,opt,name=atack_range,json=atackRange,proto3" json:"atack_range,omitempty"`
+	Damage        string                 `protobuf:"bytes,8,opt,name=damage,proto3" json:"damage,omitempty"`
+	ProdCof       int32                  `protobuf:"varint,9,opt,name=prod_cof,json=prodCof,proto3" json:"prod_cof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitCharacteristics) Reset() {
+	*x = UnitCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitCharacteristics) ProtoMessage() {}
+
+func (x *UnitCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitCharacteristics.ProtoReflect.Descriptor instead.
+func (*UnitCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnitCharacteristics) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *UnitCharacteristics) GetHpNow() int32 {
+	if x != nil {
+		return x.HpNow
+	}
+	return 0
+}
+
+func (x *UnitCharacteristics) GetArmor() int32 {
+	if x != nil {
+		return x.Armor
+	}
+	return 0
+}
+
+func (x *UnitCharacteristics) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+func (x *UnitCharacteristics) GetVision() int32 {
+	if x != nil {
+		return x.Vision
+	}
+	return 0
+}
+
+func (x *UnitCharacteristics) GetRange() bool {
+	if x != nil {
+		return x.Range
+	}
+	return false
+}
+
+func (x *UnitCharacteristics) GetAtackRange() string {
+	if x != nil {
+		return x.AtackRange
+	}
+	return ""
+}
+
+func (x *UnitCharacteristics) GetDamage() string {
+	if x != nil {
+		return x.Damage
+	}
+	return ""
+}
+
+func (x *UnitCharacteristics) GetProdCof() int32 {
+	if x != nil {
+		return x.ProdCof
+	}
+	return 0
+}
+
+type Unit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Characteristics *UnitCharacteristics   `protobuf:"bytes,3,opt,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Experience      string                 `protobuf:"bytes,4,opt,name=experience,proto3" json:"experience,omitempty"`
+	ExperienceToUp  string                 `protobuf:"bytes,5,opt,name=experience_to_up,json=experienceToUp,proto3" json:"experience_to_up,omitempty"`
+	Level           int32                  `protobuf:"varint,6,opt,name=level,proto3" json:"level,omitempty"`
+	ImageId         int64                  `protobuf:"varint,7,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Coordinates     []*Hex                 `protobuf:"bytes,8,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Unit) Reset() {
+	*x = Unit{}
+	mi := &file_websocket_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Unit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Unit) ProtoMessage() {}
+
+func (x *Unit) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Unit.ProtoReflect.Descriptor instead.
+func (*Unit) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Unit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Unit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Unit) GetCharacteristics() *UnitCharacteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *Unit) GetExperience() string {
+	if x != nil {
+		return x.Experience
+	}
+	return ""
+}
+
+func (x *Unit) GetExperienceToUp() string {
+	if x != nil {
+		return x.ExperienceToUp
+	}
+	return ""
+}
+
+func (x *Unit) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Unit) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+func (x *Unit) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type EnemyCharacteristics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Armor         int32                  `protobuf:"varint,2,opt,name=armor,proto3" json:"armor,omitempty"`
+	Speed         string                 `protobuf:"bytes,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	Vision        int32                  `protobuf:"varint,4,opt,name=vision,proto3" json:"vision,omitempty"`
+	Range         bool                   `protobuf:"varint,5,opt,name=range,proto3" json:"range,omitempty"`
+	AtackRange    string                 `protobuf:"bytes,6,opt,name=atack_range,json=atackRange,proto3" json:"atack_range,omitempty"`
+	Damage        string                 `protobuf:"bytes,7,opt,name=damage,proto3" json:"damage,omitempty"`
+	Experience    string                 `protobuf:"bytes,8,opt,name=experience,proto3" json:"experience,omitempty"`
+	Level         int32                  `protobuf:"varint,9,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnemyCharacteristics) Reset() {
+	*x = EnemyCharacteristics{}
+	mi := &file_websocket_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnemyCharacteristics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnemyCharacteristics) ProtoMessage() {}
+
+func (x *EnemyCharacteristics) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnemyCharacteristics.ProtoReflect.Descriptor instead.
+func (*EnemyCharacteristics) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EnemyCharacteristics) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *EnemyCharacteristics) GetArmor() int32 {
+	if x != nil {
+		return x.Armor
+	}
+	return 0
+}
+
+func (x *EnemyCharacteristics) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+func (x *EnemyCharacteristics) GetVision() int32 {
+	if x != nil {
+		return x.Vision
+	}
+	return 0
+}
+
+func (x *EnemyCharacteristics) GetRange() bool {
+	if x != nil {
+		return x.Range
+	}
+	return false
+}
+
+func (x *EnemyCharacteristics) GetAtackRange() string {
+	if x != nil {
+		return x.AtackRange
+	}
+	return ""
+}
+
+func (x *EnemyCharacteristics) GetDamage() string {
+	if x != nil {
+		return x.Damage
+	}
+	return ""
+}
+
+func (x *EnemyCharacteristics) GetExperience() string {
+	if x != nil {
+		return x.Experience
+	}
+	return ""
+}
+
+func (x *EnemyCharacteristics) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type Enemy struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Characteristics *EnemyCharacteristics  `protobuf:"bytes,3,opt,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Level           int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Coordinates     []*Hex                 `protobuf:"bytes,5,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Enemy) Reset() {
+	*x = Enemy{}
+	mi := &file_websocket_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Enemy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enemy) ProtoMessage() {}
+
+func (x *Enemy) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enemy.ProtoReflect.Descriptor instead.
+func (*Enemy) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Enemy) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Enemy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Enemy) GetCharacteristics() *EnemyCharacteristics {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *Enemy) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Enemy) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// AreaData - ответ на get_area_data.
+type AreaData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	CellTypeId    int32                  `protobuf:"varint,5,opt,name=cell_type_id,json=cellTypeId,proto3" json:"cell_type_id,omitempty"`
+	Neutrals      []*Neutral             `protobuf:"bytes,6,rep,name=neutrals,proto3" json:"neutrals,omitempty"`
+	Buildings     []*Building            `protobuf:"bytes,7,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	Heroes        []*Hero                `protobuf:"bytes,8,rep,name=heroes,proto3" json:"heroes,omitempty"`
+	Units         []*Unit                `protobuf:"bytes,9,rep,name=units,proto3" json:"units,omitempty"`
+	Enemies       []*Enemy               `protobuf:"bytes,10,rep,name=enemies,proto3" json:"enemies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreaData) Reset() {
+	*x = AreaData{}
+	mi := &file_websocket_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreaData) ProtoMessage() {}
+
+func (x *AreaData) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaData.ProtoReflect.Descriptor instead.
+func (*AreaData) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AreaData) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AreaData) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AreaData) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *AreaData) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AreaData) GetCellTypeId() int32 {
+	if x != nil {
+		return x.CellTypeId
+	}
+	return 0
+}
+
+func (x *AreaData) GetNeutrals() []*Neutral {
+	if x != nil {
+		return x.Neutrals
+	}
+	return nil
+}
+
+func (x *AreaData) GetBuildings() []*Building {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *AreaData) GetHeroes() []*Hero {
+	if x != nil {
+		return x.Heroes
+	}
+	return nil
+}
+
+func (x *AreaData) GetUnits() []*Unit {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *AreaData) GetEnemies() []*Enemy {
+	if x != nil {
+		return x.Enemies
+	}
+	return nil
+}
+
+// WorldState - ответ на get_world_state.
+type WorldState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AreaId        int64                  `protobuf:"varint,2,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Neutrals      []*Neutral             `protobuf:"bytes,4,rep,name=neutrals,proto3" json:"neutrals,omitempty"`
+	Buildings     []*Building            `protobuf:"bytes,5,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	Heroes        []*Hero                `protobuf:"bytes,6,rep,name=heroes,proto3" json:"heroes,omitempty"`
+	Units         []*Unit                `protobuf:"bytes,7,rep,name=units,proto3" json:"units,omitempty"`
+	Enemies       []*Enemy               `protobuf:"bytes,8,rep,name=enemies,proto3" json:"enemies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorldState) Reset() {
+	*x = WorldState{}
+	mi := &file_websocket_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorldState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldState) ProtoMessage() {}
+
+func (x *WorldState) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldState.ProtoReflect.Descriptor instead.
+func (*WorldState) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WorldState) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WorldState) GetAreaId() int64 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *WorldState) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WorldState) GetNeutrals() []*Neutral {
+	if x != nil {
+		return x.Neutrals
+	}
+	return nil
+}
+
+func (x *WorldState) GetBuildings() []*Building {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *WorldState) GetHeroes() []*Hero {
+	if x != nil {
+		return x.Heroes
+	}
+	return nil
+}
+
+func (x *WorldState) GetUnits() []*Unit {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *WorldState) GetEnemies() []*Enemy {
+	if x != nil {
+		return x.Enemies
+	}
+	return nil
+}
+
+type ObjectRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRef) Reset() {
+	*x = ObjectRef{}
+	mi := &file_websocket_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRef) ProtoMessage() {}
+
+func (x *ObjectRef) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRef.ProtoReflect.Descriptor instead.
+func (*ObjectRef) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ObjectRef) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ObjectRef) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ObjectDelta - изменения одного объекта арены.
+type ObjectDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Coordinates   []*Hex                 `protobuf:"bytes,3,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Hp            *int32                 `protobuf:"varint,4,opt,name=hp,proto3,oneof" json:"hp,omitempty"`
+	Level         *int32                 `protobuf:"varint,5,opt,name=level,proto3,oneof" json:"level,omitempty"`
+	Object        *structpb.Struct       `protobuf:"bytes,6,opt,name=object,proto3" json:"object,omitempty"` // Новый или изменившийся объект целиком
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectDelta) Reset() {
+	*x = ObjectDelta{}
+	mi := &file_websocket_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectDelta) ProtoMessage() {}
+
+func (x *ObjectDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectDelta.ProtoReflect.Descriptor instead.
+func (*ObjectDelta) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ObjectDelta) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ObjectDelta) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ObjectDelta) GetCoordinates() []*Hex {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *ObjectDelta) GetHp() int32 {
+	if x != nil && x.Hp != nil {
+		return *x.Hp
+	}
+	return 0
+}
+
+func (x *ObjectDelta) GetLevel() int32 {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return 0
+}
+
+func (x *ObjectDelta) GetObject() *structpb.Struct {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+// WorldDelta - ответ на sync_world_state.
+type WorldDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Base          int64                  `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Changed       []*ObjectDelta         `protobuf:"bytes,4,rep,name=changed,proto3" json:"changed,omitempty"`
+	Removed       []*ObjectRef           `protobuf:"bytes,5,rep,name=removed,proto3" json:"removed,omitempty"`
+	Snapshot      *WorldState            `protobuf:"bytes,6,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorldDelta) Reset() {
+	*x = WorldDelta{}
+	mi := &file_websocket_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorldDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldDelta) ProtoMessage() {}
+
+func (x *WorldDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_websocket_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldDelta.ProtoReflect.Descriptor instead.
+func (*WorldDelta) Descriptor() ([]byte, []int) {
+	return file_websocket_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WorldDelta) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *WorldDelta) GetBase() int64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *WorldDelta) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WorldDelta) GetChanged() []*ObjectDelta {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+func (x *WorldDelta) GetRemoved() []*ObjectRef {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *WorldDelta) GetSnapshot() *WorldState {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+var File_websocket_proto protoreflect.FileDescriptor
+
+var file_websocket_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8e, 0x05, 0x0a, 0x08, 0x45, 0x6e,
+	0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x01, 0x76, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x77, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
+	0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x77, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x48, 0x00, 0x52, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x2f,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x3a, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0b, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x41, 0x72, 0x65, 0x61, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x08, 0x61, 0x72, 0x65,
+	0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x77, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x36, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x44, 0x65, 0x6c, 0x74,
+	0x61, 0x48, 0x00, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x42,
+	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x62, 0x0a, 0x05, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x2b, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3c,
+	0x0a, 0x0a, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x21, 0x0a, 0x03,
+	0x48, 0x65, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x71, 0x12, 0x0c, 0x0a, 0x01, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x72, 0x22,
+	0x87, 0x04, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x72,
+	0x65, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x72, 0x65,
+	0x61, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a,
+	0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x4d, 0x6f, 0x76, 0x65,
+	0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48,
+	0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x61, 0x72, 0x76, 0x65,
+	0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x77, 0x73, 0x2e, 0x48, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68, 0x61, 0x72,
+	0x76, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x48, 0x00, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x38, 0x0a, 0x06, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48, 0x00, 0x52, 0x06, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65,
+	0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48, 0x00, 0x52, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6d, 0x6f, 0x76, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48, 0x00, 0x52, 0x09, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x4d, 0x6f, 0x76, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x6b, 0x0a, 0x13, 0x4d, 0x6f, 0x76,
+	0x65, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x12, 0x20, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x1c, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x02, 0x74, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x87, 0x01, 0x0a, 0x16, 0x48, 0x61, 0x72, 0x76, 0x65,
+	0x73, 0x74, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6e,
+	0x65, 0x75, 0x74, 0x72, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x22, 0x99, 0x01, 0x0a, 0x14, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x63,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77,
+	0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x05, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x22, 0x88, 0x01, 0x0a,
+	0x15, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x61,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x5f,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x22, 0x39, 0x0a, 0x16, 0x55, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67,
+	0x49, 0x64, 0x22, 0x4e, 0x0a, 0x18, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x6f, 0x76, 0x65, 0x43,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x02,
+	0x74, 0x6f, 0x22, 0x58, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x72, 0x65, 0x61, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x71, 0x88, 0x01, 0x01, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x22, 0x31, 0x0a, 0x10,
+	0x53, 0x79, 0x6e, 0x63, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x15, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52,
+	0x03, 0x61, 0x63, 0x6b, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x61, 0x63, 0x6b, 0x22,
+	0x86, 0x01, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x55, 0x6e, 0x69, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x08, 0x55, 0x6e, 0x69, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22,
+	0x6a, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65,
+	0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x2d, 0x0a, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x41, 0x72, 0x65, 0x61, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x44, 0x0a, 0x08, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x4a, 0x0a, 0x06, 0x4c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0xfb, 0x01,
+	0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f,
+	0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x12, 0x2f, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65,
+	0x49, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x4c, 0x65, 0x61,
+	0x67, 0x75, 0x65, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0xb8, 0x02, 0x0a, 0x07,
+	0x4e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x39, 0x0a, 0x18, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x69, 0x76, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x31,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x31, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x17, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69,
+	0x6e, 0x67, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x68,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x5f,
+	0x63, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x43,
+	0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x92, 0x02, 0x0a, 0x08, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x12, 0x4a, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x77, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61,
+	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0f, 0x63, 0x68,
+	0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x36, 0x0a, 0x0d, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x77, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x75,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x0b,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x13,
+	0x48, 0x65, 0x72, 0x6f, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x68, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x68, 0x70, 0x5f, 0x6e, 0x6f, 0x77, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x68, 0x70, 0x4e, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72,
+	0x6d, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x74, 0x61, 0x63, 0x6b,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x04, 0x08,
+	0x08, 0x10, 0x09, 0x22, 0xac, 0x01, 0x0a, 0x16, 0x41, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x43,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x50, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x69, 0x6c, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6c, 0x53, 0x70, 0x65,
+	0x65, 0x64, 0x22, 0xa9, 0x01, 0x0a, 0x07, 0x41, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x41, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x43, 0x68, 0x61,
+	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0f, 0x63, 0x68,
+	0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x22, 0xb2,
+	0x02, 0x0a, 0x04, 0x48, 0x65, 0x72, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x63,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48,
+	0x65, 0x72, 0x6f, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x75, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65,
+	0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x6f, 0x55, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x09, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x41, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x09, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x77, 0x73, 0x2e, 0x48, 0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x73, 0x22, 0xea, 0x01, 0x0a, 0x13, 0x55, 0x6e, 0x69, 0x74, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x68,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x68, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x68,
+	0x70, 0x5f, 0x6e, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x68, 0x70, 0x4e,
+	0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x61, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64,
+	0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x5f, 0x63, 0x6f,
+	0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x43, 0x6f, 0x66,
+	0x22, 0x9d, 0x02, 0x0a, 0x04, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x46, 0x0a,
+	0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x55, 0x6e, 0x69, 0x74, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x75, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x6f, 0x55, 0x70, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64,
+	0x12, 0x2e, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e,
+	0x48, 0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x22, 0xef, 0x01, 0x0a, 0x14, 0x45, 0x6e, 0x65, 0x6d, 0x79, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x68, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72, 0x6d,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x72, 0x6d, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x74, 0x61, 0x63, 0x6b, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x22, 0xba, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x65, 0x6d, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x47, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x5f, 0x77, 0x73, 0x2e, 0x45, 0x6e, 0x65, 0x6d, 0x79, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74,
+	0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x2e, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48,
+	0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x22,
+	0xd8, 0x02, 0x0a, 0x08, 0x41, 0x72, 0x65, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x65, 0x6c, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x6e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77,
+	0x73, 0x2e, 0x4e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c, 0x52, 0x08, 0x6e, 0x65, 0x75, 0x74, 0x72,
+	0x61, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48,
+	0x65, 0x72, 0x6f, 0x52, 0x06, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x77, 0x73, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x12, 0x28, 0x0a, 0x07, 0x65, 0x6e, 0x65, 0x6d, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x45, 0x6e, 0x65, 0x6d,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x65, 0x6d, 0x69, 0x65, 0x73, 0x22, 0xcd, 0x02, 0x0a, 0x0a, 0x57,
+	0x6f, 0x72, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x72, 0x65, 0x61, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2c, 0x0a, 0x08, 0x6e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77,
+	0x73, 0x2e, 0x4e, 0x65, 0x75, 0x74, 0x72, 0x61, 0x6c, 0x52, 0x08, 0x6e, 0x65, 0x75, 0x74, 0x72,
+	0x61, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48,
+	0x65, 0x72, 0x6f, 0x52, 0x06, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x77, 0x73, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x12, 0x28, 0x0a, 0x07, 0x65, 0x6e, 0x65, 0x6d, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x45, 0x6e, 0x65, 0x6d,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x65, 0x6d, 0x69, 0x65, 0x73, 0x22, 0x2f, 0x0a, 0x09, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd3, 0x01, 0x0a, 0x0b,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x2e, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x77, 0x73, 0x2e, 0x48,
+	0x65, 0x78, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x13, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x02, 0x68,
+	0x70, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12,
+	0x2f, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x42, 0x05, 0x0a, 0x03, 0x5f, 0x68, 0x70, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x22, 0x83, 0x02, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x44, 0x65, 0x6c, 0x74, 0x61,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x38,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2e, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x5f, 0x77, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x5f, 0x77, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x52, 0x07, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x77, 0x73, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x10, 0x5a, 0x0e, 0x63, 0x79, 0x62, 0x65, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x77, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_websocket_proto_rawDescOnce sync.Once
+	file_websocket_proto_rawDescData = file_websocket_proto_rawDesc
+)
+
+func file_websocket_proto_rawDescGZIP() []byte {
+	file_websocket_proto_rawDescOnce.Do(func() {
+		file_websocket_proto_rawDescData = protoimpl.X.CompressGZIP(file_websocket_proto_rawDescData)
+	})
+	return file_websocket_proto_rawDescData
+}
+
+var file_websocket_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_websocket_proto_goTypes = []any{
+	(*Envelope)(nil),                 // 0: game_ws.Envelope
+	(*Error)(nil),                    // 1: game_ws.Error
+	(*FieldError)(nil),               // 2: game_ws.FieldError
+	(*Hex)(nil),                      // 3: game_ws.Hex
+	(*ActionPayload)(nil),            // 4: game_ws.ActionPayload
+	(*MoveCharacteristics)(nil),      // 5: game_ws.MoveCharacteristics
+	(*HarvestCharacteristics)(nil),   // 6: game_ws.HarvestCharacteristics
+	(*BuildCharacteristics)(nil),     // 7: game_ws.BuildCharacteristics
+	(*AttackCharacteristics)(nil),    // 8: game_ws.AttackCharacteristics
+	(*UpgradeCharacteristics)(nil),   // 9: game_ws.UpgradeCharacteristics
+	(*GroupMoveCharacteristics)(nil), // 10: game_ws.GroupMoveCharacteristics
+	(*SubscribePayload)(nil),         // 11: game_ws.SubscribePayload
+	(*SyncWorldPayload)(nil),         // 12: game_ws.SyncWorldPayload
+	(*ActionResult)(nil),             // 13: game_ws.ActionResult
+	(*UnitPath)(nil),                 // 14: game_ws.UnitPath
+	(*SubscribeResult)(nil),          // 15: game_ws.SubscribeResult
+	(*Resource)(nil),                 // 16: game_ws.Resource
+	(*League)(nil),                   // 17: game_ws.League
+	(*UserData)(nil),                 // 18: game_ws.UserData
+	(*Neutral)(nil),                  // 19: game_ws.Neutral
+	(*BuildingCharacteristics)(nil),  // 20: game_ws.BuildingCharacteristics
+	(*Building)(nil),                 // 21: game_ws.Building
+	(*HeroCharacteristics)(nil),      // 22: game_ws.HeroCharacteristics
+	(*AbilityCharacteristics)(nil),   // 23: game_ws.AbilityCharacteristics
+	(*Ability)(nil),                  // 24: game_ws.Ability
+	(*Hero)(nil),                     // 25: game_ws.Hero
+	(*UnitCharacteristics)(nil),      // 26: game_ws.UnitCharacteristics
+	(*Unit)(nil),                     // 27: game_ws.Unit
+	(*EnemyCharacteristics)(nil),     // 28: game_ws.EnemyCharacteristics
+	(*Enemy)(nil),                    // 29: game_ws.Enemy
+	(*AreaData)(nil),                 // 30: game_ws.AreaData
+	(*WorldState)(nil),               // 31: game_ws.WorldState
+	(*ObjectRef)(nil),                // 32: game_ws.ObjectRef
+	(*ObjectDelta)(nil),              // 33: game_ws.ObjectDelta
+	(*WorldDelta)(nil),               // 34: game_ws.WorldDelta
+	(*timestamppb.Timestamp)(nil),    // 35: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),          // 36: google.protobuf.Struct
+}
+var file_websocket_proto_depIdxs = []int32{
+	1,  // 0: game_ws.Envelope.error:type_name -> game_ws.Error
+	4,  // 1: game_ws.Envelope.action:type_name -> game_ws.ActionPayload
+	11, // 2: game_ws.Envelope.subscribe:type_name -> game_ws.SubscribePayload
+	12, // 3: game_ws.Envelope.sync_world:type_name -> game_ws.SyncWorldPayload
+	13, // 4: game_ws.Envelope.result:type_name -> game_ws.ActionResult
+	15, // 5: game_ws.Envelope.subscribed:type_name -> game_ws.SubscribeResult
+	31, // 6: game_ws.Envelope.world_state:type_name -> game_ws.WorldState
+	30, // 7: game_ws.Envelope.area_data:type_name -> game_ws.AreaData
+	18, // 8: game_ws.Envelope.user_data:type_name -> game_ws.UserData
+	34, // 9: game_ws.Envelope.world_delta:type_name -> game_ws.WorldDelta
+	2,  // 10: game_ws.Error.fields:type_name -> game_ws.FieldError
+	5,  // 11: game_ws.ActionPayload.move:type_name -> game_ws.MoveCharacteristics
+	6,  // 12: game_ws.ActionPayload.harvest:type_name -> game_ws.HarvestCharacteristics
+	7,  // 13: game_ws.ActionPayload.build:type_name -> game_ws.BuildCharacteristics
+	8,  // 14: game_ws.ActionPayload.attack:type_name -> game_ws.AttackCharacteristics
+	9,  // 15: game_ws.ActionPayload.upgrade:type_name -> game_ws.UpgradeCharacteristics
+	10, // 16: game_ws.ActionPayload.group_move:type_name -> game_ws.GroupMoveCharacteristics
+	3,  // 17: game_ws.MoveCharacteristics.from:type_name -> game_ws.Hex
+	3,  // 18: game_ws.MoveCharacteristics.to:type_name -> game_ws.Hex
+	3,  // 19: game_ws.BuildCharacteristics.place:type_name -> game_ws.Hex
+	3,  // 20: game_ws.GroupMoveCharacteristics.to:type_name -> game_ws.Hex
+	14, // 21: game_ws.ActionResult.paths:type_name -> game_ws.UnitPath
+	3,  // 22: game_ws.UnitPath.path:type_name -> game_ws.Hex
+	30, // 23: game_ws.SubscribeResult.snapshot:type_name -> game_ws.AreaData
+	16, // 24: game_ws.UserData.resources:type_name -> game_ws.Resource
+	17, // 25: game_ws.UserData.league:type_name -> game_ws.League
+	3,  // 26: game_ws.Neutral.coordinates:type_name -> game_ws.Hex
+	20, // 27: game_ws.Building.characteristics:type_name -> game_ws.BuildingCharacteristics
+	16, // 28: game_ws.Building.upgrade_price:type_name -> game_ws.Resource
+	3,  // 29: game_ws.Building.coordinates:type_name -> game_ws.Hex
+	23, // 30: game_ws.Ability.characteristics:type_name -> game_ws.AbilityCharacteristics
+	22, // 31: game_ws.Hero.characteristics:type_name -> game_ws.HeroCharacteristics
+	24, // 32: game_ws.Hero.abilities:type_name -> game_ws.Ability
+	3,  // 33: game_ws.Hero.coordinates:type_name -> game_ws.Hex
+	26, // 34: game_ws.Unit.characteristics:type_name -> game_ws.UnitCharacteristics
+	3,  // 35: game_ws.Unit.coordinates:type_name -> game_ws.Hex
+	28, // 36: game_ws.Enemy.characteristics:type_name -> game_ws.EnemyCharacteristics
+	3,  // 37: game_ws.Enemy.coordinates:type_name -> game_ws.Hex
+	19, // 38: game_ws.AreaData.neutrals:type_name -> game_ws.Neutral
+	21, // 39: game_ws.AreaData.buildings:type_name -> game_ws.Building
+	25, // 40: game_ws.AreaData.heroes:type_name -> game_ws.Hero
+	27, // 41: game_ws.AreaData.units:type_name -> game_ws.Unit
+	29, // 42: game_ws.AreaData.enemies:type_name -> game_ws.Enemy
+	35, // 43: game_ws.WorldState.timestamp:type_name -> google.protobuf.Timestamp
+	19, // 44: game_ws.WorldState.neutrals:type_name -> game_ws.Neutral
+	21, // 45: game_ws.WorldState.buildings:type_name -> game_ws.Building
+	25, // 46: game_ws.WorldState.heroes:type_name -> game_ws.Hero
+	27, // 47: game_ws.WorldState.units:type_name -> game_ws.Unit
+	29, // 48: game_ws.WorldState.enemies:type_name -> game_ws.Enemy
+	3,  // 49: game_ws.ObjectDelta.coordinates:type_name -> game_ws.Hex
+	36, // 50: game_ws.ObjectDelta.object:type_name -> google.protobuf.Struct
+	35, // 51: game_ws.WorldDelta.timestamp:type_name -> google.protobuf.Timestamp
+	33, // 52: game_ws.WorldDelta.changed:type_name -> game_ws.ObjectDelta
+	32, // 53: game_ws.WorldDelta.removed:type_name -> game_ws.ObjectRef
+	31, // 54: game_ws.WorldDelta.snapshot:type_name -> game_ws.WorldState
+	55, // [55:55] is the sub-list for method output_type
+	55, // [55:55] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
+}
+
+func init() { file_websocket_proto_init() }
+func file_websocket_proto_init() {
+	if File_websocket_proto != nil {
+		return
+	}
+	file_websocket_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_Json)(nil),
+		(*Envelope_Action)(nil),
+		(*Envelope_Subscribe)(nil),
+		(*Envelope_SyncWorld)(nil),
+		(*Envelope_Result)(nil),
+		(*Envelope_Subscribed)(nil),
+		(*Envelope_WorldState)(nil),
+		(*Envelope_AreaData)(nil),
+		(*Envelope_UserData)(nil),
+		(*Envelope_WorldDelta)(nil),
+	}
+	file_websocket_proto_msgTypes[4].OneofWrappers = []any{
+		(*ActionPayload_Move)(nil),
+		(*ActionPayload_Harvest)(nil),
+		(*ActionPayload_Build)(nil),
+		(*ActionPayload_Attack)(nil),
+		(*ActionPayload_Upgrade)(nil),
+		(*ActionPayload_GroupMove)(nil),
+	}
+	file_websocket_proto_msgTypes[11].OneofWrappers = []any{}
+	file_websocket_proto_msgTypes[12].OneofWrappers = []any{}
+	file_websocket_proto_msgTypes[33].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_websocket_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_websocket_proto_goTypes,
+		DependencyIndexes: file_websocket_proto_depIdxs,
+		MessageInfos:      file_websocket_proto_msgTypes,
+	}.Build()
+	File_websocket_proto = out.File
+	file_websocket_proto_rawDesc = nil
+	file_websocket_proto_goTypes = nil
+	file_websocket_proto_depIdxs = nil
+}
@@ -1,7 +1,7 @@
 -- Удаление таблиц, если они существуют
 DROP TABLE IF EXISTS leagues, users, resources, user_resources, neutrals, buildings, heroes, abilities, 
 hero_ability, units, enemies, areas, areas_neutrals, areas_buildings, areas_heroes, 
-areas_units, areas_enemies, actions, world_state;
+areas_units, areas_enemies, actions, world_state, resource_transactions;
 
 -- Таблица leagues (лиги)
 CREATE TABLE leagues (
@@ -14,7 +14,7 @@ CREATE TABLE leagues (
 CREATE TABLE users (
     id SERIAL PRIMARY KEY,
     login VARCHAR(255) UNIQUE NOT NULL,
-    password VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL, -- bcrypt хеш пароля
     email VARCHAR(255) UNIQUE NOT NULL,
     subscription BOOLEAN DEFAULT FALSE,
     league_id BIGINT REFERENCES leagues(id) ON DELETE SET NULL,
@@ -33,9 +33,22 @@ CREATE TABLE resources (
 CREATE TABLE user_resources (
     resource_id BIGINT NOT NULL REFERENCES resources(id),
     user_id BIGINT NOT NULL REFERENCES users(id),
+    amount DECIMAL(19, 2) NOT NULL DEFAULT 0 CHECK(amount >= 0), -- количество ресурса у пользователя
   	PRIMARY KEY  (resource_id,user_id)    
 );
 
+-- История изменений ресурсов пользователей (только добавление записей)
+CREATE TABLE resource_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id), -- без каскадного удаления: история не удаляется
+    resource_id BIGINT NOT NULL REFERENCES resources(id),
+    amount DECIMAL(19, 2) NOT NULL, -- изменение количества, отрицательное - списание
+    reason VARCHAR(32) NOT NULL CHECK(reason IN ('harvest', 'build', 'upgrade', 'trade')),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE RULE resource_transactions_no_update AS ON UPDATE TO resource_transactions DO INSTEAD NOTHING;
+CREATE RULE resource_transactions_no_delete AS ON DELETE TO resource_transactions DO INSTEAD NOTHING;
+
 -- Создание таблицы neutrals (нейтральные объекты на арене)
 CREATE TABLE neutrals (
     id BIGSERIAL PRIMARY KEY,                    
@@ -56,7 +69,7 @@ CREATE TABLE buildings (
     product VARCHAR(255) NOT NULL,
     characteristics JSONB NOT NULL,
     level INTEGER DEFAULT 1,
-    upgrade_price DECIMAL(19, 2) DEFAULT 0,
+    upgrade_price JSONB NOT NULL DEFAULT '[]', -- список ресурсов [{"name": "Gold", "value": 500}]
     size INTEGER NOT NULL,
     coordinates JSONB NOT NULL 
 );
@@ -148,6 +161,7 @@ CREATE TABLE areas_units (
 CREATE TABLE areas_enemies (
     area_id BIGINT NOT NULL REFERENCES areas(id) ON DELETE CASCADE,
     enemy_id BIGINT NOT NULL REFERENCES enemies(id) ON DELETE CASCADE,
+    camp INTEGER, -- Номер лагеря на арене, NULL - враг волны
     PRIMARY KEY (area_id, enemy_id)
 );
 
@@ -158,7 +172,8 @@ CREATE TABLE actions (
     area_id BIGINT NOT NULL REFERENCES areas(id) ON DELETE CASCADE,
     object_source_id BIGINT,
     object_dest_id BIGINT,
-    action_type SMALLINT NOT NULL CHECK(action_type BETWEEN 1 AND 4),
+    action_type SMALLINT NOT NULL CHECK(action_type BETWEEN 1 AND 6),
+    characteristics JSONB NOT NULL DEFAULT '{}', -- характеристики действия, зависят от типа
     start_time TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     duration INTERVAL DEFAULT '00:00:00',
     status VARCHAR(255) DEFAULT ''  --Предусмотрено 3 статуса 1 - DONE, 2- NOT DONE , 3- PROCESS
@@ -176,6 +191,7 @@ CREATE TABLE world_state (
 -- Индексы
 CREATE INDEX idx_user_resources_user_id ON user_resources(user_id);
 CREATE INDEX idx_actions_user_id ON actions(user_id);
+CREATE INDEX idx_resource_transactions_user_id ON resource_transactions(user_id);
 
 -- Наполнение таблицы leagues (лиги)
 INSERT INTO leagues (name, authority) VALUES
@@ -184,12 +200,12 @@ INSERT INTO leagues (name, authority) VALUES
 ('Gold League', 300),
 ('Platinum League', 400);
 
--- Наполнение таблицы users (пользователи)
+-- Наполнение таблицы users (пользователи). Пароли - bcrypt хеши строк password1..password4
 INSERT INTO users (login, password, email, subscription, league_id, balance, level) VALUES
-('user1', 'password1', 'user1@example.com', TRUE, 1, 1000.00, 5),
-('user2', 'password2', 'user2@example.com', FALSE, 2, 500.00, 3),
-('user3', 'password3', 'user3@example.com', TRUE, 3, 750.00, 7),
-('user4', 'password4', 'user4@example.com', FALSE, 4, 300.00, 2);
+('user1', '$2a$10$IojM1vxAGxnRUGqLJTgisOS5B3K2zKQbWb/9nElVfKKSsVIw8qu5a', 'user1@example.com', TRUE, 1, 1000.00, 5),
+('user2', '$2a$10$mEnu3xQbfpZt1SvgP61qwOQw/TTe0uIz0/3W5Fwoq.sM0CT8XOn3G', 'user2@example.com', FALSE, 2, 500.00, 3),
+('user3', '$2a$10$zewZRwchxXTeBWPkBQACYenq7rPXwe4LTtTjswuo5n3N/kgKQrVyO', 'user3@example.com', TRUE, 3, 750.00, 7),
+('user4', '$2a$10$oYwVuPecj4yjDFrxFguJ6ujDeVtTuiG/QkMdikzJgABBO/Mb7Cyqa', 'user4@example.com', FALSE, 4, 300.00, 2);
 
 -- Наполнение таблицы resources (ресурсы)
 INSERT INTO resources (name, value) VALUES
@@ -199,11 +215,11 @@ INSERT INTO resources (name, value) VALUES
 ('Food', 0);
 
 -- Наполнение таблицы user_resources (ресурсы пользователей)
-INSERT INTO user_resources (resource_id, user_id) VALUES
-(1, 1), (2, 1), (3, 1), (4, 1),
-(1, 2), (2, 2),
-(1, 3), (3, 3),
-(1, 4), (4, 4);
+INSERT INTO user_resources (resource_id, user_id, amount) VALUES
+(1, 1, 1500), (2, 1, 800), (3, 1, 400), (4, 1, 300),
+(1, 2, 700), (2, 2, 300),
+(1, 3, 1000), (3, 3, 250),
+(1, 4, 200), (4, 4, 100);
 
 -- Наполнение таблицы neutrals (нейтральные объекты)
 INSERT INTO neutrals (name, product, productivity_coefficient, capacity, threshold_level1, threshold_level2, size, coordinates) VALUES
@@ -214,10 +230,10 @@ INSERT INTO neutrals (name, product, productivity_coefficient, capacity, thresho
 
 -- Наполнение таблицы buildings (здания)
 INSERT INTO buildings (name, product, characteristics, level, upgrade_price, size, coordinates) VALUES
-('Town Hall', 'Gold', '{"hp": 1000, "defense": 50}', 1, 500.00, 10, '[{"q": 70, "r": 80}]'),
-('Barracks', 'Units', '{"hp": 800, "defense": 30}', 1, 300.00, 8, '[{"q": 90, "r": 100}]'),
-('Farm', 'Food', '{"hp": 600, "defense": 20}', 1, 200.00, 6, '[{"q": 110, "r": 120}]'),
-('Large Castle', 'Gold', '{"hp": 2000, "defense": 100}', 1, 1000.00, 4, '[{"q": 10, "r": 20}, {"q": 11, "r": 20}, {"q": 10, "r": 21}, {"q": 11, "r": 21}]');
+('Town Hall', 'Gold', '{"hp": 1000, "defense": 50}', 1, '[{"name": "Gold", "value": 500}]', 10, '[{"q": 70, "r": 80}]'),
+('Barracks', 'Units', '{"hp": 800, "defense": 30}', 1, '[{"name": "Gold", "value": 300}]', 8, '[{"q": 90, "r": 100}]'),
+('Farm', 'Food', '{"hp": 600, "defense": 20}', 1, '[{"name": "Gold", "value": 150}, {"name": "Wood", "value": 50}]', 6, '[{"q": 110, "r": 120}]'),
+('Large Castle', 'Gold', '{"hp": 2000, "defense": 100}', 1, '[{"name": "Gold", "value": 700}, {"name": "Stone", "value": 300}]', 4, '[{"q": 10, "r": 20}, {"q": 11, "r": 20}, {"q": 10, "r": 21}, {"q": 11, "r": 21}]');
 
 -- Наполнение таблицы abilities (способности)
 INSERT INTO abilities (name, characteristics, level) VALUES